@@ -0,0 +1,419 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/cyberark/terraform-provider-cyberark/internal/importnames"
+)
+
+// exportKinds lists the kinds of objects that can be exported.
+var exportKinds = []string{"safes", "accounts", "secret_stores", "sync_policies"}
+
+// options controls what is exported.
+type options struct {
+	// SelfHosted exports safes and accounts from the self-hosted vault.
+	SelfHosted bool
+	// Include lists the kinds of objects to export.
+	Include []string
+	// SafePatterns limits safes, accounts and sync policies to safes matching any of the glob patterns.
+	SafePatterns []string
+	// PlatformPatterns limits accounts to platforms matching any of the glob patterns.
+	PlatformPatterns []string
+}
+
+// exporter walks the vault and Secrets Hub and renders the objects it finds as Terraform configuration.
+type exporter struct {
+	pam        cybrapi.PAMAPI
+	secretsHub cybrapi.SecretsHubAPI
+	opts       options
+
+	labels    importnames.Labeler
+	resources map[string][]*block
+	imports   []*block
+	variables []*block
+
+	// storeRefs maps secret store IDs to the address of the exported resource.
+	storeRefs map[string]string
+}
+
+// newExporter creates an exporter for the given clients. Either client may be nil when the
+// corresponding kinds of objects are not exported.
+func newExporter(pam cybrapi.PAMAPI, secretsHub cybrapi.SecretsHubAPI, opts options) *exporter {
+	if len(opts.Include) == 0 {
+		opts.Include = exportKinds
+	}
+
+	return &exporter{
+		pam:        pam,
+		secretsHub: secretsHub,
+		opts:       opts,
+		labels:     importnames.Labeler{},
+		resources:  map[string][]*block{},
+		storeRefs:  map[string]string{},
+	}
+}
+
+// includes reports whether the given kind of object is exported.
+func (e *exporter) includes(kind string) bool {
+	return slices.Contains(e.opts.Include, kind)
+}
+
+// run walks all included objects.
+func (e *exporter) run(ctx context.Context) error {
+	if e.includes("safes") || e.includes("accounts") {
+		if err := e.exportSafes(ctx); err != nil {
+			return err
+		}
+	}
+
+	// Secret stores are exported before sync policies so that policies can reference them
+	if e.includes("secret_stores") {
+		if err := e.exportSecretStores(ctx); err != nil {
+			return err
+		}
+	}
+
+	if e.includes("sync_policies") {
+		if err := e.exportSyncPolicies(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// files returns the generated configuration keyed by file name. Files without content are omitted.
+func (e *exporter) files() map[string]string {
+	files := map[string]string{}
+	for name, blocks := range e.resources {
+		files[name] = renderBlocks(blocks)
+	}
+	if len(e.imports) > 0 {
+		files["imports.tf"] = renderBlocks(e.imports)
+	}
+	if len(e.variables) > 0 {
+		files["variables.tf"] = renderBlocks(e.variables)
+	}
+	return files
+}
+
+// addResource records a resource block together with the import block that adopts it and
+// returns the block and its label.
+func (e *exporter) addResource(file, resourceType, name, importID string) (*block, string) {
+	label := e.labels.Label(resourceType, name)
+	address := resourceType + "." + label
+
+	resource := &block{header: fmt.Sprintf("resource %q %q", resourceType, label)}
+	e.resources[file] = append(e.resources[file], resource)
+
+	imp := &block{header: "import"}
+	imp.set("to", address)
+	imp.set("id", quote(importID))
+	e.imports = append(e.imports, imp)
+
+	return resource, label
+}
+
+// addSecretVariable declares a sensitive variable and returns the expression referencing it.
+func (e *exporter) addSecretVariable(name, description string) string {
+	variable := &block{header: fmt.Sprintf("variable %q", name)}
+	variable.set("description", quote(description))
+	variable.set("type", "string")
+	variable.set("sensitive", "true")
+	e.variables = append(e.variables, variable)
+
+	return "var." + name
+}
+
+// vaultImportID returns the import ID of a safe or account, taking the backend into account.
+func (e *exporter) vaultImportID(id string) string {
+	if e.opts.SelfHosted {
+		return "self_hosted:" + id
+	}
+	return id
+}
+
+// setBackend selects the self-hosted vault on a safe or account.
+func (e *exporter) setBackend(b *block) {
+	if e.opts.SelfHosted {
+		b.set("backend", quote("self_hosted"))
+	}
+}
+
+// safeMatches reports whether the safe name matches the safe filters.
+func (e *exporter) safeMatches(name string) bool {
+	return matchesAny(e.opts.SafePatterns, name)
+}
+
+// exportSafes exports the matching safes, their members and their accounts.
+func (e *exporter) exportSafes(ctx context.Context) error {
+	safes, err := e.pam.ListSafes(ctx)
+	if err != nil {
+		return fmt.Errorf("listing safes: %w", err)
+	}
+
+	for _, safe := range safes {
+		if safe.Name == nil || safe.URLID == nil || !e.safeMatches(*safe.Name) {
+			continue
+		}
+
+		if e.includes("safes") {
+			members, err := e.pam.ListSafeMembers(ctx, *safe.Name)
+			if err != nil {
+				return fmt.Errorf("listing members of safe %s: %w", *safe.Name, err)
+			}
+			e.exportSafe(safe, members)
+		}
+
+		if e.includes("accounts") {
+			accounts, err := e.pam.ListAccounts(ctx, *safe.Name)
+			if err != nil {
+				return fmt.Errorf("listing accounts in safe %s: %w", *safe.Name, err)
+			}
+			for _, account := range accounts {
+				e.exportAccount(account)
+			}
+		}
+	}
+
+	return nil
+}
+
+// exportSafe renders a safe. The first member that is not predefined and has one of the
+// standard permission levels becomes the seed member; other members are listed as comments.
+func (e *exporter) exportSafe(safe *cybrapi.SafeData, members []*cybrapi.Member) {
+	b, _ := e.addResource("safes.tf", "cyberark_safe", *safe.Name, e.vaultImportID(*safe.URLID))
+	e.setBackend(b)
+	b.setString("safe_name", safe.Name)
+	b.setString("safe_desc", safe.Description)
+	b.setString("safe_loc", safe.Location)
+	b.setString("cpm_name", safe.CPM)
+	b.setInt("retention", safe.RetentionDays)
+	b.setInt("retention_versions", safe.RetentionVersions)
+	b.setBool("purge", safe.PurgeEnabled)
+	b.setBool("enable_olac", safe.EnableOLAC)
+
+	var seed *cybrapi.Member
+	for _, member := range members {
+		if member.Member == nil || (member.IsPredefinedUser != nil && *member.IsPredefinedUser) {
+			continue
+		}
+
+		level := cybrapi.PermissionLevel(member.Perm)
+		if seed == nil && level != "" {
+			seed = member
+			b.setString("member", member.Member)
+			b.setString("member_type", member.MemberType)
+			b.set("permission_level", quote(level))
+			continue
+		}
+
+		if level == "" {
+			level = "custom"
+		}
+		b.comments = append(b.comments, fmt.Sprintf("Additional member: %s (%s, %s permissions)",
+			*member.Member, cybrapi.StringValue(member.MemberType), level))
+	}
+
+	if seed == nil {
+		b.comments = append(b.comments,
+			"No member with a standard permission level was found; set member, member_type and permission_level.")
+	}
+}
+
+// exportAccount renders an account as the resource type matching its platform properties.
+func (e *exporter) exportAccount(account *cybrapi.CredentialResponse) {
+	if account.CredID == nil || account.Name == nil || !matchesAny(e.opts.PlatformPatterns, cybrapi.StringValue(account.Platform)) {
+		return
+	}
+
+	kind := importnames.AccountKind(account)
+	b, label := e.addResource("accounts.tf", "cyberark_"+kind, *account.Name, e.vaultImportID(*account.CredID))
+	e.setBackend(b)
+
+	b.setString("name", account.Name)
+	b.setString("address", account.Address)
+	b.setString("username", account.UserName)
+	b.setString("platform", account.Platform)
+	b.setString("safe", account.SafeName)
+	b.setString("secret_type", account.SecretType)
+	b.set("secret", e.addSecretVariable(fmt.Sprintf("%s_%s_secret", kind, label),
+		fmt.Sprintf("Secret of account %s in safe %s", *account.Name, cybrapi.StringValue(account.SafeName))))
+
+	if account.SecretMgmt != nil {
+		b.setBool("sm_manage", account.SecretMgmt.AutomaticManagement)
+		b.setString("sm_manage_reason", account.SecretMgmt.ManualManagementReason)
+	}
+
+	if access := account.RemoteAccess; access != nil && cybrapi.StringValue(access.RemoteMachines) != "" {
+		var machines []string
+		for _, machine := range strings.Split(*access.RemoteMachines, ";") {
+			if machine = strings.TrimSpace(machine); machine != "" {
+				machines = append(machines, quote(machine))
+			}
+		}
+		b.set("remote_machines", "["+strings.Join(machines, ", ")+"]")
+		b.setBool("access_restricted_to_remote_machines", access.AccessRestrictedToRemoteMachines)
+	}
+
+	props := account.Props
+	if props == nil {
+		return
+	}
+
+	switch kind {
+	case "aws_account":
+		b.setString("aws_kid", props.AWSKID)
+		b.setString("aws_account_id", props.AWSAccount)
+		b.setString("aws_alias", props.Alias)
+		b.setString("aws_account_region", props.Region)
+	case "azure_account":
+		b.setString("ms_app_id", props.MAppID)
+		b.setString("ms_app_obj_id", props.MAppObjectID)
+		b.setString("ms_key_id", props.MKID)
+		b.setString("ms_ad_id", props.MADID)
+		b.setString("ms_duration", props.MDur)
+		b.setString("ms_pop", props.MPop)
+		b.setString("ms_key_desc", props.MKeyDesc)
+	default:
+		b.setString("db_port", props.Port)
+		b.setString("dbname", props.DBName)
+		b.setString("db_dsn", props.DSN)
+	}
+	b.setString("secret_name_in_secret_store", props.SecretNameInSecretStore)
+}
+
+// exportSecretStores renders the AWS, Azure and GCP secret stores.
+func (e *exporter) exportSecretStores(ctx context.Context) error {
+	awsStores, err := e.secretsHub.GetAwsAsmSecretStores(ctx)
+	if err != nil {
+		return fmt.Errorf("listing AWS secret stores: %w", err)
+	}
+	for _, store := range awsStores.SecretStores {
+		b, _ := e.addSecretStore("cyberark_aws_secret_store", store.ID, store.Name, store.Description)
+		if data := store.Data; data != nil {
+			b.setString("aws_account_alias", data.AccountAlias)
+			b.setString("aws_account_id", data.AccountID)
+			b.setString("aws_account_region", data.RegionID)
+			b.setString("aws_iam_role", data.RoleName)
+		}
+	}
+
+	azureStores, err := e.secretsHub.GetAzureAkvSecretStores(ctx)
+	if err != nil {
+		return fmt.Errorf("listing Azure secret stores: %w", err)
+	}
+	for _, store := range azureStores.SecretStores {
+		b, label := e.addSecretStore("cyberark_azure_secret_store", store.ID, store.Name, store.Description)
+		if data := store.Data; data != nil {
+			b.setString("azure_app_client_directory_id", data.AppClientDirectoryID)
+			b.setString("azure_vault_url", data.AzureVaultURL)
+			b.setString("azure_app_client_id", data.AppClientID)
+			b.set("azure_app_client_secret", e.addSecretVariable(label+"_app_client_secret",
+				fmt.Sprintf("Application client secret of Azure secret store %s", cybrapi.StringValue(store.Name))))
+			if data.Connector != nil {
+				b.setString("connection_type", data.Connector.ConnectionType)
+				b.setString("connector_id", data.Connector.ConnectorID)
+				b.setString("connector_pool_id", data.Connector.ConnectorPoolID)
+			}
+			b.setString("subscription_id", data.SubscriptionID)
+			b.setString("subscription_name", data.SubscriptionName)
+			b.setString("resource_group_name", data.ResourceGroupName)
+		}
+	}
+
+	gcpStores, err := e.secretsHub.GetGcpSecretStores(ctx)
+	if err != nil {
+		return fmt.Errorf("listing GCP secret stores: %w", err)
+	}
+	for _, store := range gcpStores.SecretStores {
+		b, _ := e.addSecretStore("cyberark_gcp_secret_store", store.ID, store.Name, store.Description)
+		if data := store.Data; data != nil {
+			b.setString("gcp_project_name", data.GcpProjectName)
+			b.setString("gcp_project_number", data.GcpProjectNumber)
+			b.setString("gcp_workload_identity_pool_id", data.GcpWorkloadIdentityPoolId)
+			b.setString("gcp_pool_provider_id", data.GcpPoolProviderId)
+			b.setString("service_account_email", data.ServiceAccountEmail)
+		}
+	}
+
+	return nil
+}
+
+// addSecretStore renders the attributes shared by all secret store types.
+func (e *exporter) addSecretStore(resourceType, id string, name, description *string) (*block, string) {
+	b, label := e.addResource("secret_stores.tf", resourceType, cybrapi.StringValue(name), id)
+	e.storeRefs[id] = resourceType + "." + label
+
+	b.setString("name", name)
+	b.setString("description", description)
+	return b, label
+}
+
+// exportSyncPolicies renders the sync policies whose safe matches the safe filters. Targets that
+// were exported as well are referenced by address rather than by ID.
+func (e *exporter) exportSyncPolicies(ctx context.Context) error {
+	policies, err := e.secretsHub.GetSyncPolicies(ctx)
+	if err != nil {
+		return fmt.Errorf("listing sync policies: %w", err)
+	}
+
+	for _, policy := range policies.Policies {
+		if policy.ID == nil || policy.Source == nil || policy.Target == nil {
+			continue
+		}
+
+		var safeName *string
+		if policy.Filter != nil && policy.Filter.ID != nil {
+			filter, err := e.secretsHub.GetSecretFilter(ctx, policy.Source.SourceID, *policy.Filter.ID)
+			if err != nil {
+				return fmt.Errorf("reading filter of sync policy %s: %w", *policy.ID, err)
+			}
+			if filter.Data != nil {
+				safeName = filter.Data.SafeName
+			}
+		}
+
+		if !e.safeMatches(cybrapi.StringValue(safeName)) {
+			continue
+		}
+
+		b, _ := e.addResource("sync_policies.tf", "cyberark_sync_policy", cybrapi.StringValue(policy.Name), *policy.ID)
+		b.setString("name", policy.Name)
+		b.setString("description", policy.Description)
+		b.set("source_id", e.storeReference(policy.Source.SourceID))
+		b.set("target_id", e.storeReference(policy.Target.TargetID))
+		b.setString("safe_name", safeName)
+		if policy.Transformation != nil && policy.Transformation.Predefined != "" && policy.Transformation.Predefined != "default" {
+			b.set("transformation", quote(policy.Transformation.Predefined))
+		}
+	}
+
+	return nil
+}
+
+// storeReference returns an expression for a secret store ID, referencing the exported resource if there is one.
+func (e *exporter) storeReference(id string) string {
+	if ref, ok := e.storeRefs[id]; ok {
+		return ref + ".id"
+	}
+	return quote(id)
+}
+
+// matchesAny reports whether value matches any of the glob patterns. An empty pattern list matches everything.
+func matchesAny(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, value); ok {
+			return true
+		}
+	}
+	return false
+}
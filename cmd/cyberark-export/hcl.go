@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// attribute is a single attribute of a rendered block. The value is a raw HCL expression.
+type attribute struct {
+	name  string
+	value string
+}
+
+// block is a Terraform block such as a resource, variable or import block.
+type block struct {
+	comments   []string
+	header     string
+	attributes []attribute
+}
+
+// set adds an attribute with a raw HCL expression value.
+func (b *block) set(name, expr string) {
+	b.attributes = append(b.attributes, attribute{name: name, value: expr})
+}
+
+// setString adds a string attribute, skipping it when value is nil.
+func (b *block) setString(name string, value *string) {
+	if value != nil {
+		b.set(name, quote(*value))
+	}
+}
+
+// setBool adds a bool attribute, skipping it when value is nil.
+func (b *block) setBool(name string, value *bool) {
+	if value != nil {
+		b.set(name, fmt.Sprintf("%t", *value))
+	}
+}
+
+// setInt adds a number attribute, skipping it when value is nil.
+func (b *block) setInt(name string, value *int64) {
+	if value != nil {
+		b.set(name, fmt.Sprintf("%d", *value))
+	}
+}
+
+// render writes the block the way terraform fmt would, aligning the equals signs.
+func (b *block) render(w *strings.Builder) {
+	for _, comment := range b.comments {
+		fmt.Fprintf(w, "# %s\n", comment)
+	}
+
+	width := 0
+	for _, attr := range b.attributes {
+		width = max(width, len(attr.name))
+	}
+
+	fmt.Fprintf(w, "%s {\n", b.header)
+	for _, attr := range b.attributes {
+		fmt.Fprintf(w, "  %-*s = %s\n", width, attr.name, attr.value)
+	}
+	w.WriteString("}\n")
+}
+
+// renderBlocks renders the blocks separated by blank lines.
+func renderBlocks(blocks []*block) string {
+	var w strings.Builder
+	for i, b := range blocks {
+		if i > 0 {
+			w.WriteString("\n")
+		}
+		b.render(&w)
+	}
+	return w.String()
+}
+
+// quote returns s as an HCL string literal, escaping template sequences.
+func quote(s string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+		"${", "$${",
+		"%{", "%%{",
+	)
+	return `"` + replacer.Replace(s) + `"`
+}
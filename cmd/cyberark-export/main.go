@@ -0,0 +1,222 @@
+// Package main provides cyberark-export, which generates Terraform configuration and import
+// blocks for the safes, accounts, secret stores and sync policies that already exist in a vault.
+//
+// Usage:
+//
+//	CYBERARK_CLIENT_SECRET=... cyberark-export -tenant abc1234 -domain example -client-id user@cyberark.cloud.1234 -out ./generated
+//
+// Secrets are never exported. Account secrets and Azure application client secrets are replaced
+// by sensitive variables that are declared in variables.tf.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+)
+
+const (
+	cloudAuthURL       = "https://%s.id.cyberark.cloud"
+	cloudPamURL        = "https://%s.privilegecloud.cyberark.cloud"
+	cloudSecretsHubURL = "https://%s.secretshub.cyberark.cloud"
+
+	clientSecretEnv = "CYBERARK_CLIENT_SECRET"
+	pvwaPasswordEnv = "CYBERARK_PVWA_PASSWORD"
+)
+
+// config holds the command line configuration.
+type config struct {
+	tenant          string
+	domain          string
+	clientID        string
+	backend         string
+	pvwaURL         string
+	pvwaUsername    string
+	pvwaLoginMethod string
+	identityURL     string
+	pamURL          string
+	secretsHubURL   string
+	outDir          string
+	include         string
+	safes           string
+	platforms       string
+}
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Getenv, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "cyberark-export: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the arguments, exports the vault and writes the generated files.
+func run(ctx context.Context, args []string, getenv func(string) string, stdout io.Writer) error {
+	cfg := config{}
+
+	flags := flag.NewFlagSet("cyberark-export", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	flags.StringVar(&cfg.tenant, "tenant", "", "CyberArk Shared Services tenant")
+	flags.StringVar(&cfg.domain, "domain", "", "CyberArk Privilege Cloud domain")
+	flags.StringVar(&cfg.clientID, "client-id", "", "CyberArk client ID; the secret is read from "+clientSecretEnv)
+	flags.StringVar(&cfg.backend, "backend", "privilege_cloud", "vault to export safes and accounts from: privilege_cloud or self_hosted")
+	flags.StringVar(&cfg.pvwaURL, "pvwa-url", "", "self-hosted PVWA URL")
+	flags.StringVar(&cfg.pvwaUsername, "pvwa-username", "", "self-hosted PVWA username; the password is read from "+pvwaPasswordEnv)
+	flags.StringVar(&cfg.pvwaLoginMethod, "pvwa-login-method", "cyberark", "self-hosted PVWA login method")
+	flags.StringVar(&cfg.identityURL, "identity-url", "", "override the Identity URL derived from -tenant")
+	flags.StringVar(&cfg.pamURL, "pam-url", "", "override the Privilege Cloud URL derived from -domain")
+	flags.StringVar(&cfg.secretsHubURL, "secretshub-url", "", "override the Secrets Hub URL derived from -domain")
+	flags.StringVar(&cfg.outDir, "out", ".", "directory to write the generated files to")
+	flags.StringVar(&cfg.include, "include", strings.Join(exportKinds, ","), "comma separated kinds of objects to export")
+	flags.StringVar(&cfg.safes, "safe", "", "comma separated glob patterns of safe names to export")
+	flags.StringVar(&cfg.platforms, "platform", "", "comma separated glob patterns of account platform IDs to export")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	opts := options{
+		SelfHosted:       cfg.backend == "self_hosted",
+		Include:          splitList(cfg.include),
+		SafePatterns:     splitList(cfg.safes),
+		PlatformPatterns: splitList(cfg.platforms),
+	}
+	if err := validate(cfg, opts); err != nil {
+		return err
+	}
+
+	needsVault := slices.Contains(opts.Include, "safes") || slices.Contains(opts.Include, "accounts")
+	needsSecretsHub := slices.Contains(opts.Include, "secret_stores") || slices.Contains(opts.Include, "sync_policies")
+
+	var (
+		pamAPI        cybrapi.PAMAPI
+		secretsHubAPI cybrapi.SecretsHubAPI
+	)
+
+	if needsSecretsHub || (needsVault && !opts.SelfHosted) {
+		token, err := cloudToken(ctx, cfg, getenv(clientSecretEnv))
+		if err != nil {
+			return err
+		}
+		pamAPI = cybrapi.NewPAMAPI(urlOrDefault(cfg.pamURL, cloudPamURL, cfg.domain), token, true)
+		secretsHubAPI = cybrapi.NewSecretsHubAPI(urlOrDefault(cfg.secretsHubURL, cloudSecretsHubURL, cfg.domain), token)
+	}
+
+	if needsVault && opts.SelfHosted {
+		pvwaAuthAPI := cybrapi.NewPVWAAuthAPI(cfg.pvwaURL, cfg.pvwaLoginMethod)
+		token, err := pvwaAuthAPI.GetToken(ctx, cfg.pvwaUsername, []byte(getenv(pvwaPasswordEnv)))
+		if err != nil {
+			return fmt.Errorf("failed to get PVWA authentication token: %w", err)
+		}
+		defer func() {
+			// PVWA limits concurrent sessions per user, so do not leave the session open
+			if err := pvwaAuthAPI.Logoff(context.WithoutCancel(ctx), token); err != nil {
+				fmt.Fprintf(os.Stderr, "cyberark-export: failed to log off PVWA session: %v\n", err)
+			}
+		}()
+		pamAPI = cybrapi.NewPAMAPI(cfg.pvwaURL, token, false)
+	}
+
+	e := newExporter(pamAPI, secretsHubAPI, opts)
+	if err := e.run(ctx); err != nil {
+		return err
+	}
+
+	return writeFiles(cfg.outDir, e.files(), stdout)
+}
+
+// validate checks the configuration before any request is made.
+func validate(cfg config, opts options) error {
+	if cfg.backend != "privilege_cloud" && cfg.backend != "self_hosted" {
+		return fmt.Errorf("invalid backend %q: must be privilege_cloud or self_hosted", cfg.backend)
+	}
+
+	for _, kind := range opts.Include {
+		if !slices.Contains(exportKinds, kind) {
+			return fmt.Errorf("invalid kind %q: must be one of %s", kind, strings.Join(exportKinds, ", "))
+		}
+	}
+
+	for _, pattern := range append(slices.Clone(opts.SafePatterns), opts.PlatformPatterns...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	if opts.SelfHosted && (cfg.pvwaURL == "" || cfg.pvwaUsername == "") {
+		return errors.New("-pvwa-url and -pvwa-username are required for the self_hosted backend")
+	}
+
+	return nil
+}
+
+// cloudToken authenticates against CyberArk Identity with the client credentials.
+func cloudToken(ctx context.Context, cfg config, clientSecret string) ([]byte, error) {
+	if cfg.clientID == "" || clientSecret == "" {
+		return nil, fmt.Errorf("-client-id and %s are required", clientSecretEnv)
+	}
+	if cfg.identityURL == "" && cfg.tenant == "" {
+		return nil, errors.New("-tenant is required")
+	}
+	if (cfg.pamURL == "" || cfg.secretsHubURL == "") && cfg.domain == "" {
+		return nil, errors.New("-domain is required")
+	}
+
+	identityAuthAPI := cybrapi.NewIdentityAuthAPI(urlOrDefault(cfg.identityURL, cloudAuthURL, cfg.tenant))
+	token, err := identityAuthAPI.GetToken(ctx, cfg.clientID, []byte(clientSecret))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authentication token: %w", err)
+	}
+	return token, nil
+}
+
+// writeFiles writes the generated files to dir in a stable order.
+func writeFiles(dir string, files map[string]string, stdout io.Writer) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		filename := filepath.Join(dir, name)
+		if err := os.WriteFile(filename, []byte(files[name]), 0o644); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Wrote %s\n", filename)
+	}
+
+	return nil
+}
+
+// urlOrDefault returns override when set and otherwise formats the default URL.
+func urlOrDefault(override, format, value string) string {
+	if override != "" {
+		return override
+	}
+	return fmt.Sprintf(format, value)
+}
+
+// splitList splits a comma separated list, dropping empty elements.
+func splitList(s string) []string {
+	list := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// newFakeServer serves the Identity, Privilege Cloud and Secrets Hub endpoints used by the export.
+func newFakeServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(newFakeHandler(t))
+}
+
+// newFakeHandler handles the Identity, PVWA, Privilege Cloud and Secrets Hub endpoints used by the export.
+func newFakeHandler(t *testing.T) http.Handler {
+	t.Helper()
+
+	readOnly := cybrapi.Permission{UseAccounts: true, RetrieveAccounts: true, ListAccounts: true}
+
+	routes := map[string]interface{}{
+		"/oauth2/platformtoken":                   cybrapi.IdentityToken{AccessToken: ptr("token")},
+		"/PasswordVault/API/auth/cyberark/Logon/": "token",
+		"/PasswordVault/API/Auth/Logoff":          nil,
+		"/PasswordVault/API/Safes": cybrapi.SafeSearchResponse{Safes: []*cybrapi.SafeData{
+			{Name: ptr("App Safe"), URLID: ptr("App%20Safe"), Description: ptr(`Uses ${var} "quotes"`), CPM: ptr("PasswordManager"), RetentionDays: ptr(int64(7))},
+			{Name: ptr("other"), URLID: ptr("other")},
+		}},
+		"/PasswordVault/API/Safes/App Safe/Members": cybrapi.SafeMemberSearchResponse{Members: []*cybrapi.Member{
+			{Member: ptr("Administrator"), MemberType: ptr("User"), IsPredefinedUser: ptr(true)},
+			{Member: ptr("app-readers"), MemberType: ptr("Group"), Perm: readOnly},
+			{Member: ptr("auditor"), MemberType: ptr("User"), Perm: cybrapi.Permission{ViewAuditLog: true}},
+		}},
+		"/PasswordVault/API/Accounts": cybrapi.CredentialSearchResponse{Accounts: []*cybrapi.CredentialResponse{
+			{CredID: ptr("12_1"), Name: ptr("db-admin"), Platform: ptr("MySQL"), SafeName: ptr("App Safe"), UserName: ptr("admin"),
+				SecretType: ptr("password"), Props: &cybrapi.AccountProps{Port: ptr("3306")}},
+			{CredID: ptr("12_2"), Name: ptr("aws-key"), Platform: ptr("AWSAccessKeys"), SafeName: ptr("App Safe"),
+				SecretType: ptr("key"), Props: &cybrapi.AccountProps{AWSKID: ptr("AKIA"), AWSAccount: ptr("123456789012")}},
+			{CredID: ptr("12_3"), Name: ptr("win-admin"), Platform: ptr("WinDomain"), SafeName: ptr("App Safe"), UserName: ptr("Administrator"),
+				RemoteAccess: &cybrapi.RemoteMachinesAccess{RemoteMachines: ptr("srv1;srv2"), AccessRestrictedToRemoteMachines: ptr(true)}},
+		}},
+		"/api/policies": cybrapi.SyncResponse{Policies: []*cybrapi.PolicyExternalOutput{
+			{ID: ptr("policy-1"), Name: ptr("app sync"), Source: &cybrapi.Source{SourceID: "store-pam"},
+				Target: &cybrapi.Target{TargetID: "store-aws"}, Filter: &cybrapi.FilterResponse{ID: ptr("filter-1")}},
+			{ID: ptr("policy-2"), Name: ptr("other sync"), Source: &cybrapi.Source{SourceID: "store-pam"},
+				Target: &cybrapi.Target{TargetID: "store-aws"}, Filter: &cybrapi.FilterResponse{ID: ptr("filter-2")}},
+		}},
+		"/api/secret-stores/store-pam/filters/filter-1": cybrapi.SecretFilterOutput{Data: &cybrapi.SafeDataFilter{SafeName: ptr("App Safe")}},
+		"/api/secret-stores/store-pam/filters/filter-2": cybrapi.SecretFilterOutput{Data: &cybrapi.SafeDataFilter{SafeName: ptr("other")}},
+	}
+
+	stores := map[string]interface{}{
+		"type EQ AWS_ASM": cybrapi.SecretStoresOutput[cybrapi.AwsAsmData]{SecretStores: []*cybrapi.SecretStoreOutput[cybrapi.AwsAsmData]{
+			{ID: "store-aws", Name: ptr("AWS Prod"), Description: ptr("prod"), Data: &cybrapi.AwsAsmData{
+				AccountAlias: ptr("prod"), AccountID: ptr("123456789012"), RegionID: ptr("us-east-1"), RoleName: ptr("SecretsHub")}},
+		}},
+		"type EQ AZURE_AKV": cybrapi.SecretStoresOutput[cybrapi.AzureAkvData]{SecretStores: []*cybrapi.SecretStoreOutput[cybrapi.AzureAkvData]{
+			{ID: "store-azure", Name: ptr("Azure Prod"), Data: &cybrapi.AzureAkvData{
+				AppClientID: ptr("client"), AppClientSecret: ptr("must-not-leak"), AzureVaultURL: ptr("https://prod.vault.azure.net")}},
+		}},
+		"type EQ GCP_GSM": cybrapi.SecretStoresOutput[cybrapi.GcpData]{},
+	}
+
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/api/secret-stores" {
+			json.NewEncoder(rw).Encode(stores[req.URL.Query().Get("filter")])
+			return
+		}
+
+		if req.URL.Path == "/PasswordVault/API/Accounts" {
+			assert.Equal(t, "safeName eq App Safe", req.URL.Query().Get("filter"))
+		}
+
+		body, ok := routes[req.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request: %s %s", req.Method, req.URL)
+			http.NotFound(rw, req)
+			return
+		}
+		json.NewEncoder(rw).Encode(body)
+	})
+}
+
+func TestRun(t *testing.T) {
+	server := newFakeServer(t)
+	defer server.Close()
+
+	out := t.TempDir()
+	getenv := func(key string) string {
+		return map[string]string{clientSecretEnv: "secret"}[key]
+	}
+
+	var stdout bytes.Buffer
+	err := run(context.Background(), []string{
+		"-client-id", "user@cyberark.cloud.1234",
+		"-identity-url", server.URL,
+		"-pam-url", server.URL,
+		"-secretshub-url", server.URL,
+		"-safe", "App*",
+		"-out", out,
+	}, getenv, &stdout)
+	require.NoError(t, err)
+
+	read := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(out, name))
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	assert.Equal(t, `# Additional member: auditor (User, custom permissions)
+resource "cyberark_safe" "app_safe" {
+  safe_name        = "App Safe"
+  safe_desc        = "Uses $${var} \"quotes\""
+  cpm_name         = "PasswordManager"
+  retention        = 7
+  member           = "app-readers"
+  member_type      = "Group"
+  permission_level = "read"
+}
+`, read("safes.tf"))
+
+	accounts := read("accounts.tf")
+	assert.Contains(t, accounts, `resource "cyberark_db_account" "db_admin" {`)
+	assert.Contains(t, accounts, `  secret      = var.db_account_db_admin_secret`)
+	assert.Contains(t, accounts, `  db_port     = "3306"`)
+	assert.Contains(t, accounts, `resource "cyberark_aws_account" "aws_key" {`)
+	assert.Contains(t, accounts, `  aws_kid        = "AKIA"`)
+	assert.Contains(t, accounts, `  remote_machines                      = ["srv1", "srv2"]`)
+	assert.Contains(t, accounts, `  access_restricted_to_remote_machines = true`)
+
+	stores := read("secret_stores.tf")
+	assert.Contains(t, stores, `resource "cyberark_aws_secret_store" "aws_prod" {`)
+	assert.Contains(t, stores, `azure_app_client_secret = var.azure_prod_app_client_secret`)
+	assert.NotContains(t, stores, "must-not-leak")
+
+	policies := read("sync_policies.tf")
+	assert.Contains(t, policies, `  source_id = "store-pam"`)
+	assert.Contains(t, policies, `  target_id = cyberark_aws_secret_store.aws_prod.id`)
+	assert.Contains(t, policies, `  safe_name = "App Safe"`)
+	assert.NotContains(t, policies, "other sync")
+
+	imports := read("imports.tf")
+	assert.Contains(t, imports, "import {\n  to = cyberark_safe.app_safe\n  id = \"App%20Safe\"\n}\n")
+	assert.Contains(t, imports, "  to = cyberark_sync_policy.app_sync\n  id = \"policy-1\"\n")
+	assert.Equal(t, 7, strings.Count(imports, "import {"))
+
+	variables := read("variables.tf")
+	assert.Contains(t, variables, `variable "db_account_db_admin_secret" {`)
+	assert.Contains(t, variables, `  sensitive   = true`)
+
+	assert.Contains(t, stdout.String(), filepath.Join(out, "imports.tf"))
+}
+
+func TestRunFilters(t *testing.T) {
+	server := newFakeServer(t)
+	defer server.Close()
+
+	out := t.TempDir()
+	getenv := func(key string) string {
+		return map[string]string{clientSecretEnv: "secret"}[key]
+	}
+
+	err := run(context.Background(), []string{
+		"-client-id", "user@cyberark.cloud.1234",
+		"-identity-url", server.URL,
+		"-pam-url", server.URL,
+		"-secretshub-url", server.URL,
+		"-safe", "App*",
+		"-platform", "AWS*",
+		"-include", "accounts",
+		"-out", out,
+	}, getenv, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	entries, err := os.ReadDir(out)
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.Equal(t, []string{"accounts.tf", "imports.tf", "variables.tf"}, names)
+
+	accounts, err := os.ReadFile(filepath.Join(out, "accounts.tf"))
+	require.NoError(t, err)
+	assert.Contains(t, string(accounts), "cyberark_aws_account")
+	assert.NotContains(t, string(accounts), "cyberark_db_account")
+}
+
+func TestRunSelfHostedLogsOff(t *testing.T) {
+	handler := newFakeHandler(t)
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests[req.URL.Path]++
+		handler.ServeHTTP(rw, req)
+	}))
+	defer server.Close()
+
+	getenv := func(key string) string {
+		return map[string]string{pvwaPasswordEnv: "password"}[key]
+	}
+
+	err := run(context.Background(), []string{
+		"-backend", "self_hosted",
+		"-pvwa-url", server.URL,
+		"-pvwa-username", "admin",
+		"-safe", "App*",
+		"-include", "safes,accounts",
+		"-out", t.TempDir(),
+	}, getenv, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, requests["/PasswordVault/API/auth/cyberark/Logon/"])
+	assert.Equal(t, 1, requests["/PasswordVault/API/Auth/Logoff"])
+}
+
+func TestRunValidation(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"InvalidBackend": {[]string{"-backend", "cloud"}, "invalid backend"},
+		"InvalidKind":    {[]string{"-include", "users"}, `invalid kind "users"`},
+		"InvalidPattern": {[]string{"-safe", "["}, "invalid pattern"},
+		"SelfHosted":     {[]string{"-backend", "self_hosted"}, "-pvwa-url and -pvwa-username are required"},
+		"MissingSecret":  {[]string{"-client-id", "user"}, clientSecretEnv},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := run(context.Background(), test.args, func(string) string { return "" }, &bytes.Buffer{})
+			assert.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestQuote(t *testing.T) {
+	assert.Equal(t, `"a\\b \"c\" $${d} %%{e}\n"`, quote("a\\b \"c\" ${d} %{e}\n"))
+}
@@ -0,0 +1,10 @@
+# Discover existing objects and write import blocks for them, then run
+# terraform plan -generate-config-out=generated.tf
+data "cyberark_import_discovery" "existing" {
+  include = ["safes", "accounts"]
+}
+
+resource "local_file" "imports" {
+  filename = "${path.module}/imports.tf"
+  content  = data.cyberark_import_discovery.existing.import_blocks
+}
@@ -0,0 +1,7 @@
+data "cyberark_onboarding_rules" "windows" {
+  target_safe_name = "Windows-Servers"
+}
+
+output "windows_onboarding_rules" {
+  value = [for rule in data.cyberark_onboarding_rules.windows.rules : rule.rule_name]
+}
@@ -0,0 +1,8 @@
+data "cyberark_platform" "windows" {
+  platform_id = "WinDomain"
+}
+
+resource "cyberark_target_platform" "payments" {
+  base_platform_id = data.cyberark_platform.windows.platform_id
+  name             = "Payments Windows Domain"
+}
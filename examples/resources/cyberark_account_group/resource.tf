@@ -0,0 +1,21 @@
+variable "orders_sys_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "cyberark_account_group" "orders_cluster" {
+  group_name        = "orders-db-cluster"
+  group_platform_id = "OracleGroup"
+  safe              = "Databases"
+}
+
+resource "cyberark_db_account" "orders_node1" {
+  name             = "orders-node1-sys"
+  address          = "orders-node1.example.com"
+  username         = "sys"
+  platform         = "Oracle"
+  safe             = "Databases"
+  secret_type      = "password"
+  secret           = var.orders_sys_password
+  account_group_id = cyberark_account_group.orders_cluster.id
+}
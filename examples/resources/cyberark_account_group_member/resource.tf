@@ -0,0 +1,4 @@
+resource "cyberark_account_group_member" "orders_node2" {
+  group_id   = cyberark_account_group.orders_cluster.id
+  account_id = cyberark_db_account.orders_node2.id
+}
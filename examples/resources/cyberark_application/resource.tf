@@ -0,0 +1,16 @@
+resource "cyberark_application" "billing" {
+  app_id                = "billing"
+  description           = "Billing batch jobs"
+  access_permitted_from = 6
+  access_permitted_to   = 22
+  business_owner_email  = "billing-team@example.com"
+}
+
+# Let the Credential Provider retrieve the accounts of a safe for the application
+resource "cyberark_safe" "billing" {
+  backend          = "self_hosted"
+  safe_name        = "Billing"
+  member           = cyberark_application.billing.app_id
+  member_type      = "user"
+  permission_level = "application"
+}
@@ -0,0 +1,21 @@
+resource "cyberark_application_auth_method" "billing_hosts" {
+  for_each = toset(["10.0.10.21", "10.0.10.22"])
+
+  app_id     = cyberark_application.billing.app_id
+  auth_type  = "machineAddress"
+  auth_value = each.key
+}
+
+resource "cyberark_application_auth_method" "billing_user" {
+  app_id     = cyberark_application.billing.app_id
+  auth_type  = "osUser"
+  auth_value = "svc_billing"
+}
+
+resource "cyberark_application_auth_method" "billing_scripts" {
+  app_id                 = cyberark_application.billing.app_id
+  auth_type              = "path"
+  auth_value             = "/opt/billing/bin"
+  is_folder              = true
+  allow_internal_scripts = true
+}
@@ -0,0 +1,31 @@
+# Push accounts found by a discovery scan to the pending accounts list for review
+resource "cyberark_discovered_accounts" "scan" {
+  accounts = [
+    {
+      user_name           = "Administrator"
+      address             = "srv-01.corp.example.com"
+      platform_type       = "Windows Server Local"
+      account_enabled     = true
+      privileged          = true
+      privileged_criteria = "Administrators"
+      os_family           = "Windows"
+      properties = {
+        SID = "S-1-5-21-3623811015-3361044348-30300820-500"
+      }
+    },
+    {
+      user_name     = "root"
+      address       = "db-01.corp.example.com"
+      platform_type = "Unix"
+      privileged    = true
+      properties = {
+        UID = "0"
+        GID = "0"
+      }
+
+      # Onboard the account right away instead of waiting for review
+      onboard_safe_name   = "Unix-Root"
+      onboard_platform_id = "UnixSSH"
+    },
+  ]
+}
@@ -0,0 +1,12 @@
+resource "cyberark_identity_role" "team" {
+  name        = "Team Payments"
+  description = "Members of the payments team"
+}
+
+# Grant the role access to the safe of the team
+resource "cyberark_safe" "team" {
+  safe_name        = "Payments"
+  member           = cyberark_identity_role.team.name
+  member_type      = "role"
+  permission_level = "read"
+}
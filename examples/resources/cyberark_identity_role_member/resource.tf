@@ -0,0 +1,5 @@
+resource "cyberark_identity_role_member" "ci" {
+  role_id     = cyberark_identity_role.team.id
+  member_id   = cyberark_identity_service_user.ci.id
+  member_type = "User" # User, Group or Role
+}
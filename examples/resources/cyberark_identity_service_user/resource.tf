@@ -0,0 +1,6 @@
+resource "cyberark_identity_service_user" "ci" {
+  username     = "payments-ci@example.cyberark.cloud.1234"
+  password     = var.ci_client_secret
+  display_name = "Payments CI"
+  description  = "Confidential client of the payments pipelines"
+}
@@ -0,0 +1,21 @@
+# Onboard the local administrators of discovered Windows servers
+resource "cyberark_onboarding_rule" "windows_admins" {
+  rule_name           = "Windows server administrators"
+  target_platform_id  = "WinServerLocal"
+  target_safe_name    = "Windows-Servers"
+  system_type_filter  = "Windows"
+  machine_type_filter = "Server"
+  is_admin_id_filter  = true
+  address_filter      = "corp.example.com"
+  address_method      = "Ends"
+}
+
+# Onboard root accounts and reconcile them with a reconcile account
+resource "cyberark_onboarding_rule" "unix_root" {
+  target_platform_id   = "UnixSSH"
+  target_safe_name     = "Unix-Root"
+  system_type_filter   = "Unix"
+  user_name_filter     = "root"
+  reconcile_account_id = "24_7"
+  rule_precedence      = 1
+}
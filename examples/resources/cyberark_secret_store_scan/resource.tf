@@ -0,0 +1,7 @@
+resource "cyberark_secret_store_scan" "aws_store_scan" {
+  store_id = cyberark_aws_secret_store.awstest.id
+
+  triggers = {
+    iam_role = cyberark_aws_secret_store.awstest.aws_iam_role
+  }
+}
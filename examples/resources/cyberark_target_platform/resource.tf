@@ -0,0 +1,20 @@
+# Duplicate a built-in target platform
+resource "cyberark_target_platform" "payments" {
+  base_platform_id = "WinDomain"
+  name             = "Payments Windows Domain"
+  description      = "Windows domain accounts of the payments team"
+}
+
+# Import a platform package from the Marketplace
+resource "cyberark_target_platform" "custom_ssh" {
+  package_file = "${path.module}/platforms/CustomSSH.zip"
+}
+
+resource "cyberark_db_account" "payments" {
+  name     = "payments-db"
+  address  = "db.example.com"
+  username = "payments"
+  secret   = var.payments_db_password
+  platform = cyberark_target_platform.payments.platform_id
+  safe     = "Payments"
+}
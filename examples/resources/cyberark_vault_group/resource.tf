@@ -0,0 +1,5 @@
+resource "cyberark_vault_group" "operators" {
+  group_name  = "TF_Operators"
+  description = "Safe operators managed by Terraform"
+  location    = "\\"
+}
@@ -0,0 +1,11 @@
+resource "cyberark_vault_group_member" "jdoe" {
+  group_id    = cyberark_vault_group.operators.id
+  member_name = cyberark_vault_user.jdoe.username
+}
+
+resource "cyberark_vault_group_member" "domain_user" {
+  group_id    = cyberark_vault_group.operators.id
+  member_name = "asmith"
+  member_type = "domain"
+  domain_name = "example.com"
+}
@@ -0,0 +1,14 @@
+resource "cyberark_vault_user" "jdoe" {
+  username               = "jdoe"
+  initial_password       = var.initial_password
+  user_type              = "EPVUser"
+  location               = "\\Applications"
+  expiry_date            = "2027-01-01T00:00:00Z"
+  vault_authorization    = ["AddSafes", "AuditUsers"]
+  authentication_methods = ["AuthTypePass"]
+  enable_user            = true
+  password_never_expires = false
+  email                  = "jdoe@example.com"
+  first_name             = "John"
+  last_name              = "Doe"
+}
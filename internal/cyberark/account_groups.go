@@ -0,0 +1,151 @@
+package cyberark
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// AccountGroups is an interface for interacting with account groups.
+type AccountGroups interface {
+	AddAccountGroup(ctx context.Context, group AccountGroup) (*AccountGroup, error)
+	ListAccountGroups(ctx context.Context, safeName string) ([]*AccountGroup, error)
+	ListAccountGroupMembers(ctx context.Context, groupID string) ([]*AccountGroupMember, error)
+	AddAccountGroupMember(ctx context.Context, groupID string, accountID string) error
+	DeleteAccountGroupMember(ctx context.Context, groupID string, accountID string) error
+}
+
+// AddAccountGroup adds a new account group to a safe.
+func (a *pamAPI) AddAccountGroup(ctx context.Context, group AccountGroup) (*AccountGroup, error) {
+	body, err := json.Marshal(group)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := a.client.DoRequest(
+		ctx,
+		"POST",
+		"/PasswordVault/API/AccountGroups",
+		bytes.NewBuffer(body),
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 200 && response.StatusCode != 201 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	newGroup := AccountGroup{}
+	err = json.NewDecoder(response.Body).Decode(&newGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &newGroup, nil
+}
+
+// ListAccountGroups lists the account groups of a safe.
+func (a *pamAPI) ListAccountGroups(ctx context.Context, safeName string) ([]*AccountGroup, error) {
+	response, err := a.client.DoRequest(
+		ctx,
+		"GET",
+		"/PasswordVault/API/AccountGroups",
+		nil,
+		map[string]string{},
+		map[string]string{"Safe": safeName},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	groups := []*AccountGroup{}
+	err = json.NewDecoder(response.Body).Decode(&groups)
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// ListAccountGroupMembers lists the accounts of an account group.
+func (a *pamAPI) ListAccountGroupMembers(ctx context.Context, groupID string) ([]*AccountGroupMember, error) {
+	response, err := a.client.DoRequest(
+		ctx,
+		"GET",
+		fmt.Sprintf("/PasswordVault/API/AccountGroups/%s/Members", url.PathEscape(groupID)),
+		nil,
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	members := []*AccountGroupMember{}
+	err = json.NewDecoder(response.Body).Decode(&members)
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// AddAccountGroupMember adds an account to an account group. The account must be in the safe of the group.
+func (a *pamAPI) AddAccountGroupMember(ctx context.Context, groupID string, accountID string) error {
+	body, err := json.Marshal(AccountGroupMember{AccountID: &accountID})
+	if err != nil {
+		return err
+	}
+
+	response, err := a.client.DoRequest(
+		ctx,
+		"POST",
+		fmt.Sprintf("/PasswordVault/API/AccountGroups/%s/Members", url.PathEscape(groupID)),
+		bytes.NewBuffer(body),
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != 200 && response.StatusCode != 201 {
+		return APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	return nil
+}
+
+// DeleteAccountGroupMember removes an account from an account group.
+func (a *pamAPI) DeleteAccountGroupMember(ctx context.Context, groupID string, accountID string) error {
+	response, err := a.client.DoRequest(
+		ctx,
+		"DELETE",
+		fmt.Sprintf("/PasswordVault/API/AccountGroups/%s/Members/%s", url.PathEscape(groupID), url.PathEscape(accountID)),
+		nil,
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != 200 && response.StatusCode != 204 {
+		return APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	return nil
+}
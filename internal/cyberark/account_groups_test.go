@@ -0,0 +1,127 @@
+package cyberark_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountGroups(t *testing.T) {
+	groupID, name, platform, safe := "17_3", "db-cluster", "OracleGroup", "Databases"
+
+	t.Run("AddAccountGroup", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "POST", req.Method)
+			assert.Equal(t, "/PasswordVault/API/AccountGroups", req.URL.Path)
+
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"GroupName": "db-cluster", "GroupPlatformID": "OracleGroup", "Safe": "Databases"}`, string(body))
+
+			rw.WriteHeader(http.StatusCreated)
+			json.NewEncoder(rw).Encode(cyberark.AccountGroup{GroupID: &groupID, GroupName: &name, GroupPlatformID: &platform, Safe: &safe})
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		group, err := client.AddAccountGroup(context.Background(), cyberark.AccountGroup{
+			GroupName:       &name,
+			GroupPlatformID: &platform,
+			Safe:            &safe,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, groupID, *group.GroupID)
+	})
+
+	t.Run("ListAccountGroups", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "GET", req.Method)
+			assert.Equal(t, "/PasswordVault/API/AccountGroups", req.URL.Path)
+			assert.Equal(t, "Databases", req.URL.Query().Get("Safe"))
+
+			rw.Write([]byte(`[{"GroupID": "17_3", "GroupName": "db-cluster", "GroupPlatformID": "OracleGroup", "Safe": "Databases"}]`))
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		groups, err := client.ListAccountGroups(context.Background(), safe)
+
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.Equal(t, name, *groups[0].GroupName)
+	})
+
+	t.Run("ListAccountGroupMembers", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "GET", req.Method)
+			assert.Equal(t, "/PasswordVault/API/AccountGroups/17_3/Members", req.URL.Path)
+
+			rw.Write([]byte(`[{"AccountID": "42_7", "SafeName": "Databases", "PlatformID": "Oracle", "Address": "db1", "UserName": "sys"}]`))
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		members, err := client.ListAccountGroupMembers(context.Background(), groupID)
+
+		require.NoError(t, err)
+		require.Len(t, members, 1)
+		assert.Equal(t, "42_7", *members[0].AccountID)
+	})
+
+	t.Run("AddAccountGroupMember", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "POST", req.Method)
+			assert.Equal(t, "/PasswordVault/API/AccountGroups/17_3/Members", req.URL.Path)
+
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"AccountID": "42_7"}`, string(body))
+
+			rw.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		require.NoError(t, client.AddAccountGroupMember(context.Background(), groupID, "42_7"))
+	})
+
+	t.Run("DeleteAccountGroupMember", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "DELETE", req.Method)
+			assert.Equal(t, "/PasswordVault/API/AccountGroups/17_3/Members/42_7", req.URL.Path)
+
+			rw.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		require.NoError(t, client.DeleteAccountGroupMember(context.Background(), groupID, "42_7"))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+			rw.WriteHeader(http.StatusNotFound)
+			rw.Write([]byte(`{"ErrorCode": "PASWS167E", "ErrorMessage": "Group not found"}`))
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		_, err := client.ListAccountGroupMembers(context.Background(), groupID)
+
+		require.Error(t, err)
+	})
+}
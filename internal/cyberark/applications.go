@@ -0,0 +1,181 @@
+package cyberark
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// applicationsPath is the path of the Credential Provider applications in the PIMServices API,
+// which has no successor in the PAM API.
+const applicationsPath = "/PasswordVault/WebServices/PIMServices.svc/Applications"
+
+// Applications is an interface for interacting with Credential Provider applications and
+// their authentication methods.
+type Applications interface {
+	AddApplication(ctx context.Context, application Application) error
+	GetApplication(ctx context.Context, appID string) (*Application, error)
+	DeleteApplication(ctx context.Context, appID string) error
+	AddApplicationAuthMethod(ctx context.Context, appID string, method ApplicationAuthMethod) error
+	ListApplicationAuthMethods(ctx context.Context, appID string) ([]*ApplicationAuthMethod, error)
+	DeleteApplicationAuthMethod(ctx context.Context, appID string, authID string) error
+}
+
+// AddApplication adds a new application.
+func (a *pamAPI) AddApplication(ctx context.Context, application Application) error {
+	body, err := json.Marshal(map[string]Application{"application": application})
+	if err != nil {
+		return err
+	}
+
+	response, err := a.client.DoRequest(
+		ctx,
+		"POST",
+		applicationsPath+"/",
+		bytes.NewBuffer(body),
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != 201 {
+		return APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	return nil
+}
+
+// GetApplication retrieves an application by its application ID.
+func (a *pamAPI) GetApplication(ctx context.Context, appID string) (*Application, error) {
+	response, err := a.client.DoRequest(
+		ctx,
+		"GET",
+		fmt.Sprintf("%s/%s/", applicationsPath, url.PathEscape(appID)),
+		nil,
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	output := struct {
+		Application *Application `json:"application"`
+	}{}
+	err = json.NewDecoder(response.Body).Decode(&output)
+	if err != nil {
+		return nil, err
+	}
+
+	if output.Application == nil {
+		return nil, fmt.Errorf("application %s not found", appID)
+	}
+
+	return output.Application, nil
+}
+
+// DeleteApplication deletes an application together with its authentication methods.
+func (a *pamAPI) DeleteApplication(ctx context.Context, appID string) error {
+	response, err := a.client.DoRequest(
+		ctx,
+		"DELETE",
+		fmt.Sprintf("%s/%s/", applicationsPath, url.PathEscape(appID)),
+		nil,
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != 200 && response.StatusCode != 204 {
+		return APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	return nil
+}
+
+// AddApplicationAuthMethod adds an authentication method to an application. The API does not
+// return the ID of the new method, which has to be looked up with ListApplicationAuthMethods.
+func (a *pamAPI) AddApplicationAuthMethod(ctx context.Context, appID string, method ApplicationAuthMethod) error {
+	body, err := json.Marshal(map[string]ApplicationAuthMethod{"authentication": method})
+	if err != nil {
+		return err
+	}
+
+	response, err := a.client.DoRequest(
+		ctx,
+		"POST",
+		fmt.Sprintf("%s/%s/Authentications/", applicationsPath, url.PathEscape(appID)),
+		bytes.NewBuffer(body),
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != 200 && response.StatusCode != 201 {
+		return APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	return nil
+}
+
+// ListApplicationAuthMethods lists the authentication methods of an application.
+func (a *pamAPI) ListApplicationAuthMethods(ctx context.Context, appID string) ([]*ApplicationAuthMethod, error) {
+	response, err := a.client.DoRequest(
+		ctx,
+		"GET",
+		fmt.Sprintf("%s/%s/Authentications/", applicationsPath, url.PathEscape(appID)),
+		nil,
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	output := struct {
+		Authentication []*ApplicationAuthMethod `json:"authentication"`
+	}{}
+	err = json.NewDecoder(response.Body).Decode(&output)
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Authentication, nil
+}
+
+// DeleteApplicationAuthMethod deletes an authentication method of an application.
+func (a *pamAPI) DeleteApplicationAuthMethod(ctx context.Context, appID string, authID string) error {
+	response, err := a.client.DoRequest(
+		ctx,
+		"DELETE",
+		fmt.Sprintf("%s/%s/Authentications/%s/", applicationsPath, url.PathEscape(appID), url.PathEscape(authID)),
+		nil,
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != 200 && response.StatusCode != 204 {
+		return APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	return nil
+}
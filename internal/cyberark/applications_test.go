@@ -0,0 +1,140 @@
+package cyberark_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplications(t *testing.T) {
+	appID := "billing app"
+	location := "\\"
+
+	t.Run("AddApplication", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "POST", req.Method)
+			assert.Equal(t, "/PasswordVault/WebServices/PIMServices.svc/Applications/", req.URL.Path)
+
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"application": {"AppID": "billing app", "Location": "\\"}}`, string(body))
+
+			rw.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		err := client.AddApplication(context.Background(), cyberark.Application{AppID: &appID, Location: &location})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("GetApplication", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "GET", req.Method)
+			assert.Equal(t, "/PasswordVault/WebServices/PIMServices.svc/Applications/billing%20app/", req.URL.EscapedPath())
+
+			rw.Write([]byte(`{"application": {"AppID": "billing app", "AccessPermittedFrom": 0, "AccessPermittedTo": 24, "Disabled": false}}`))
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		application, err := client.GetApplication(context.Background(), appID)
+
+		require.NoError(t, err)
+		assert.Equal(t, appID, *application.AppID)
+		assert.Equal(t, 24, *application.AccessPermittedTo)
+		assert.False(t, *application.Disabled)
+	})
+
+	t.Run("DeleteApplication", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "DELETE", req.Method)
+			assert.Equal(t, "/PasswordVault/WebServices/PIMServices.svc/Applications/billing%20app/", req.URL.EscapedPath())
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		assert.NoError(t, client.DeleteApplication(context.Background(), appID))
+	})
+
+	t.Run("AddApplicationAuthMethod", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "POST", req.Method)
+			assert.Equal(t, "/PasswordVault/WebServices/PIMServices.svc/Applications/billing%20app/Authentications/", req.URL.EscapedPath())
+
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"authentication": {"AuthType": "machineAddress", "AuthValue": "10.0.0.1"}}`, string(body))
+
+			rw.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		authType, authValue := "machineAddress", "10.0.0.1"
+		err := client.AddApplicationAuthMethod(context.Background(), appID, cyberark.ApplicationAuthMethod{
+			AuthType:  &authType,
+			AuthValue: &authValue,
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("ListApplicationAuthMethods", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "GET", req.Method)
+
+			json.NewEncoder(rw).Encode(map[string]any{"authentication": []map[string]any{
+				{"authID": 4, "AppID": appID, "AuthType": "hash", "AuthValue": "A1B2", "Comment": "v1.2"},
+			}})
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		methods, err := client.ListApplicationAuthMethods(context.Background(), appID)
+
+		require.NoError(t, err)
+		require.Len(t, methods, 1)
+		assert.Equal(t, "4", methods[0].AuthID.String())
+		assert.Equal(t, "v1.2", *methods[0].Comment)
+	})
+
+	t.Run("DeleteApplicationAuthMethod", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "DELETE", req.Method)
+			assert.Equal(t, "/PasswordVault/WebServices/PIMServices.svc/Applications/billing%20app/Authentications/4/", req.URL.EscapedPath())
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		assert.NoError(t, client.DeleteApplicationAuthMethod(context.Background(), appID, "4"))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.WriteHeader(http.StatusNotFound)
+			rw.Write([]byte(`{"ErrorCode": "APPAP004E", "ErrorMessage": "Application not found"}`))
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		_, err := client.GetApplication(context.Background(), appID)
+
+		assert.ErrorContains(t, err, "Application not found")
+	})
+}
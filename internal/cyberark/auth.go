@@ -2,11 +2,16 @@
 package cyberark
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http/cookiejar"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 )
 
 // TokenFetcher is an interface for fetching identity tokens.
@@ -14,6 +19,133 @@ type TokenFetcher interface {
 	GetToken(ctx context.Context, clientID string, clientSecret []byte) ([]byte, error)
 }
 
+// ExpiringTokenFetcher is a TokenFetcher that also reports when tokens expire, so that they can
+// be cached. A zero expiry means it is unknown.
+type ExpiringTokenFetcher interface {
+	TokenFetcher
+	GetExpiringToken(ctx context.Context, clientID string, clientSecret []byte) ([]byte, time.Time, error)
+}
+
+// SecretSource fetches the secret a TokenFetcher logs in with, e.g. from a vault.
+type SecretSource interface {
+	GetSecret(ctx context.Context) ([]byte, error)
+}
+
+// SourcedTokenFetcher is a TokenFetcher that logs in with a secret fetched from a SecretSource
+// instead of the secret it is given.
+type SourcedTokenFetcher struct {
+	Fetcher TokenFetcher
+	Source  SecretSource
+}
+
+// GetToken fetches the secret from the source and logs in with it.
+func (f *SourcedTokenFetcher) GetToken(ctx context.Context, clientID string, _ []byte) ([]byte, error) {
+	secret, err := f.Source.GetSecret(ctx)
+	if err != nil {
+		return []byte{}, fmt.Errorf("failed to fetch secret: %w", err)
+	}
+	return f.Fetcher.GetToken(ctx, clientID, secret)
+}
+
+// GetExpiringToken fetches the secret from the source and logs in with it. The expiry is zero if
+// the wrapped fetcher does not report it.
+func (f *SourcedTokenFetcher) GetExpiringToken(ctx context.Context, clientID string, _ []byte) ([]byte, time.Time, error) {
+	fetcher, ok := f.Fetcher.(ExpiringTokenFetcher)
+	if !ok {
+		token, err := f.GetToken(ctx, clientID, nil)
+		return token, time.Time{}, err
+	}
+
+	secret, err := f.Source.GetSecret(ctx)
+	if err != nil {
+		return []byte{}, time.Time{}, fmt.Errorf("failed to fetch secret: %w", err)
+	}
+	return fetcher.GetExpiringToken(ctx, clientID, secret)
+}
+
+// TokenSource returns the token used to authenticate API requests. It is called before every
+// request, so implementations are expected to cache the token.
+type TokenSource func(ctx context.Context) ([]byte, error)
+
+// NewLazyTokenSource returns a TokenSource that logs in on first use only. Concurrent callers wait
+// for the same login, and later calls return its token, or its error, so that a failing login is
+// never retried and cannot lock out the user. The login is not canceled with the context of the
+// request that triggered it, as its result is shared with all other requests.
+func NewLazyTokenSource(login TokenSource) TokenSource {
+	return NewSession(login, nil).Token
+}
+
+// Session is a login that is established on first use and shared by all requests until it is
+// closed. It follows the semantics of NewLazyTokenSource.
+type Session struct {
+	login  TokenSource
+	logoff func(ctx context.Context, token []byte) error
+
+	once   sync.Once
+	mu     sync.Mutex
+	token  []byte
+	err    error
+	closed bool
+}
+
+// NewSession creates a session that logs in with login on first use and, if logoff is not nil,
+// ends the login with logoff when it is closed.
+func NewSession(login TokenSource, logoff func(ctx context.Context, token []byte) error) *Session {
+	return &Session{
+		login:  login,
+		logoff: logoff,
+	}
+}
+
+// Token returns the token of the session, logging in if this is the first call.
+func (s *Session) Token(ctx context.Context) ([]byte, error) {
+	s.mu.Lock()
+	closed := s.closed
+	s.mu.Unlock()
+	if closed {
+		return nil, fmt.Errorf("session is closed")
+	}
+
+	s.once.Do(func() {
+		token, err := s.login(context.WithoutCancel(ctx))
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.token, s.err = token, err
+
+		// The session was closed while logging in, end the new login right away
+		if s.closed && err == nil && s.logoff != nil {
+			_ = s.logoff(context.WithoutCancel(ctx), token)
+		}
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, fmt.Errorf("session is closed")
+	}
+	return s.token, s.err
+}
+
+// Close logs off if the session has logged in. It is safe to call Close more than once, only the
+// first call logs off.
+func (s *Session) Close(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	if s.logoff == nil || s.err != nil || len(s.token) == 0 {
+		return nil
+	}
+	return s.logoff(ctx, s.token)
+}
+
 // IdentityAuthAPI provides methods for fetching identity tokens.
 type IdentityAuthAPI struct {
 	client *Client
@@ -21,6 +153,12 @@ type IdentityAuthAPI struct {
 
 // GetIdentityToken fetches an identity token using the provided client ID and client secret.
 func (a *IdentityAuthAPI) GetToken(ctx context.Context, clientID string, clientSecret []byte) ([]byte, error) {
+	token, _, err := a.GetExpiringToken(ctx, clientID, clientSecret)
+	return token, err
+}
+
+// GetExpiringToken fetches an identity token and its expiry using the provided client ID and client secret.
+func (a *IdentityAuthAPI) GetExpiringToken(ctx context.Context, clientID string, clientSecret []byte) ([]byte, time.Time, error) {
 	body := strings.NewReader(fmt.Sprintf("client_id=%s&grant_type=client_credentials&client_secret=%s",
 		url.QueryEscape(clientID),
 		url.QueryEscape(string(clientSecret))))
@@ -36,20 +174,28 @@ func (a *IdentityAuthAPI) GetToken(ctx context.Context, clientID string, clientS
 	}
 
 	if err != nil {
-		return []byte{}, err
+		return []byte{}, time.Time{}, err
 	}
 	defer resp.Body.Close()
 
 	var tokenResponse IdentityToken
 	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
-		return []byte{}, err
+		return []byte{}, time.Time{}, err
 	}
 
 	if tokenResponse.AccessToken == nil {
-		return []byte{}, fmt.Errorf("invalid token response: %v", tokenResponse)
+		return []byte{}, time.Time{}, fmt.Errorf("invalid token response: %v", tokenResponse)
 	}
 
-	return []byte(*tokenResponse.AccessToken), nil
+	return []byte(*tokenResponse.AccessToken), tokenResponse.expiresAt(), nil
+}
+
+// expiresAt returns when the token expires, or the zero time if the response has no expiry.
+func (t IdentityToken) expiresAt() time.Time {
+	if t.ExpiresIn == nil {
+		return time.Time{}
+	}
+	return time.Now().Add(time.Duration(*t.ExpiresIn) * time.Second)
 }
 
 // NewIdentityAuthAPI creates a new IdentityAuthAPI instance with the provided base URL.
@@ -64,46 +210,157 @@ type PVWAAuthAPIError struct {
 	ErrorMessage string `json:"ErrorMessage"`
 }
 
+// PVWALogonRequest is the body of a PVWA logon request.
+type PVWALogonRequest struct {
+	Username          string `json:"username"`
+	Password          string `json:"password"`
+	NewPassword       string `json:"newPassword,omitempty"`
+	ConcurrentSession bool   `json:"concurrentSession,omitempty"`
+	SecureMode        bool   `json:"secureMode,omitempty"`
+}
+
+// RADIUS logon modes and the OTP value that approves a logon on a device.
+const (
+	RADIUSModeChallenge = "challenge"
+	RADIUSModeAppend    = "append"
+	RADIUSPush          = "push"
+
+	// DefaultRADIUSPushTimeout is how long a push logon waits for approval by default.
+	DefaultRADIUSPushTimeout = 2 * time.Minute
+)
+
+// radiusChallengeCode is the PVWA error code of a RADIUS challenge.
+const radiusChallengeCode = "ITATS542I"
+
+// RADIUSOptions configures the second factor of a RADIUS logon.
+type RADIUSOptions struct {
+	// OTP is the one-time password of the logon, or RADIUSPush to approve the logon on a device.
+	// RADIUSPush is never sent in response to a challenge, as it cannot answer one.
+	OTP string
+	// Mode is RADIUSModeChallenge to send the OTP in response to a challenge, or RADIUSModeAppend
+	// to send it together with the password. Defaults to RADIUSModeChallenge.
+	Mode string
+	// PushTimeout bounds the wait for a push approval. Defaults to DefaultRADIUSPushTimeout.
+	PushTimeout time.Duration
+}
+
+// RADIUSChallengeError is returned when the PVWA answers a RADIUS logon with a challenge.
+type RADIUSChallengeError struct {
+	Message string
+}
+
+func (e *RADIUSChallengeError) Error() string {
+	return fmt.Sprintf("RADIUS challenge: %s", e.Message)
+}
+
 type PVWAAuthAPI struct {
 	client      *Client
 	loginMethod string
+
+	// ConcurrentSession allows the logon to open a session while the user has other sessions.
+	ConcurrentSession bool
+	// SecureMode requests a logon in secure mode.
+	SecureMode bool
+	// RADIUS configures the second factor of logons with the radius login method.
+	RADIUS *RADIUSOptions
 }
 
 // GetToken fetches a PAM API token using the provided username and password.
 func (a *PVWAAuthAPI) GetToken(ctx context.Context, clientID string, clientSecret []byte) ([]byte, error) {
-	body := strings.NewReader(
-		fmt.Sprintf(
-			`{"username": "%s", "password": "%s"}`,
-			clientID,
-			string(clientSecret),
-		),
-	)
+	token, err := a.Logon(ctx, PVWALogonRequest{
+		Username:          clientID,
+		Password:          string(clientSecret),
+		ConcurrentSession: a.ConcurrentSession,
+		SecureMode:        a.SecureMode,
+	})
+
+	for i := range clientSecret {
+		clientSecret[i] = 0
+	}
+
+	return token, err
+}
+
+// Logon opens a PVWA session and returns its token. With the radius login method and RADIUS
+// options, the OTP is sent in response to a RADIUS challenge or appended to the password.
+func (a *PVWAAuthAPI) Logon(ctx context.Context, request PVWALogonRequest) ([]byte, error) {
+	radius := a.RADIUS
+	if a.loginMethod != "radius" || radius == nil {
+		return a.logon(ctx, a.client, request)
+	}
+
+	client := a.client
+	if radius.OTP == RADIUSPush {
+		// The PVWA answers only once the logon has been approved on the device
+		timeout := radius.PushTimeout
+		if timeout <= 0 {
+			timeout = DefaultRADIUSPushTimeout
+		}
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		client = a.client.withTimeout(timeout)
+	}
+
+	if radius.Mode == RADIUSModeAppend {
+		request.Password = request.Password + "," + radius.OTP
+		return a.logon(ctx, client, request)
+	}
+
+	token, err := a.logon(ctx, client, request)
+
+	var challenge *RADIUSChallengeError
+	if !errors.As(err, &challenge) {
+		return token, err
+	}
+	if radius.OTP == "" {
+		return []byte{}, fmt.Errorf("%w, but no OTP was provided", err)
+	}
+	if radius.OTP == RADIUSPush {
+		return []byte{}, fmt.Errorf("%w, but a %s logon cannot answer a challenge, provide a one-time password or use the %s mode",
+			err, RADIUSPush, RADIUSModeAppend)
+	}
+
+	request.Password = radius.OTP
+	request.NewPassword = ""
+	return a.logon(ctx, client, request)
+}
+
+// logon sends a single logon request. The encoded request body is zeroed once it has been sent.
+func (a *PVWAAuthAPI) logon(ctx context.Context, client *Client, request PVWALogonRequest) ([]byte, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return []byte{}, fmt.Errorf("failed to encode PVWA logon request: %w", err)
+	}
 	headers := map[string]string{
 		"Content-Type": "application/json",
 	}
 
 	logonPath := fmt.Sprintf("/PasswordVault/API/auth/%s/Logon/", a.loginMethod)
 
-	resp, err := a.client.DoRequest(ctx, "POST", logonPath, body, headers, map[string]string{})
-	body = strings.NewReader("")
-	body.Reset("")
+	resp, err := client.DoRequest(ctx, "POST", logonPath, bytes.NewReader(body), headers, map[string]string{})
 
-	for i := range clientSecret {
-		clientSecret[i] = 0
+	for i := range body {
+		body[i] = 0
 	}
 
 	if err != nil {
 		return []byte{}, err
 	}
+	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
 		var pvwaAuthError PVWAAuthAPIError
 		if err := json.NewDecoder(resp.Body).Decode(&pvwaAuthError); err != nil {
 			return []byte{}, fmt.Errorf("failed to decode PVWA Auth API error response: %w", err)
 		}
+		if resp.StatusCode == 500 && pvwaAuthError.ErrorCode == radiusChallengeCode {
+			return []byte{}, &RADIUSChallengeError{Message: pvwaAuthError.ErrorMessage}
+		}
 		return []byte{}, fmt.Errorf("%s %s", pvwaAuthError.ErrorCode, pvwaAuthError.ErrorMessage)
 	}
-	defer resp.Body.Close()
 
 	var token string
 	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
@@ -117,9 +374,37 @@ func (a *PVWAAuthAPI) GetToken(ctx context.Context, clientID string, clientSecre
 	return []byte(token), nil
 }
 
+// Logoff ends the PVWA session of the token.
+func (a *PVWAAuthAPI) Logoff(ctx context.Context, token []byte) error {
+	headers := map[string]string{
+		"Authorization": string(token),
+	}
+
+	resp, err := a.client.DoRequest(ctx, "POST", "/PasswordVault/API/Auth/Logoff", nil, headers, map[string]string{})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		var pvwaAuthError PVWAAuthAPIError
+		if err := json.NewDecoder(resp.Body).Decode(&pvwaAuthError); err != nil {
+			return fmt.Errorf("failed to decode PVWA Auth API error response: %w", err)
+		}
+		return fmt.Errorf("%s %s", pvwaAuthError.ErrorCode, pvwaAuthError.ErrorMessage)
+	}
+
+	return nil
+}
+
 func NewPVWAAuthAPI(baseURL string, loginMethod string) *PVWAAuthAPI {
+	client := NewClient(baseURL, false, false)
+
+	// The PVWA keeps the state of a RADIUS challenge in the session cookies
+	client.httpClient.Jar, _ = cookiejar.New(nil)
+
 	return &PVWAAuthAPI{
-		client:      NewClient(baseURL, false, false),
+		client:      client,
 		loginMethod: loginMethod,
 	}
 }
@@ -2,11 +2,15 @@ package cyberark_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
 	"github.com/stretchr/testify/assert"
@@ -84,3 +88,344 @@ func TestGetToken(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestNewLazyTokenSource(t *testing.T) {
+	t.Run("LogsInOnce", func(t *testing.T) {
+		logins := 0
+		source := cyberark.NewLazyTokenSource(func(context.Context) ([]byte, error) {
+			logins++
+			return []byte("dummy_token"), nil
+		})
+
+		assert.Equal(t, 0, logins)
+
+		for i := 0; i < 3; i++ {
+			token, err := source(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, "dummy_token", string(token))
+		}
+		assert.Equal(t, 1, logins)
+	})
+
+	t.Run("DoesNotRetryFailedLogin", func(t *testing.T) {
+		logins := 0
+		source := cyberark.NewLazyTokenSource(func(context.Context) ([]byte, error) {
+			logins++
+			return nil, fmt.Errorf("invalid credentials")
+		})
+
+		_, err := source(context.Background())
+		assert.ErrorContains(t, err, "invalid credentials")
+		_, err = source(context.Background())
+		assert.ErrorContains(t, err, "invalid credentials")
+		assert.Equal(t, 1, logins)
+	})
+
+	t.Run("ConcurrentCallersShareLogin", func(t *testing.T) {
+		var logins atomic.Int32
+		source := cyberark.NewLazyTokenSource(func(context.Context) ([]byte, error) {
+			logins.Add(1)
+			time.Sleep(10 * time.Millisecond)
+			return []byte("dummy_token"), nil
+		})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				token, err := source(context.Background())
+				assert.NoError(t, err)
+				assert.Equal(t, "dummy_token", string(token))
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), logins.Load())
+	})
+
+	t.Run("IgnoresCancelationOfFirstCaller", func(t *testing.T) {
+		source := cyberark.NewLazyTokenSource(func(ctx context.Context) ([]byte, error) {
+			return []byte("dummy_token"), ctx.Err()
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		token, err := source(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "dummy_token", string(token))
+	})
+}
+
+func TestSession(t *testing.T) {
+	t.Run("LogsOffOnce", func(t *testing.T) {
+		var logins, logoffs atomic.Int32
+		session := cyberark.NewSession(func(context.Context) ([]byte, error) {
+			logins.Add(1)
+			return []byte("dummy_token"), nil
+		}, func(_ context.Context, token []byte) error {
+			logoffs.Add(1)
+			assert.Equal(t, "dummy_token", string(token))
+			return nil
+		})
+
+		for i := 0; i < 3; i++ {
+			_, err := session.Token(context.Background())
+			assert.NoError(t, err)
+		}
+
+		assert.NoError(t, session.Close(context.Background()))
+		assert.NoError(t, session.Close(context.Background()))
+		assert.Equal(t, int32(1), logins.Load())
+		assert.Equal(t, int32(1), logoffs.Load())
+
+		_, err := session.Token(context.Background())
+		assert.ErrorContains(t, err, "session is closed")
+		assert.Equal(t, int32(1), logins.Load())
+	})
+
+	t.Run("DoesNotLogOffWithoutLogin", func(t *testing.T) {
+		session := cyberark.NewSession(func(context.Context) ([]byte, error) {
+			t.Fatal("unexpected login")
+			return nil, nil
+		}, func(context.Context, []byte) error {
+			t.Fatal("unexpected logoff")
+			return nil
+		})
+
+		assert.NoError(t, session.Close(context.Background()))
+		_, err := session.Token(context.Background())
+		assert.Error(t, err)
+	})
+
+	t.Run("DoesNotLogOffFailedLogin", func(t *testing.T) {
+		session := cyberark.NewSession(func(context.Context) ([]byte, error) {
+			return nil, fmt.Errorf("invalid credentials")
+		}, func(context.Context, []byte) error {
+			t.Fatal("unexpected logoff")
+			return nil
+		})
+
+		_, err := session.Token(context.Background())
+		assert.ErrorContains(t, err, "invalid credentials")
+		assert.NoError(t, session.Close(context.Background()))
+	})
+}
+
+func TestPVWALogoff(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "POST", r.Method)
+			assert.Equal(t, "/PasswordVault/API/Auth/Logoff", r.URL.Path)
+			assert.Equal(t, "session-token", r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		authAPI := cyberark.NewPVWAAuthAPI(server.URL, "cyberark")
+		assert.NoError(t, authAPI.Logoff(context.Background(), []byte("session-token")))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"ErrorCode": "PASWS013E", "ErrorMessage": "Session expired"}`))
+		}))
+		defer server.Close()
+
+		authAPI := cyberark.NewPVWAAuthAPI(server.URL, "cyberark")
+		assert.EqualError(t, authAPI.Logoff(context.Background(), []byte("session-token")), "PASWS013E Session expired")
+	})
+}
+
+func TestPVWAGetToken(t *testing.T) {
+	var request map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/PasswordVault/API/auth/ldap/Logon/", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		request = map[string]interface{}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		_, _ = w.Write([]byte(`"session-token"`))
+	}))
+	defer server.Close()
+
+	t.Run("EscapesCredentials", func(t *testing.T) {
+		authAPI := cyberark.NewPVWAAuthAPI(server.URL, "ldap")
+		password := []byte(`pa"ss\word`)
+
+		token, err := authAPI.GetToken(context.Background(), `admin", "secureMode": "true`, password)
+		assert.NoError(t, err)
+		assert.Equal(t, "session-token", string(token))
+
+		assert.Equal(t, map[string]interface{}{
+			"username": `admin", "secureMode": "true`,
+			"password": `pa"ss\word`,
+		}, request)
+		assert.Equal(t, make([]byte, len(password)), password)
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		authAPI := cyberark.NewPVWAAuthAPI(server.URL, "ldap")
+		authAPI.ConcurrentSession = true
+		authAPI.SecureMode = true
+
+		_, err := authAPI.GetToken(context.Background(), "admin", []byte("password"))
+		assert.NoError(t, err)
+
+		assert.Equal(t, true, request["concurrentSession"])
+		assert.Equal(t, true, request["secureMode"])
+		assert.NotContains(t, request, "newPassword")
+	})
+
+	t.Run("NewPassword", func(t *testing.T) {
+		authAPI := cyberark.NewPVWAAuthAPI(server.URL, "ldap")
+
+		_, err := authAPI.Logon(context.Background(), cyberark.PVWALogonRequest{
+			Username:    "admin",
+			Password:    "expired",
+			NewPassword: "rotated",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "rotated", request["newPassword"])
+	})
+}
+
+// newFakeRADIUSPVWA serves a PVWA that challenges RADIUS logons with the password "password" and
+// accepts the OTP "123456" from the same session, or "password,<otp>" in append mode.
+func newFakeRADIUSPVWA(t *testing.T, pushDelay time.Duration) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		assert.Equal(t, "/PasswordVault/API/auth/radius/Logon/", r.URL.Path)
+
+		var request cyberark.PVWALogonRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		challenged := false
+		if cookie, err := r.Cookie("challenge"); err == nil {
+			challenged = cookie.Value == "pending"
+		}
+
+		switch {
+		case request.Password == "password":
+			http.SetCookie(w, &http.Cookie{Name: "challenge", Value: "pending", Path: "/"})
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"ErrorCode": "ITATS542I", "ErrorMessage": "Enter the code from your token"}`))
+		case challenged && request.Password == "123456", request.Password == "password,123456":
+			_, _ = w.Write([]byte(`"session-token"`))
+		case request.Password == "password,push":
+			time.Sleep(pushDelay)
+			_, _ = w.Write([]byte(`"session-token"`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"ErrorCode": "ITATS004E", "ErrorMessage": "Authentication failure"}`))
+		}
+	}))
+
+	return server, &requests
+}
+
+func TestPVWARADIUSLogon(t *testing.T) {
+	tests := map[string]struct {
+		radius       *cyberark.RADIUSOptions
+		wantRequests int32
+		wantErr      string
+	}{
+		"Challenge": {
+			radius:       &cyberark.RADIUSOptions{OTP: "123456"},
+			wantRequests: 2,
+		},
+		"Append": {
+			radius:       &cyberark.RADIUSOptions{OTP: "123456", Mode: cyberark.RADIUSModeAppend},
+			wantRequests: 1,
+		},
+		"ChallengePush": {
+			// The OTP is only sent in response to a challenge, which push cannot answer
+			radius:       &cyberark.RADIUSOptions{OTP: cyberark.RADIUSPush, PushTimeout: time.Second},
+			wantRequests: 1,
+			wantErr:      "RADIUS challenge: Enter the code from your token, but a push logon cannot answer a challenge, provide a one-time password or use the append mode",
+		},
+		"AppendPush": {
+			radius:       &cyberark.RADIUSOptions{OTP: cyberark.RADIUSPush, Mode: cyberark.RADIUSModeAppend, PushTimeout: time.Second},
+			wantRequests: 1,
+		},
+		"WrongOTP": {
+			radius:       &cyberark.RADIUSOptions{OTP: "654321"},
+			wantRequests: 2,
+			wantErr:      "ITATS004E Authentication failure",
+		},
+		"MissingOTP": {
+			radius:       &cyberark.RADIUSOptions{},
+			wantRequests: 1,
+			wantErr:      "RADIUS challenge: Enter the code from your token, but no OTP was provided",
+		},
+		"NoOptions": {
+			wantRequests: 1,
+			wantErr:      "RADIUS challenge: Enter the code from your token",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server, requests := newFakeRADIUSPVWA(t, 50*time.Millisecond)
+			defer server.Close()
+
+			authAPI := cyberark.NewPVWAAuthAPI(server.URL, "radius")
+			authAPI.RADIUS = test.radius
+
+			token, err := authAPI.GetToken(context.Background(), "admin", []byte("password"))
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "session-token", string(token))
+			}
+			assert.Equal(t, test.wantRequests, requests.Load())
+		})
+	}
+
+	t.Run("PushTimeout", func(t *testing.T) {
+		server, _ := newFakeRADIUSPVWA(t, time.Second)
+		defer server.Close()
+
+		authAPI := cyberark.NewPVWAAuthAPI(server.URL, "radius")
+		authAPI.RADIUS = &cyberark.RADIUSOptions{OTP: cyberark.RADIUSPush, Mode: cyberark.RADIUSModeAppend, PushTimeout: 50 * time.Millisecond}
+
+		_, err := authAPI.GetToken(context.Background(), "admin", []byte("password"))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+type staticSecretSource struct {
+	secret string
+	err    error
+}
+
+func (s staticSecretSource) GetSecret(context.Context) ([]byte, error) {
+	return []byte(s.secret), s.err
+}
+
+func TestSourcedTokenFetcher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "sourced-secret", r.PostForm.Get("client_secret"))
+		_, _ = w.Write([]byte(`{"access_token": "platform-token"}`))
+	}))
+	defer server.Close()
+
+	fetcher := &cyberark.SourcedTokenFetcher{
+		Fetcher: cyberark.NewIdentityAuthAPI(server.URL),
+		Source:  staticSecretSource{secret: "sourced-secret"},
+	}
+	token, err := fetcher.GetToken(context.Background(), "automation@cyberark.cloud.1234", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "platform-token", string(token))
+
+	fetcher.Source = staticSecretSource{err: fmt.Errorf("vault unavailable")}
+	_, err = fetcher.GetToken(context.Background(), "automation@cyberark.cloud.1234", nil)
+	assert.EqualError(t, err, "failed to fetch secret: vault unavailable")
+}
@@ -0,0 +1,67 @@
+package cyberark
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// CCPSecretSource fetches a secret from the CyberArk Central Credential Provider (CCP) REST API.
+type CCPSecretSource struct {
+	client *Client
+	appID  string
+	safe   string
+	object string
+}
+
+// ccpAccount is the response of the CCP REST API.
+type ccpAccount struct {
+	Content   *string `json:"Content"`
+	ErrorCode string  `json:"ErrorCode"`
+	ErrorMsg  string  `json:"ErrorMsg"`
+}
+
+// GetSecret fetches the password of the account object from the CCP.
+func (s *CCPSecretSource) GetSecret(ctx context.Context) ([]byte, error) {
+	resp, err := s.client.DoRequest(ctx, "GET", "/AIMWebService/api/Accounts", nil, map[string]string{}, map[string]string{
+		"AppID":  s.appID,
+		"Safe":   s.safe,
+		"Object": s.object,
+	})
+	if err != nil {
+		return []byte{}, err
+	}
+	defer resp.Body.Close()
+
+	var account ccpAccount
+	if err := json.NewDecoder(resp.Body).Decode(&account); err != nil {
+		return []byte{}, fmt.Errorf("failed to decode CCP response: %w", err)
+	}
+
+	if resp.StatusCode != 200 {
+		return []byte{}, fmt.Errorf("%s %s", account.ErrorCode, account.ErrorMsg)
+	}
+	if account.Content == nil {
+		return []byte{}, fmt.Errorf("CCP response contains no secret")
+	}
+
+	return []byte(*account.Content), nil
+}
+
+// NewCCPSecretSource creates a new CCPSecretSource for the account object in the safe. The TLS
+// configuration, if not nil, is used for client certificate authentication of the application.
+func NewCCPSecretSource(baseURL, appID, safe, object string, tlsConfig *tls.Config) *CCPSecretSource {
+	client := NewClient(baseURL, false, false)
+	if tlsConfig != nil {
+		client.httpClient.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+
+	return &CCPSecretSource{
+		client: client,
+		appID:  appID,
+		safe:   safe,
+		object: object,
+	}
+}
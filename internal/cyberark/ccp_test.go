@@ -0,0 +1,90 @@
+package cyberark_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newClientCertificate returns a self-signed client certificate.
+func newClientCertificate(t *testing.T) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestCCPGetSecret(t *testing.T) {
+	clientCert := newClientCertificate(t)
+	clientCA := x509.NewCertPool()
+	leaf, err := x509.ParseCertificate(clientCert.Certificate[0])
+	require.NoError(t, err)
+	clientCA.AddCert(leaf)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/AIMWebService/api/Accounts", r.URL.Path)
+		assert.Equal(t, "Terraform", r.URL.Query().Get("AppID"))
+		assert.Equal(t, "Automation", r.URL.Query().Get("Safe"))
+
+		if r.URL.Query().Get("Object") != "ispss-client" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"ErrorCode": "APPAP004E", "ErrorMsg": "Password object matching query was not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"Content": "client-secret", "UserName": "automation@cyberark.cloud.1234"}`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCA}
+	server.StartTLS()
+	defer server.Close()
+
+	serverCA := x509.NewCertPool()
+	serverCA.AddCert(server.Certificate())
+	tlsConfig := &tls.Config{RootCAs: serverCA, Certificates: []tls.Certificate{clientCert}}
+
+	t.Run("Success", func(t *testing.T) {
+		source := cyberark.NewCCPSecretSource(server.URL, "Terraform", "Automation", "ispss-client", tlsConfig)
+
+		secret, err := source.GetSecret(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "client-secret", string(secret))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		source := cyberark.NewCCPSecretSource(server.URL, "Terraform", "Automation", "missing", tlsConfig)
+
+		_, err := source.GetSecret(context.Background())
+		assert.EqualError(t, err, "APPAP004E Password object matching query was not found")
+	})
+
+	t.Run("WithoutClientCertificate", func(t *testing.T) {
+		source := cyberark.NewCCPSecretSource(server.URL, "Terraform", "Automation", "ispss-client", &tls.Config{RootCAs: serverCA})
+
+		_, err := source.GetSecret(context.Background())
+		assert.Error(t, err)
+	})
+}
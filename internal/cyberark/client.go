@@ -4,9 +4,11 @@ package cyberark
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -17,21 +19,72 @@ type Client struct {
 	httpClient      *http.Client
 	baseURL         string
 	AuthToken       []byte
+	tokenSource     TokenSource
 	logResponse     bool
+	logSubsystem    string
+	logCtx          context.Context
+	redactor        *Redactor
 	WithBearerToken bool
 }
 
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithRedactedKeys masks the values of keys in logged bodies and headers, in addition to
+// DefaultRedactedKeys.
+func WithRedactedKeys(keys ...string) ClientOption {
+	return func(c *Client) {
+		c.redactor = NewRedactor(keys...)
+	}
+}
+
+// withLogSubsystem logs requests to the tflog subsystem of the given name. Its level can be
+// set apart from the provider's, e.g. with TF_LOG_PROVIDER_CYBERARK_PAM for cyberark.pam.
+func withLogSubsystem(subsystem string) ClientOption {
+	return func(c *Client) {
+		c.logSubsystem = subsystem
+	}
+}
+
+// WithLogContext logs requests through ctx, usually the context the provider was configured
+// with, instead of the context of each request. The log subsystem of the API is registered in
+// ctx once, when the client is created.
+func WithLogContext(ctx context.Context) ClientOption {
+	return func(c *Client) {
+		c.logCtx = ctx
+		if c.logSubsystem != "" {
+			c.logCtx = tflog.NewSubsystem(ctx, c.logSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER", strings.Split(c.logSubsystem, ".")...))
+		}
+	}
+}
+
 // DoRequest sends an HTTP request to the CyberArk API.
 func (c *Client) DoRequest(ctx context.Context, method string, path string, body io.Reader, headers map[string]string, params map[string]string) (*http.Response, error) {
 	relativeURL, err := JoinURL(c.baseURL, path, params)
 	if err != nil {
 		return nil, err
 	}
+	var requestBody []byte
+	if c.logResponse && body != nil {
+		// Keep a copy of the request body to log it
+		requestBody, err = io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(requestBody)
+	}
 	req, err := http.NewRequest(method, relativeURL, body)
 	if err != nil {
 		return nil, err
 	}
 	authToken := string(c.AuthToken)
+	if c.tokenSource != nil {
+		token, err := c.tokenSource(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("authentication failed: %w", err)
+		}
+		authToken = string(token)
+	}
 	if authToken != "" {
 		// Set the Authorization header to include the auth token.
 		auth := "Bearer " + authToken
@@ -47,30 +100,19 @@ func (c *Client) DoRequest(ctx context.Context, method string, path string, body
 		req.Header.Set(key, value)
 	}
 
+	start := time.Now()
 	response, err := c.httpClient.Do(req)
 	if err != nil {
+		if c.logResponse {
+			c.logRequest(ctx, req, requestBody, nil, nil, time.Since(start))
+		}
 		return nil, err
 	}
 
 	if c.logResponse {
 		responseBody, err := io.ReadAll(response.Body)
-
-		var url url.URL
-		if req.URL != nil {
-			url = *req.URL
-		}
-
-		if err == nil && len(responseBody) > 0 {
-			tflog.Debug(
-				ctx,
-				"Response from CyberArk API",
-				map[string]interface{}{
-					"request_url":     url.String(),
-					"method":          method,
-					"response_status": response.Status,
-					"response_body":   string(responseBody),
-				},
-			)
+		if err == nil {
+			c.logRequest(ctx, req, requestBody, response, responseBody, time.Since(start))
 		}
 		response.Body.Close()
 
@@ -81,6 +123,39 @@ func (c *Client) DoRequest(ctx context.Context, method string, path string, body
 	return response, nil
 }
 
+// logRequest logs a request and its response, or the failure to send it when response is nil,
+// with the values of sensitive keys redacted.
+func (c *Client) logRequest(ctx context.Context, req *http.Request, requestBody []byte, response *http.Response, responseBody []byte, latency time.Duration) {
+	redactor := c.redactor
+	if redactor == nil {
+		redactor = NewRedactor()
+	}
+
+	fields := map[string]interface{}{
+		"method":          req.Method,
+		"request_url":     req.URL.String(),
+		"request_headers": redactor.Headers(req.Header),
+		"request_body":    redactor.Body(requestBody),
+		"latency_ms":      latency.Milliseconds(),
+	}
+	message := "Request to CyberArk API failed"
+	if response != nil {
+		message = "Response from CyberArk API"
+		fields["response_status"] = response.Status
+		fields["response_body"] = redactor.Body(responseBody)
+	}
+
+	if c.logCtx == nil {
+		tflog.Debug(ctx, message, fields)
+		return
+	}
+	if c.logSubsystem == "" {
+		tflog.Debug(c.logCtx, message, fields)
+		return
+	}
+	tflog.SubsystemDebug(c.logCtx, c.logSubsystem, message, fields)
+}
+
 // NewClient creates a new Client instance with the provided base URL.
 func NewClient(baseURL string, logResponse bool, withBearerToken bool) *Client {
 	return &Client{
@@ -106,6 +181,39 @@ func NewClientWithToken(baseURL string, logResponse bool, authToken []byte, with
 	}
 }
 
+// NewClientWithTokenSource creates a new Client instance with the provided base URL that
+// authenticates requests with the token returned by source.
+func NewClientWithTokenSource(baseURL string, logResponse bool, source TokenSource, withBearerToken bool) *Client {
+	return &Client{
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		baseURL:         baseURL,
+		logResponse:     logResponse,
+		tokenSource:     source,
+		WithBearerToken: withBearerToken,
+	}
+}
+
+// with applies opts to the client and returns it.
+func (c *Client) with(opts ...ClientOption) *Client {
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// withTimeout returns a copy of the client whose requests time out after timeout.
+func (c *Client) withTimeout(timeout time.Duration) *Client {
+	client := *c
+	client.httpClient = &http.Client{
+		Timeout:   timeout,
+		Jar:       c.httpClient.Jar,
+		Transport: c.httpClient.Transport,
+	}
+	return &client
+}
+
 // JoinURL constructs a URL by joining the base URL with the provided path segments.
 func JoinURL(baseURL string, path string, params map[string]string) (string, error) {
 	baseURI, err := url.ParseRequestURI(baseURL)
@@ -196,3 +196,39 @@ func TestDoRequest(t *testing.T) {
 
 	})
 }
+
+func TestDoRequestWithTokenSource(t *testing.T) {
+	t.Run("AuthenticatesOnFirstRequest", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "Bearer dummy_token", req.Header.Get("Authorization"))
+		}))
+		defer server.Close()
+
+		logins := 0
+		client := cyberark.NewClientWithTokenSource(server.URL, false, cyberark.NewLazyTokenSource(func(context.Context) ([]byte, error) {
+			logins++
+			return []byte("dummy_token"), nil
+		}), true)
+
+		assert.Equal(t, 0, logins)
+		for i := 0; i < 2; i++ {
+			_, err := client.DoRequest(context.Background(), "GET", "/test", nil, map[string]string{}, map[string]string{})
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, 1, logins)
+	})
+
+	t.Run("LoginError", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			t.Error("no request should be sent when authentication fails")
+		}))
+		defer server.Close()
+
+		client := cyberark.NewClientWithTokenSource(server.URL, false, func(context.Context) ([]byte, error) {
+			return nil, fmt.Errorf("invalid credentials")
+		}, true)
+
+		_, err := client.DoRequest(context.Background(), "GET", "/test", nil, map[string]string{}, map[string]string{})
+		assert.ErrorContains(t, err, "authentication failed: invalid credentials")
+	})
+}
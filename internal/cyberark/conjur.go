@@ -0,0 +1,101 @@
+package cyberark
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// ConjurAuthenticator authenticates to Conjur and returns a base64 encoded access token.
+type ConjurAuthenticator func(ctx context.Context, client *Client, account string) ([]byte, error)
+
+// ConjurSecretSource fetches a secret from a Conjur variable.
+type ConjurSecretSource struct {
+	client       *Client
+	account      string
+	variable     string
+	authenticate ConjurAuthenticator
+}
+
+// GetSecret authenticates to Conjur and fetches the value of the variable.
+func (s *ConjurSecretSource) GetSecret(ctx context.Context) ([]byte, error) {
+	token, err := s.authenticate(ctx, s.client, s.account)
+	if err != nil {
+		return []byte{}, fmt.Errorf("failed to authenticate to Conjur: %w", err)
+	}
+
+	headers := map[string]string{
+		"Authorization": fmt.Sprintf(`Token token="%s"`, token),
+	}
+
+	resp, err := s.client.DoRequest(ctx, "GET",
+		fmt.Sprintf("/secrets/%s/variable/%s", url.PathEscape(s.account), url.PathEscape(s.variable)),
+		nil, headers, map[string]string{})
+	if err != nil {
+		return []byte{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return []byte{}, APIErrorFromResponse(resp.StatusCode, resp.Body)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// NewConjurSecretSource creates a new ConjurSecretSource for the variable of the Conjur account.
+func NewConjurSecretSource(baseURL, account, variable string, authenticate ConjurAuthenticator) *ConjurSecretSource {
+	return &ConjurSecretSource{
+		client:       NewClient(baseURL, false, false),
+		account:      account,
+		variable:     variable,
+		authenticate: authenticate,
+	}
+}
+
+// ConjurAPIKeyAuthenticator authenticates to Conjur with the API key of a user or host.
+func ConjurAPIKeyAuthenticator(login string, apiKey []byte) ConjurAuthenticator {
+	return func(ctx context.Context, client *Client, account string) ([]byte, error) {
+		return conjurAuthenticate(ctx, client,
+			fmt.Sprintf("/authn/%s/%s/authenticate", url.PathEscape(account), url.PathEscape(login)),
+			strings.NewReader(string(apiKey)), "text/plain")
+	}
+}
+
+// ConjurJWTAuthenticator authenticates to Conjur with a JWT through the authn-jwt authenticator
+// of the service ID. The JWT is read on every authentication.
+func ConjurJWTAuthenticator(serviceID string, jwt func() ([]byte, error)) ConjurAuthenticator {
+	return func(ctx context.Context, client *Client, account string) ([]byte, error) {
+		token, err := jwt()
+		if err != nil {
+			return []byte{}, err
+		}
+
+		body := url.Values{"jwt": {strings.TrimSpace(string(token))}}.Encode()
+		return conjurAuthenticate(ctx, client,
+			fmt.Sprintf("/authn-jwt/%s/%s/authenticate", url.PathEscape(serviceID), url.PathEscape(account)),
+			strings.NewReader(body), "application/x-www-form-urlencoded")
+	}
+}
+
+// conjurAuthenticate sends an authentication request and returns the base64 encoded access token.
+func conjurAuthenticate(ctx context.Context, client *Client, path string, body io.Reader, contentType string) ([]byte, error) {
+	headers := map[string]string{
+		"Content-Type":    contentType,
+		"Accept-Encoding": "base64",
+	}
+
+	resp, err := client.DoRequest(ctx, "POST", path, body, headers, map[string]string{})
+	if err != nil {
+		return []byte{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return []byte{}, APIErrorFromResponse(resp.StatusCode, resp.Body)
+	}
+
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,82 @@
+package cyberark_test
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConjurGetSecret(t *testing.T) {
+	accessToken := base64.StdEncoding.EncodeToString([]byte(`{"protected": "conjur"}`))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/authn/myorg/host%2Fterraform%2Fci/authenticate":
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != "api-key" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			assert.Equal(t, "base64", r.Header.Get("Accept-Encoding"))
+			_, _ = w.Write([]byte(accessToken))
+		case "/authn-jwt/github/myorg/authenticate":
+			assert.NoError(t, r.ParseForm())
+			assert.Equal(t, "header.payload.signature", r.PostForm.Get("jwt"))
+			_, _ = w.Write([]byte(accessToken))
+		case "/secrets/myorg/variable/terraform%2Fispss%2Fclient-secret":
+			assert.Equal(t, `Token token="`+accessToken+`"`, r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte("client-secret"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := map[string]struct {
+		variable     string
+		authenticate cyberark.ConjurAuthenticator
+		wantErr      string
+	}{
+		"APIKey": {
+			variable:     "terraform/ispss/client-secret",
+			authenticate: cyberark.ConjurAPIKeyAuthenticator("host/terraform/ci", []byte("api-key")),
+		},
+		"JWT": {
+			variable: "terraform/ispss/client-secret",
+			authenticate: cyberark.ConjurJWTAuthenticator("github", func() ([]byte, error) {
+				return []byte("header.payload.signature\n"), nil
+			}),
+		},
+		"InvalidAPIKey": {
+			variable:     "terraform/ispss/client-secret",
+			authenticate: cyberark.ConjurAPIKeyAuthenticator("host/terraform/ci", []byte("wrong")),
+			wantErr:      "failed to authenticate to Conjur: HTTP status code 401",
+		},
+		"MissingVariable": {
+			variable:     "terraform/missing",
+			authenticate: cyberark.ConjurAPIKeyAuthenticator("host/terraform/ci", []byte("api-key")),
+			wantErr:      "HTTP status code 404",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			source := cyberark.NewConjurSecretSource(server.URL, "myorg", test.variable, test.authenticate)
+
+			secret, err := source.GetSecret(context.Background())
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "client-secret", string(secret))
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package cyberark
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// DiscoveredAccounts is an interface for interacting with the pending (discovered) accounts list.
+type DiscoveredAccounts interface {
+	AddDiscoveredAccounts(ctx context.Context, accounts []DiscoveredAccount) ([]*DiscoveredAccountResponse, error)
+	ListDiscoveredAccounts(ctx context.Context, search string) ([]*DiscoveredAccount, error)
+	OnboardDiscoveredAccount(ctx context.Context, discoveredAccountID string, request OnboardDiscoveredAccountRequest) (*CredentialResponse, error)
+}
+
+// AddDiscoveredAccounts adds accounts to the discovered accounts list, or updates them if an
+// account with the same user name and address is already listed. The API adds one account per
+// request, so the accounts are added in order and adding stops at the first error.
+func (a *pamAPI) AddDiscoveredAccounts(ctx context.Context, accounts []DiscoveredAccount) ([]*DiscoveredAccountResponse, error) {
+	responses := []*DiscoveredAccountResponse{}
+
+	for _, account := range accounts {
+		body, err := json.Marshal(account)
+		if err != nil {
+			return responses, err
+		}
+
+		response, err := a.client.DoRequest(
+			ctx,
+			"POST",
+			"/PasswordVault/API/DiscoveredAccounts",
+			bytes.NewBuffer(body),
+			map[string]string{},
+			map[string]string{},
+		)
+		if err != nil {
+			return responses, err
+		}
+
+		if response.StatusCode != 200 && response.StatusCode != 201 {
+			return responses, APIErrorFromResponse(response.StatusCode, response.Body)
+		}
+
+		added := DiscoveredAccountResponse{}
+		err = json.NewDecoder(response.Body).Decode(&added)
+		if err != nil {
+			return responses, err
+		}
+
+		responses = append(responses, &added)
+	}
+
+	return responses, nil
+}
+
+// ListDiscoveredAccounts lists the discovered accounts matching the search, or all of them if
+// search is empty.
+func (a *pamAPI) ListDiscoveredAccounts(ctx context.Context, search string) ([]*DiscoveredAccount, error) {
+	accounts := []*DiscoveredAccount{}
+
+	for offset := 0; ; offset += pageSize {
+		params := a.filters(search, nil)
+		params["limit"] = strconv.Itoa(pageSize)
+		params["offset"] = strconv.Itoa(offset)
+
+		response, err := a.client.DoRequest(
+			ctx,
+			"GET",
+			"/PasswordVault/API/DiscoveredAccounts",
+			nil,
+			map[string]string{},
+			params,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.StatusCode != 200 {
+			return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+		}
+
+		page := DiscoveredAccountSearchResponse{}
+		err = json.NewDecoder(response.Body).Decode(&page)
+		if err != nil {
+			return nil, err
+		}
+
+		accounts = append(accounts, page.Accounts...)
+		if len(page.Accounts) < pageSize || (page.Count != nil && len(accounts) >= *page.Count) {
+			return accounts, nil
+		}
+	}
+}
+
+// OnboardDiscoveredAccount onboards a discovered account to a safe, which removes it from the
+// discovered accounts list.
+func (a *pamAPI) OnboardDiscoveredAccount(ctx context.Context, discoveredAccountID string, request OnboardDiscoveredAccountRequest) (*CredentialResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := a.client.DoRequest(
+		ctx,
+		"POST",
+		fmt.Sprintf("/PasswordVault/API/DiscoveredAccounts/%s/Onboard", url.PathEscape(discoveredAccountID)),
+		bytes.NewBuffer(body),
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 200 && response.StatusCode != 201 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	account := CredentialResponse{}
+	err = json.NewDecoder(response.Body).Decode(&account)
+	if err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
@@ -0,0 +1,116 @@
+package cyberark_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoveredAccounts(t *testing.T) {
+	t.Run("AddDiscoveredAccounts", func(t *testing.T) {
+		var users []string
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "POST", req.Method)
+			assert.Equal(t, "/PasswordVault/API/DiscoveredAccounts", req.URL.Path)
+
+			account := map[string]any{}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&account))
+			users = append(users, account["userName"].(string))
+			if account["userName"] == "root" {
+				assert.Equal(t, map[string]any{"UID": "0"}, account["additionalProperties"])
+			}
+
+			rw.WriteHeader(http.StatusCreated)
+			json.NewEncoder(rw).Encode(map[string]string{"id": "id-" + account["userName"].(string), "status": "added"})
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		admin, root, address, platformType := "admin", "root", "srv1", "Unix"
+		responses, err := client.AddDiscoveredAccounts(context.Background(), []cyberark.DiscoveredAccount{
+			{UserName: &admin, Address: &address, PlatformType: &platformType},
+			{UserName: &root, Address: &address, PlatformType: &platformType, AdditionalProperties: map[string]string{"UID": "0"}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"admin", "root"}, users)
+		require.Len(t, responses, 2)
+		assert.Equal(t, "id-root", *responses[1].ID)
+	})
+
+	t.Run("AddDiscoveredAccountsError", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(rw).Encode(map[string]string{"ErrorMessage": "Invalid platform type"})
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		user := "admin"
+		responses, err := client.AddDiscoveredAccounts(context.Background(), []cyberark.DiscoveredAccount{{UserName: &user}})
+
+		assert.ErrorContains(t, err, "Invalid platform type")
+		assert.Empty(t, responses)
+	})
+
+	t.Run("ListDiscoveredAccounts", func(t *testing.T) {
+		total := 1100
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "GET", req.Method)
+			assert.Equal(t, "srv", req.URL.Query().Get("search"))
+
+			offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+			limit, _ := strconv.Atoi(req.URL.Query().Get("limit"))
+			page := cyberark.DiscoveredAccountSearchResponse{Count: &total}
+			for i := offset; i < min(offset+limit, total); i++ {
+				id := fmt.Sprintf("id-%d", i)
+				page.Accounts = append(page.Accounts, &cyberark.DiscoveredAccount{ID: &id})
+			}
+			json.NewEncoder(rw).Encode(page)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		accounts, err := client.ListDiscoveredAccounts(context.Background(), "srv")
+
+		require.NoError(t, err)
+		assert.Len(t, accounts, total)
+	})
+
+	t.Run("OnboardDiscoveredAccount", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "POST", req.Method)
+			assert.Equal(t, "/PasswordVault/API/DiscoveredAccounts/id-1/Onboard", req.URL.Path)
+
+			body := map[string]string{}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			assert.Equal(t, map[string]string{"safeName": "Unix-Root", "platformId": "UnixSSH"}, body)
+
+			rw.WriteHeader(http.StatusCreated)
+			json.NewEncoder(rw).Encode(map[string]string{"id": "12_3", "safeName": "Unix-Root"})
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		safe, platform := "Unix-Root", "UnixSSH"
+		account, err := client.OnboardDiscoveredAccount(context.Background(), "id-1", cyberark.OnboardDiscoveredAccountRequest{
+			SafeName:   &safe,
+			PlatformID: &platform,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "12_3", *account.CredID)
+	})
+}
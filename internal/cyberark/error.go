@@ -5,15 +5,32 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 )
 
+// APIError is returned when a CyberArk API answers with an unexpected status code.
+type APIError struct {
+	StatusCode int
+	message    string
+}
+
+func (e *APIError) Error() string {
+	return e.message
+}
+
+// IsNotFound reports whether err is an APIError for a missing object.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
 func APIErrorFromResponse(code int, body io.ReadCloser) error {
 	errorStr := fmt.Sprintf("HTTP status code %d", code)
 
 	var jsonError interface{}
 	err := json.NewDecoder(body).Decode(&jsonError)
 	if err != nil {
-		return errors.New(errorStr)
+		return &APIError{StatusCode: code, message: errorStr}
 	}
 
 	if jsonError != nil {
@@ -28,5 +45,5 @@ func APIErrorFromResponse(code int, body io.ReadCloser) error {
 		}
 	}
 
-	return errors.New(errorStr)
+	return &APIError{StatusCode: code, message: errorStr}
 }
@@ -0,0 +1,290 @@
+package cyberark
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// identityLogSubsystem is the log subsystem of Identity API requests.
+const identityLogSubsystem = "cyberark.identity"
+
+// Identity role member types.
+const (
+	IdentityMemberUser  = "User"
+	IdentityMemberGroup = "Group"
+	IdentityMemberRole  = "Role"
+)
+
+// identityMemberKeys maps role member types to the keys of role updates that add or remove them.
+var identityMemberKeys = map[string]string{
+	IdentityMemberUser:  "Users",
+	IdentityMemberGroup: "Groups",
+	IdentityMemberRole:  "Roles",
+}
+
+// IdentityRoles is an interface for interacting with CyberArk Identity roles.
+type IdentityRoles interface {
+	AddRole(ctx context.Context, role IdentityRole) (*IdentityRole, error)
+	GetRole(ctx context.Context, roleID string) (*IdentityRole, error)
+	UpdateRole(ctx context.Context, roleID string, role IdentityRole) error
+	DeleteRole(ctx context.Context, roleID string) error
+	ListRoleMembers(ctx context.Context, roleID string) ([]*IdentityRoleMember, error)
+	AddRoleMember(ctx context.Context, roleID string, memberType string, memberID string) error
+	RemoveRoleMember(ctx context.Context, roleID string, memberType string, memberID string) error
+}
+
+// IdentityServiceUsers is an interface for interacting with CyberArk Identity service users.
+type IdentityServiceUsers interface {
+	AddServiceUser(ctx context.Context, user IdentityServiceUser) (*IdentityServiceUser, error)
+	GetServiceUser(ctx context.Context, userID string) (*IdentityServiceUser, error)
+	UpdateServiceUser(ctx context.Context, userID string, user IdentityServiceUser) error
+	SetServiceUserPassword(ctx context.Context, userID string, password []byte) error
+	DeleteServiceUser(ctx context.Context, userID string) error
+}
+
+// IdentityAPI is an interface for interacting with the CyberArk Identity APIs.
+type IdentityAPI interface {
+	IdentityRoles
+	IdentityServiceUsers
+}
+
+// identityAPI is a client for interacting with the CyberArk Identity APIs.
+type identityAPI struct {
+	client *Client
+}
+
+// AddRole adds a new Identity role.
+func (a *identityAPI) AddRole(ctx context.Context, role IdentityRole) (*IdentityRole, error) {
+	var result struct {
+		RowKey *string `json:"_RowKey"`
+	}
+	err := a.post(ctx, "/Roles/StoreRole", map[string]string{}, IdentityRole{
+		Name:        role.Name,
+		Description: role.Description,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.RowKey == nil {
+		return nil, fmt.Errorf("identity API returned no ID for role %s", StringValue(role.Name))
+	}
+
+	return &IdentityRole{
+		ID:          result.RowKey,
+		Name:        role.Name,
+		Description: role.Description,
+	}, nil
+}
+
+// GetRole retrieves an Identity role by its ID.
+func (a *identityAPI) GetRole(ctx context.Context, roleID string) (*IdentityRole, error) {
+	role := IdentityRole{}
+	err := a.post(ctx, "/Roles/GetRole", map[string]string{"name": roleID}, nil, &role)
+	if err != nil {
+		return nil, err
+	}
+
+	if role.ID == nil {
+		role.ID = &roleID
+	}
+
+	return &role, nil
+}
+
+// UpdateRole updates the description of an Identity role.
+func (a *identityAPI) UpdateRole(ctx context.Context, roleID string, role IdentityRole) error {
+	return a.post(ctx, "/Roles/UpdateRole", map[string]string{}, map[string]any{
+		"Name":        roleID,
+		"Description": StringValue(role.Description),
+	}, nil)
+}
+
+// DeleteRole deletes an Identity role.
+func (a *identityAPI) DeleteRole(ctx context.Context, roleID string) error {
+	return a.post(ctx, "/Roles/DeleteRole", map[string]string{}, map[string]string{"Name": roleID}, nil)
+}
+
+// ListRoleMembers lists the users, groups and roles that are members of an Identity role.
+func (a *identityAPI) ListRoleMembers(ctx context.Context, roleID string) ([]*IdentityRoleMember, error) {
+	var result struct {
+		Results []struct {
+			Row *IdentityRoleMember `json:"Row"`
+		} `json:"Results"`
+	}
+	err := a.post(ctx, "/Roles/GetRoleMembers", map[string]string{"name": roleID}, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*IdentityRoleMember, 0, len(result.Results))
+	for _, row := range result.Results {
+		if row.Row != nil {
+			members = append(members, row.Row)
+		}
+	}
+
+	return members, nil
+}
+
+// AddRoleMember adds a user, group or role to an Identity role.
+func (a *identityAPI) AddRoleMember(ctx context.Context, roleID string, memberType string, memberID string) error {
+	return a.updateRoleMembers(ctx, roleID, memberType, "Add", memberID)
+}
+
+// RemoveRoleMember removes a user, group or role from an Identity role.
+func (a *identityAPI) RemoveRoleMember(ctx context.Context, roleID string, memberType string, memberID string) error {
+	return a.updateRoleMembers(ctx, roleID, memberType, "Delete", memberID)
+}
+
+// updateRoleMembers adds or deletes a member of an Identity role.
+func (a *identityAPI) updateRoleMembers(ctx context.Context, roleID, memberType, action, memberID string) error {
+	key, ok := identityMemberKeys[memberType]
+	if !ok {
+		return fmt.Errorf("invalid role member type %q, must be %s, %s or %s",
+			memberType, IdentityMemberUser, IdentityMemberGroup, IdentityMemberRole)
+	}
+
+	return a.post(ctx, "/Roles/UpdateRole", map[string]string{}, map[string]any{
+		"Name": roleID,
+		key:    map[string][]string{action: {memberID}},
+	}, nil)
+}
+
+// identityServiceUserRequest is the body of requests that create Identity service users.
+type identityServiceUserRequest struct {
+	IdentityServiceUser
+	OauthClient         bool `json:"OauthClient"`
+	PasswordNeverExpire bool `json:"PasswordNeverExpire"`
+}
+
+// AddServiceUser adds a new Identity service user that is an OAuth2 confidential client, whose
+// password is the client secret.
+func (a *identityAPI) AddServiceUser(ctx context.Context, user IdentityServiceUser) (*IdentityServiceUser, error) {
+	var userID string
+	err := a.post(ctx, "/CDirectoryService/CreateUser", map[string]string{}, identityServiceUserRequest{
+		IdentityServiceUser: user,
+		OauthClient:         true,
+		PasswordNeverExpire: true,
+	}, &userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID == "" {
+		return nil, fmt.Errorf("identity API returned no ID for service user %s", StringValue(user.Name))
+	}
+
+	newUser := user
+	newUser.ID = &userID
+	newUser.Password = nil
+
+	return &newUser, nil
+}
+
+// GetServiceUser retrieves an Identity service user by its ID.
+func (a *identityAPI) GetServiceUser(ctx context.Context, userID string) (*IdentityServiceUser, error) {
+	user := IdentityServiceUser{}
+	err := a.post(ctx, "/CDirectoryService/GetUser", map[string]string{"ID": userID}, nil, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// UpdateServiceUser updates the display name, description and email of an Identity service user.
+func (a *identityAPI) UpdateServiceUser(ctx context.Context, userID string, user IdentityServiceUser) error {
+	return a.post(ctx, "/CDirectoryService/ChangeUser", map[string]string{}, map[string]string{
+		"ID":          userID,
+		"DisplayName": StringValue(user.DisplayName),
+		"Description": StringValue(user.Description),
+		"Mail":        StringValue(user.Mail),
+	}, nil)
+}
+
+// SetServiceUserPassword sets the password of an Identity service user. The password is zeroed
+// once it has been sent.
+func (a *identityAPI) SetServiceUserPassword(ctx context.Context, userID string, password []byte) error {
+	err := a.post(ctx, "/UserMgmt/ResetUserPassword", map[string]string{}, map[string]string{
+		"ID":          userID,
+		"newPassword": string(password),
+	}, nil)
+
+	for i := range password {
+		password[i] = 0
+	}
+
+	return err
+}
+
+// DeleteServiceUser deletes an Identity service user.
+func (a *identityAPI) DeleteServiceUser(ctx context.Context, userID string) error {
+	return a.post(ctx, "/UserMgmt/RemoveUser", map[string]string{"ID": userID}, nil, nil)
+}
+
+// post sends a request to the Identity API and decodes the result of its response into result,
+// unless result is nil. Identity answers failed requests with a 200 status and an unsuccessful
+// response, which is returned as an error.
+func (a *identityAPI) post(ctx context.Context, path string, params map[string]string, body any, result any) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewBuffer(encoded)
+	}
+
+	response, err := a.client.DoRequest(
+		ctx,
+		"POST",
+		path,
+		reader,
+		map[string]string{
+			"X-IDAP-NATIVE-CLIENT": "true",
+		},
+		params,
+	)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != 200 {
+		return APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	output := IdentityResponse{}
+	err = json.NewDecoder(response.Body).Decode(&output)
+	if err != nil {
+		return err
+	}
+
+	if !output.Success {
+		return fmt.Errorf("identity API request %s failed: %s %s", path, StringValue(output.ErrorCode), StringValue(output.Message))
+	}
+
+	if result == nil || len(output.Result) == 0 || string(output.Result) == "null" {
+		return nil
+	}
+
+	return json.Unmarshal(output.Result, result)
+}
+
+// NewIdentityAPI creates a new IdentityAPI client.
+func NewIdentityAPI(baseURL string, authToken []byte, opts ...ClientOption) IdentityAPI {
+	return &identityAPI{
+		client: NewClientWithToken(baseURL, true, authToken, true).with(withLogSubsystem(identityLogSubsystem)).with(opts...),
+	}
+}
+
+// NewIdentityAPIWithTokenSource creates a new IdentityAPI client that authenticates with the
+// token returned by source, allowing the login to be deferred until the first request.
+func NewIdentityAPIWithTokenSource(baseURL string, source TokenSource, opts ...ClientOption) IdentityAPI {
+	return &identityAPI{
+		client: NewClientWithTokenSource(baseURL, true, source, true).with(withLogSubsystem(identityLogSubsystem)).with(opts...),
+	}
+}
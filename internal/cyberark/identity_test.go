@@ -0,0 +1,200 @@
+package cyberark_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newIdentityServer returns an Identity API whose requests are handled by handler, which returns
+// the result of a successful response.
+func newIdentityServer(t *testing.T, handler func(req *http.Request, body map[string]interface{}) interface{}) cyberark.IdentityAPI {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "POST", req.Method)
+		assert.Equal(t, "true", req.Header.Get("X-IDAP-NATIVE-CLIENT"))
+		assert.Equal(t, "Bearer "+string(token), req.Header.Get("Authorization"))
+
+		var body map[string]interface{}
+		if req.ContentLength != 0 {
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		}
+
+		json.NewEncoder(rw).Encode(map[string]interface{}{
+			"success": true,
+			"Result":  handler(req, body),
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return cyberark.NewIdentityAPI(server.URL, token)
+}
+
+func TestIdentityRoles(t *testing.T) {
+	roleName := "Team Operators"
+	description := "Operators of the team"
+
+	t.Run("AddRole", func(t *testing.T) {
+		client := newIdentityServer(t, func(req *http.Request, body map[string]interface{}) interface{} {
+			assert.Equal(t, "/Roles/StoreRole", req.URL.Path)
+			assert.Equal(t, roleName, body["Name"])
+			assert.Equal(t, description, body["Description"])
+			return map[string]string{"_RowKey": "role_1"}
+		})
+
+		role, err := client.AddRole(context.Background(), cyberark.IdentityRole{Name: &roleName, Description: &description})
+
+		require.NoError(t, err)
+		assert.Equal(t, "role_1", *role.ID)
+		assert.Equal(t, roleName, *role.Name)
+	})
+
+	t.Run("GetRole", func(t *testing.T) {
+		client := newIdentityServer(t, func(req *http.Request, _ map[string]interface{}) interface{} {
+			assert.Equal(t, "/Roles/GetRole", req.URL.Path)
+			assert.Equal(t, "role_1", req.URL.Query().Get("name"))
+			return map[string]string{"Name": roleName, "Description": description}
+		})
+
+		role, err := client.GetRole(context.Background(), "role_1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "role_1", *role.ID)
+		assert.Equal(t, description, *role.Description)
+	})
+
+	t.Run("ListRoleMembers", func(t *testing.T) {
+		client := newIdentityServer(t, func(req *http.Request, _ map[string]interface{}) interface{} {
+			assert.Equal(t, "/Roles/GetRoleMembers", req.URL.Path)
+			return map[string]interface{}{
+				"Results": []interface{}{
+					map[string]interface{}{"Row": map[string]string{"Guid": "user_1", "Name": "svc@example", "Type": "User"}},
+					map[string]interface{}{"Row": map[string]string{"Guid": "role_2", "Name": "Auditors", "Type": "Role"}},
+				},
+			}
+		})
+
+		members, err := client.ListRoleMembers(context.Background(), "role_1")
+
+		require.NoError(t, err)
+		require.Len(t, members, 2)
+		assert.Equal(t, "user_1", *members[0].GUID)
+		assert.Equal(t, "Role", *members[1].Type)
+	})
+
+	t.Run("AddRoleMember", func(t *testing.T) {
+		client := newIdentityServer(t, func(req *http.Request, body map[string]interface{}) interface{} {
+			assert.Equal(t, "/Roles/UpdateRole", req.URL.Path)
+			assert.Equal(t, map[string]interface{}{
+				"Name":   "role_1",
+				"Groups": map[string]interface{}{"Add": []interface{}{"group_1"}},
+			}, body)
+			return nil
+		})
+
+		require.NoError(t, client.AddRoleMember(context.Background(), "role_1", cyberark.IdentityMemberGroup, "group_1"))
+	})
+
+	t.Run("RemoveRoleMember", func(t *testing.T) {
+		client := newIdentityServer(t, func(req *http.Request, body map[string]interface{}) interface{} {
+			assert.Equal(t, map[string]interface{}{"Delete": []interface{}{"user_1"}}, body["Users"])
+			return nil
+		})
+
+		require.NoError(t, client.RemoveRoleMember(context.Background(), "role_1", cyberark.IdentityMemberUser, "user_1"))
+	})
+
+	t.Run("InvalidMemberType", func(t *testing.T) {
+		client := cyberark.NewIdentityAPI("http://localhost", token)
+
+		err := client.AddRoleMember(context.Background(), "role_1", "Computer", "c_1")
+
+		assert.ErrorContains(t, err, "invalid role member type")
+	})
+
+	t.Run("UnsuccessfulResponse", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			json.NewEncoder(rw).Encode(map[string]interface{}{
+				"success":   false,
+				"Message":   "Role not found",
+				"ErrorCode": "NotFound",
+			})
+		}))
+		defer server.Close()
+
+		client := cyberark.NewIdentityAPI(server.URL, token)
+
+		_, err := client.GetRole(context.Background(), "missing")
+
+		assert.EqualError(t, err, "identity API request /Roles/GetRole failed: NotFound Role not found")
+	})
+}
+
+func TestIdentityServiceUsers(t *testing.T) {
+	username := "svc-terraform@example.cyberark.cloud"
+	displayName := "Terraform"
+
+	t.Run("AddServiceUser", func(t *testing.T) {
+		client := newIdentityServer(t, func(req *http.Request, body map[string]interface{}) interface{} {
+			assert.Equal(t, "/CDirectoryService/CreateUser", req.URL.Path)
+			assert.Equal(t, username, body["Name"])
+			assert.Equal(t, "s3cr3t", body["Password"])
+			assert.Equal(t, true, body["OauthClient"])
+			assert.Equal(t, true, body["PasswordNeverExpire"])
+			return "user_1"
+		})
+		password := "s3cr3t"
+
+		user, err := client.AddServiceUser(context.Background(), cyberark.IdentityServiceUser{
+			Name:        &username,
+			DisplayName: &displayName,
+			Password:    &password,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "user_1", *user.ID)
+		assert.Nil(t, user.Password)
+	})
+
+	t.Run("GetServiceUser", func(t *testing.T) {
+		client := newIdentityServer(t, func(req *http.Request, _ map[string]interface{}) interface{} {
+			assert.Equal(t, "/CDirectoryService/GetUser", req.URL.Path)
+			assert.Equal(t, "user_1", req.URL.Query().Get("ID"))
+			return map[string]string{"Uuid": "user_1", "Name": username, "DisplayName": displayName}
+		})
+
+		user, err := client.GetServiceUser(context.Background(), "user_1")
+
+		require.NoError(t, err)
+		assert.Equal(t, username, *user.Name)
+		assert.Equal(t, displayName, *user.DisplayName)
+	})
+
+	t.Run("SetServiceUserPassword", func(t *testing.T) {
+		client := newIdentityServer(t, func(req *http.Request, body map[string]interface{}) interface{} {
+			assert.Equal(t, "/UserMgmt/ResetUserPassword", req.URL.Path)
+			assert.Equal(t, "user_1", body["ID"])
+			assert.Equal(t, "n3w", body["newPassword"])
+			return nil
+		})
+		password := []byte("n3w")
+
+		require.NoError(t, client.SetServiceUserPassword(context.Background(), "user_1", password))
+		assert.Equal(t, []byte{0, 0, 0}, password)
+	})
+
+	t.Run("DeleteServiceUser", func(t *testing.T) {
+		client := newIdentityServer(t, func(req *http.Request, _ map[string]interface{}) interface{} {
+			assert.Equal(t, "/UserMgmt/RemoveUser", req.URL.Path)
+			assert.Equal(t, "user_1", req.URL.Query().Get("ID"))
+			return nil
+		})
+
+		require.NoError(t, client.DeleteServiceUser(context.Background(), "user_1"))
+	})
+}
@@ -0,0 +1,119 @@
+package cyberark
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// jwtBearerAssertionType is the OAuth client assertion type of a JWT (RFC 7523).
+const jwtBearerAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// OIDCAuthAPI exchanges OIDC JWTs issued to CI workloads (GitHub Actions, GitLab, Terraform Cloud
+// workload identity) for ISPSS platform tokens.
+type OIDCAuthAPI struct {
+	client *Client
+}
+
+// GetToken exchanges the JWT for a platform token of the service user with the given client ID.
+// The JWT must be federated with the service user in CyberArk Identity.
+func (a *OIDCAuthAPI) GetToken(ctx context.Context, clientID string, jwt []byte) ([]byte, error) {
+	token, _, err := a.GetExpiringToken(ctx, clientID, jwt)
+	return token, err
+}
+
+// GetExpiringToken exchanges the JWT for a platform token and returns the token and its expiry.
+func (a *OIDCAuthAPI) GetExpiringToken(ctx context.Context, clientID string, jwt []byte) ([]byte, time.Time, error) {
+	defer func() {
+		for i := range jwt {
+			jwt[i] = 0
+		}
+	}()
+
+	// JWTs read from files often end with a newline
+	assertion := bytes.TrimSpace(jwt)
+
+	expiry, err := jwtExpiry(assertion)
+	if err != nil {
+		return []byte{}, time.Time{}, err
+	}
+	if !expiry.IsZero() && !time.Now().Before(expiry) {
+		return []byte{}, time.Time{}, fmt.Errorf("OIDC token expired at %s", expiry.Format(time.RFC3339))
+	}
+
+	body := strings.NewReader(url.Values{
+		"client_id":             {clientID},
+		"grant_type":            {"client_credentials"},
+		"client_assertion_type": {jwtBearerAssertionType},
+		"client_assertion":      {string(assertion)},
+	}.Encode())
+	headers := map[string]string{
+		"Content-Type": "application/x-www-form-urlencoded",
+	}
+
+	resp, err := a.client.DoRequest(ctx, "POST", "/oauth2/platformtoken", body, headers, map[string]string{})
+	if err != nil {
+		return []byte{}, time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return []byte{}, time.Time{}, APIErrorFromResponse(resp.StatusCode, resp.Body)
+	}
+
+	var tokenResponse IdentityToken
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return []byte{}, time.Time{}, err
+	}
+
+	if tokenResponse.AccessToken == nil {
+		return []byte{}, time.Time{}, fmt.Errorf("invalid token response: %v", tokenResponse)
+	}
+
+	return []byte(*tokenResponse.AccessToken), tokenResponse.expiresAt(), nil
+}
+
+// NewOIDCAuthAPI creates a new OIDCAuthAPI instance with the provided Identity base URL.
+func NewOIDCAuthAPI(baseURL string) *OIDCAuthAPI {
+	return &OIDCAuthAPI{
+		client: NewClient(baseURL, false, true),
+	}
+}
+
+// jwtExpiry returns the expiry of a JWT, or the zero time if it has none. The signature is not
+// verified, that is up to CyberArk Identity. The JWT is only decoded to fail early with a clear
+// error when it is malformed or expired.
+func jwtExpiry(jwt []byte) (time.Time, error) {
+	parts := bytes.Split(jwt, []byte("."))
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("invalid OIDC token: expected a JWT with 3 parts, got %d", len(parts))
+	}
+
+	payload := make([]byte, base64.RawURLEncoding.DecodedLen(len(parts[1])))
+	n, err := base64.RawURLEncoding.Decode(payload, parts[1])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid OIDC token payload: %w", err)
+	}
+
+	var claims struct {
+		Expiry *json.Number `json:"exp"`
+	}
+	if err := json.Unmarshal(payload[:n], &claims); err != nil {
+		return time.Time{}, fmt.Errorf("invalid OIDC token claims: %w", err)
+	}
+
+	if claims.Expiry == nil {
+		return time.Time{}, nil
+	}
+
+	exp, err := claims.Expiry.Float64()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid OIDC token expiry: %w", err)
+	}
+	return time.Unix(int64(exp), 0), nil
+}
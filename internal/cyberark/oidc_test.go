@@ -0,0 +1,112 @@
+package cyberark_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signJWT returns an RS256 JWT with the given claims.
+func signJWT(t *testing.T, key *rsa.PrivateKey, claims map[string]interface{}) string {
+	t.Helper()
+
+	encode := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	signingInput := encode(map[string]string{"alg": "RS256", "typ": "JWT"}) + "." + encode(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// verifyJWT checks the RS256 signature of a JWT.
+func verifyJWT(key *rsa.PublicKey, jwt string) error {
+	parts := strings.Split(jwt, ".")
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature)
+}
+
+func TestOIDCGetToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/oauth2/platformtoken", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "ci@cyberark.cloud.1234", r.PostForm.Get("client_id"))
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "urn:ietf:params:oauth:client-assertion-type:jwt-bearer", r.PostForm.Get("client_assertion_type"))
+
+		if err := verifyJWT(&key.PublicKey, r.PostForm.Get("client_assertion")); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": "invalid_client"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token": "platform-token", "token_type": "Bearer", "expires_in": 3600}`))
+	}))
+	defer server.Close()
+
+	authAPI := cyberark.NewOIDCAuthAPI(server.URL)
+	future := time.Now().Add(time.Hour).Unix()
+
+	t.Run("Success", func(t *testing.T) {
+		requests = 0
+		jwt := []byte(signJWT(t, key, map[string]interface{}{"sub": "repo:org/infra", "exp": future}) + "\n")
+
+		token, err := authAPI.GetToken(context.Background(), "ci@cyberark.cloud.1234", jwt)
+		require.NoError(t, err)
+		assert.Equal(t, "platform-token", string(token))
+		assert.Equal(t, 1, requests)
+		assert.Equal(t, make([]byte, len(jwt)), jwt)
+	})
+
+	t.Run("UntrustedSigner", func(t *testing.T) {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		jwt := signJWT(t, otherKey, map[string]interface{}{"sub": "repo:org/infra", "exp": future})
+
+		_, err = authAPI.GetToken(context.Background(), "ci@cyberark.cloud.1234", []byte(jwt))
+		assert.ErrorContains(t, err, "HTTP status code 401")
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		requests = 0
+		jwt := signJWT(t, key, map[string]interface{}{"exp": time.Now().Add(-time.Minute).Unix()})
+
+		_, err := authAPI.GetToken(context.Background(), "ci@cyberark.cloud.1234", []byte(jwt))
+		assert.ErrorContains(t, err, "OIDC token expired at")
+		assert.Equal(t, 0, requests)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := authAPI.GetToken(context.Background(), "ci@cyberark.cloud.1234", []byte("not-a-jwt"))
+		assert.EqualError(t, err, "invalid OIDC token: expected a JWT with 3 parts, got 1")
+
+		_, err = authAPI.GetToken(context.Background(), "ci@cyberark.cloud.1234", []byte("a.!!!.c"))
+		assert.ErrorContains(t, err, "invalid OIDC token payload")
+	})
+}
@@ -0,0 +1,128 @@
+package cyberark
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// OnboardingRules is an interface for interacting with automatic onboarding rules.
+type OnboardingRules interface {
+	AddOnboardingRule(ctx context.Context, rule OnboardingRule) (*OnboardingRule, error)
+	ListOnboardingRules(ctx context.Context) ([]*OnboardingRule, error)
+	UpdateOnboardingRule(ctx context.Context, ruleID int, rule OnboardingRule) (*OnboardingRule, error)
+	DeleteOnboardingRule(ctx context.Context, ruleID int) error
+}
+
+// AddOnboardingRule adds a new automatic onboarding rule.
+func (a *pamAPI) AddOnboardingRule(ctx context.Context, rule OnboardingRule) (*OnboardingRule, error) {
+	body, err := json.Marshal(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := a.client.DoRequest(
+		ctx,
+		"POST",
+		"/PasswordVault/API/AutomaticOnboardingRules/",
+		bytes.NewBuffer(body),
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 200 && response.StatusCode != 201 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	newRule := OnboardingRule{}
+	err = json.NewDecoder(response.Body).Decode(&newRule)
+	if err != nil {
+		return nil, err
+	}
+
+	return &newRule, nil
+}
+
+// ListOnboardingRules lists the automatic onboarding rules. The API cannot get a single rule.
+func (a *pamAPI) ListOnboardingRules(ctx context.Context) ([]*OnboardingRule, error) {
+	response, err := a.client.DoRequest(
+		ctx,
+		"GET",
+		"/PasswordVault/API/AutomaticOnboardingRules/",
+		nil,
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	output := OnboardingRuleSearchResponse{}
+	err = json.NewDecoder(response.Body).Decode(&output)
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Rules, nil
+}
+
+// UpdateOnboardingRule updates an automatic onboarding rule.
+func (a *pamAPI) UpdateOnboardingRule(ctx context.Context, ruleID int, rule OnboardingRule) (*OnboardingRule, error) {
+	body, err := json.Marshal(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := a.client.DoRequest(
+		ctx,
+		"PUT",
+		fmt.Sprintf("/PasswordVault/API/AutomaticOnboardingRules/%d/", ruleID),
+		bytes.NewBuffer(body),
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	updated := OnboardingRule{}
+	err = json.NewDecoder(response.Body).Decode(&updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteOnboardingRule deletes an automatic onboarding rule.
+func (a *pamAPI) DeleteOnboardingRule(ctx context.Context, ruleID int) error {
+	response, err := a.client.DoRequest(
+		ctx,
+		"DELETE",
+		fmt.Sprintf("/PasswordVault/API/AutomaticOnboardingRules/%d/", ruleID),
+		nil,
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != 200 && response.StatusCode != 204 {
+		return APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package cyberark_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnboardingRules(t *testing.T) {
+	ruleID := 12
+	platform, safe, system := "WinServerLocal", "Windows-Servers", "Windows"
+
+	t.Run("AddOnboardingRule", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "POST", req.Method)
+			assert.Equal(t, "/PasswordVault/API/AutomaticOnboardingRules/", req.URL.Path)
+
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"TargetPlatformId": "WinServerLocal", "TargetSafeName": "Windows-Servers", "SystemTypeFilter": "Windows"}`, string(body))
+
+			rw.WriteHeader(http.StatusCreated)
+			json.NewEncoder(rw).Encode(cyberark.OnboardingRule{RuleID: &ruleID, TargetPlatformID: &platform})
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		rule, err := client.AddOnboardingRule(context.Background(), cyberark.OnboardingRule{
+			TargetPlatformID: &platform,
+			TargetSafeName:   &safe,
+			SystemTypeFilter: &system,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, ruleID, *rule.RuleID)
+	})
+
+	t.Run("ListOnboardingRules", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "GET", req.Method)
+			assert.Equal(t, "/PasswordVault/API/AutomaticOnboardingRules/", req.URL.Path)
+
+			rw.Write([]byte(`{"AutomaticOnboardingRules": [{"RuleId": 12, "RuleName": "Servers", "RulePrecedence": 1}], "Total": 1}`))
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		rules, err := client.ListOnboardingRules(context.Background())
+
+		require.NoError(t, err)
+		require.Len(t, rules, 1)
+		assert.Equal(t, "Servers", *rules[0].RuleName)
+		assert.Equal(t, 1, *rules[0].RulePrecedence)
+	})
+
+	t.Run("UpdateOnboardingRule", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "PUT", req.Method)
+			assert.Equal(t, "/PasswordVault/API/AutomaticOnboardingRules/12/", req.URL.Path)
+
+			rule := cyberark.OnboardingRule{}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&rule))
+			rule.RuleID = &ruleID
+			json.NewEncoder(rw).Encode(rule)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		rule, err := client.UpdateOnboardingRule(context.Background(), ruleID, cyberark.OnboardingRule{TargetSafeName: &safe})
+
+		require.NoError(t, err)
+		assert.Equal(t, safe, *rule.TargetSafeName)
+	})
+
+	t.Run("DeleteOnboardingRule", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "DELETE", req.Method)
+			assert.Equal(t, "/PasswordVault/API/AutomaticOnboardingRules/12/", req.URL.Path)
+			rw.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		assert.NoError(t, client.DeleteOnboardingRule(context.Background(), ruleID))
+	})
+}
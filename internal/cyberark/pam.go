@@ -7,7 +7,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -18,6 +21,7 @@ type Account interface {
 	AddAccount(ctx context.Context, credential Credential) (*CredentialResponse, error)
 	GetAccount(ctx context.Context, accountID string) (*CredentialResponse, error)
 	FilterAccounts(ctx context.Context, search string, filter []string) (*CredentialSearchResponse, error)
+	ListAccounts(ctx context.Context, safeName string) ([]*CredentialResponse, error)
 	UpdateAccount(ctx context.Context, accountID string, credential Credential) (*CredentialResponse, error)
 	DeleteAccount(ctx context.Context, accountID string) error
 }
@@ -26,6 +30,7 @@ type Account interface {
 type Safe interface {
 	AddSafe(ctx context.Context, safe SafeData) (*SafeData, error)
 	GetSafe(ctx context.Context, safeID string) (*SafeData, error)
+	ListSafes(ctx context.Context) ([]*SafeData, error)
 	UpdateSafe(ctx context.Context, safeID string, safe SafeData) (*SafeData, error)
 	DeleteSafe(ctx context.Context, safeID string) error
 }
@@ -34,6 +39,8 @@ type Safe interface {
 type SafeMember interface {
 	AddSafeMember(ctx context.Context, safe SafeData) (*Member, error)
 	GetSafeMember(ctx context.Context, safe SafeData) (*Member, error)
+	ListSafeMembers(ctx context.Context, safeName string) ([]*Member, error)
+	AddSyncMember(ctx context.Context, safeName string, memberName string) (*Member, error)
 	UpdateSafeMember(ctx context.Context, safe SafeData) (*Member, error)
 	DeleteSafeMember(ctx context.Context, safeName string, memberName string) error
 }
@@ -43,8 +50,17 @@ type PAMAPI interface {
 	Account
 	Safe
 	SafeMember
+	UserManagement
+	Platforms
+	Applications
+	OnboardingRules
+	DiscoveredAccounts
+	AccountGroups
 }
 
+// rootFolder is the folder accounts are added to when no folder is given.
+const rootFolder = "Root"
+
 // pamAPI is a client for interacting with the SecretsHub APIs.
 type pamAPI struct {
 	client    *Client
@@ -53,6 +69,10 @@ type pamAPI struct {
 
 // AddAccount adds a new account to the SecretsHub.
 func (a *pamAPI) AddAccount(ctx context.Context, credential Credential) (*CredentialResponse, error) {
+	if credential.Folder != nil && *credential.Folder != "" && *credential.Folder != rootFolder {
+		return a.addAccountToFolder(ctx, credential)
+	}
+
 	body, err := json.Marshal(credential)
 	if err != nil {
 		return nil, err
@@ -83,6 +103,92 @@ func (a *pamAPI) AddAccount(ctx context.Context, credential Credential) (*Creden
 	return &createdAccount, nil
 }
 
+// addAccountToFolder adds a new account to a folder of a safe. The Accounts API always adds accounts
+// to the root folder, so the account is added through the PIMServices API, which does not return it.
+// The account is then looked up by its name and its remote machines access is set.
+func (a *pamAPI) addAccountToFolder(ctx context.Context, credential Credential) (*CredentialResponse, error) {
+	if credential.Name == nil || credential.SafeName == nil {
+		return nil, fmt.Errorf("accounts can only be added to a folder with a name and a safe")
+	}
+
+	account := classicAccount{
+		Safe:        credential.SafeName,
+		Folder:      credential.Folder,
+		PlatformID:  credential.Platform,
+		Address:     credential.Address,
+		AccountName: credential.Name,
+		Password:    credential.Secret,
+		UserName:    credential.UserName,
+	}
+	if mgmt := credential.SecretMgmt; mgmt != nil && mgmt.AutomaticManagement != nil && !*mgmt.AutomaticManagement {
+		disable := true
+		account.DisableAutoMgmt = &disable
+		account.DisableAutoMgmtReason = mgmt.ManualManagementReason
+	}
+
+	if credential.Props != nil {
+		// The platform properties are sent as key value pairs named like their JSON fields
+		propsJSON, err := json.Marshal(credential.Props)
+		if err != nil {
+			return nil, err
+		}
+		props := map[string]string{}
+		if err := json.Unmarshal(propsJSON, &props); err != nil {
+			return nil, err
+		}
+		for _, key := range slices.Sorted(maps.Keys(props)) {
+			account.Properties = append(account.Properties, classicProperty{Key: key, Value: props[key]})
+		}
+	}
+
+	body, err := json.Marshal(map[string]classicAccount{"account": account})
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := a.client.DoRequest(
+		ctx,
+		"POST",
+		"/PasswordVault/WebServices/PIMServices.svc/Account",
+		bytes.NewBuffer(body),
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 200 && response.StatusCode != 201 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	// The PIMServices API does not return the account, so it is looked up in the pages of its safe.
+	// Errors name the account, as it was added and must be imported or removed by hand.
+	accounts, err := a.ListAccounts(ctx, *credential.SafeName)
+	if err != nil {
+		return nil, fmt.Errorf("account %s was added to folder %s of safe %s, but looking it up failed, import it as %s/%s: %w",
+			*credential.Name, *credential.Folder, *credential.SafeName, *credential.SafeName, *credential.Name, err)
+	}
+
+	for _, created := range accounts {
+		if created.Name == nil || *created.Name != *credential.Name || created.CredID == nil {
+			continue
+		}
+
+		if credential.RemoteAccess != nil {
+			return a.UpdateAccount(ctx, *created.CredID, Credential{
+				Name:         created.Name,
+				Address:      created.Address,
+				RemoteAccess: credential.RemoteAccess,
+			})
+		}
+		return created, nil
+	}
+
+	return nil, fmt.Errorf("account %s was added to folder %s of safe %s, but the safe does not list it, import it as %s/%s once it is listed",
+		*credential.Name, *credential.Folder, *credential.SafeName, *credential.SafeName, *credential.Name)
+}
+
 // GetAccount retrieves an account from the SecretsHub.
 func (a *pamAPI) GetAccount(ctx context.Context, accountID string) (*CredentialResponse, error) {
 	response, err := a.client.DoRequest(
@@ -139,6 +245,47 @@ func (a *pamAPI) FilterAccounts(ctx context.Context, search string, filter []str
 	return &searchAccounts, nil
 }
 
+// pageSize is the number of objects requested per page when listing safes and accounts.
+const pageSize = 100
+
+// ListAccounts retrieves all accounts in the given safe, following pagination.
+func (a *pamAPI) ListAccounts(ctx context.Context, safeName string) ([]*CredentialResponse, error) {
+	accounts := []*CredentialResponse{}
+
+	for offset := 0; ; offset += pageSize {
+		params := a.filters("", []string{fmt.Sprintf("safeName eq %s", safeName)})
+		params["limit"] = strconv.Itoa(pageSize)
+		params["offset"] = strconv.Itoa(offset)
+
+		response, err := a.client.DoRequest(
+			ctx,
+			"GET",
+			"/PasswordVault/API/Accounts",
+			nil,
+			map[string]string{},
+			params,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.StatusCode != 200 {
+			return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+		}
+
+		page := CredentialSearchResponse{}
+		err = json.NewDecoder(response.Body).Decode(&page)
+		if err != nil {
+			return nil, err
+		}
+
+		accounts = append(accounts, page.Accounts...)
+		if len(page.Accounts) < pageSize || (page.Count != nil && len(accounts) >= *page.Count) {
+			return accounts, nil
+		}
+	}
+}
+
 func (a *pamAPI) filters(search string, filter []string) (query map[string]string) {
 	query = make(map[string]string)
 
@@ -317,6 +464,49 @@ func generateAccountPatch(existing *CredentialResponse, desired *Credential) ([]
 		}
 	}
 
+	// Remote machines access properties
+	if desired.RemoteAccess != nil {
+		existingAccess := existing.RemoteAccess
+		if existingAccess == nil {
+			existingAccess = &RemoteMachinesAccess{}
+		}
+
+		// Handle remoteMachines, where an empty value removes the list
+		if desired.RemoteAccess.RemoteMachines != nil {
+			machines := *desired.RemoteAccess.RemoteMachines
+			existingMachines := ""
+			if existingAccess.RemoteMachines != nil {
+				existingMachines = *existingAccess.RemoteMachines
+			}
+
+			if machines == "" && existingMachines != "" {
+				patch = append(patch, map[string]interface{}{
+					"op":   "remove",
+					"path": "/remoteMachinesAccess/remoteMachines",
+				})
+			} else if machines != existingMachines {
+				patch = append(patch, map[string]interface{}{
+					"op":    "replace",
+					"path":  "/remoteMachinesAccess/remoteMachines",
+					"value": machines,
+				})
+			}
+		}
+
+		// Handle accessRestrictedToRemoteMachines
+		if desired.RemoteAccess.AccessRestrictedToRemoteMachines != nil {
+			restricted := *desired.RemoteAccess.AccessRestrictedToRemoteMachines
+			existingRestricted := existingAccess.AccessRestrictedToRemoteMachines != nil && *existingAccess.AccessRestrictedToRemoteMachines
+			if restricted != existingRestricted {
+				patch = append(patch, map[string]interface{}{
+					"op":    "replace",
+					"path":  "/remoteMachinesAccess/accessRestrictedToRemoteMachines",
+					"value": restricted,
+				})
+			}
+		}
+	}
+
 	return patch, nil
 }
 
@@ -400,6 +590,43 @@ func (a *pamAPI) GetSafe(ctx context.Context, safeID string) (*SafeData, error)
 	return &safe, nil
 }
 
+// ListSafes retrieves all safes visible to the authenticated user, following pagination.
+func (a *pamAPI) ListSafes(ctx context.Context) ([]*SafeData, error) {
+	safes := []*SafeData{}
+
+	for offset := 0; ; offset += pageSize {
+		response, err := a.client.DoRequest(
+			ctx,
+			"GET",
+			"/PasswordVault/API/Safes",
+			nil,
+			map[string]string{},
+			map[string]string{
+				"limit":  strconv.Itoa(pageSize),
+				"offset": strconv.Itoa(offset),
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.StatusCode != 200 {
+			return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+		}
+
+		page := SafeSearchResponse{}
+		err = json.NewDecoder(response.Body).Decode(&page)
+		if err != nil {
+			return nil, err
+		}
+
+		safes = append(safes, page.Safes...)
+		if len(page.Safes) < pageSize || (page.Count != nil && len(safes) >= *page.Count) {
+			return safes, nil
+		}
+	}
+}
+
 // UpdateSafe updates a safe in the SecretsHub.
 func (a *pamAPI) UpdateSafe(ctx context.Context, safeID string, safe SafeData) (*SafeData, error) {
 	body, err := json.Marshal(safe)
@@ -466,10 +693,36 @@ func (a *pamAPI) AddSafeMember(ctx context.Context, safe SafeData) (*Member, err
 	tflog.Info(ctx, fmt.Sprintf("Generated permission block for: %s", *safe.Owner))
 	tflog.Debug(ctx, string(block))
 
+	return a.addSafeMember(ctx, *safe.Name, block)
+}
+
+// AddSyncMember adds the Secrets Hub or Conjur Sync service user to a safe with the
+// permissions it needs to sync the accounts of the safe.
+func (a *pamAPI) AddSyncMember(ctx context.Context, safeName string, memberName string) (*Member, error) {
+	var block []byte
+	var err error
+
+	switch memberName {
+	case SecretsHubMember:
+		block, err = SecretsHub()
+	case ConjurSyncMember:
+		block, err = ConjurSync()
+	default:
+		return nil, fmt.Errorf("%s is not a sync service user", memberName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return a.addSafeMember(ctx, safeName, block)
+}
+
+// addSafeMember adds the member of the permission block to a safe.
+func (a *pamAPI) addSafeMember(ctx context.Context, safeName string, block []byte) (*Member, error) {
 	response, err := a.client.DoRequest(
 		ctx,
 		"POST",
-		fmt.Sprintf("/PasswordVault/API/Safes/%s/Members", *safe.Name),
+		fmt.Sprintf("/PasswordVault/API/Safes/%s/Members", safeName),
 		bytes.NewBuffer(block),
 		map[string]string{},
 		map[string]string{},
@@ -518,6 +771,43 @@ func (a *pamAPI) GetSafeMember(ctx context.Context, safe SafeData) (*Member, err
 	return &safeMember, nil
 }
 
+// ListSafeMembers retrieves all members of a safe, following pagination.
+func (a *pamAPI) ListSafeMembers(ctx context.Context, safeName string) ([]*Member, error) {
+	members := []*Member{}
+
+	for offset := 0; ; offset += pageSize {
+		response, err := a.client.DoRequest(
+			ctx,
+			"GET",
+			fmt.Sprintf("/PasswordVault/API/Safes/%s/Members", safeName),
+			nil,
+			map[string]string{},
+			map[string]string{
+				"limit":  strconv.Itoa(pageSize),
+				"offset": strconv.Itoa(offset),
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.StatusCode != 200 {
+			return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+		}
+
+		page := SafeMemberSearchResponse{}
+		err = json.NewDecoder(response.Body).Decode(&page)
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, page.Members...)
+		if len(page.Members) < pageSize || (page.Count != nil && len(members) >= *page.Count) {
+			return members, nil
+		}
+	}
+}
+
 // UpdateSafeMember updates a safe member
 func (a *pamAPI) UpdateSafeMember(ctx context.Context, safe SafeData) (*Member, error) {
 	tflog.Debug(ctx, fmt.Sprintf("Updating permission for member %s to level %s.", *safe.Owner, *safe.Level))
@@ -588,15 +878,28 @@ func generateSafePermissions(safe *SafeData) ([]byte, error) {
 		return Approver(safe.OwnerType, safe.Owner)
 	case "manager":
 		return Manager(safe.OwnerType, safe.Owner)
+	case "application":
+		return ApplicationMember(safe.OwnerType, safe.Owner)
 	}
 
 	return []byte{}, errors.New("invalid permission level")
 }
 
+// pamLogSubsystem is the tflog subsystem PAM requests are logged to.
+const pamLogSubsystem = "cyberark.pam"
+
 // NewPAMAPI creates a new PAMAPI client.
-func NewPAMAPI(baseURL string, authToken []byte, withBearerToken bool) PAMAPI {
+func NewPAMAPI(baseURL string, authToken []byte, withBearerToken bool, opts ...ClientOption) PAMAPI {
 	return &pamAPI{
-		client:    NewClientWithToken(baseURL, true, authToken, withBearerToken),
+		client:    NewClientWithToken(baseURL, true, authToken, withBearerToken).with(withLogSubsystem(pamLogSubsystem)).with(opts...),
 		authToken: authToken,
 	}
 }
+
+// NewPAMAPIWithTokenSource creates a new PAMAPI client that authenticates with the token
+// returned by source, allowing the login to be deferred until the first request.
+func NewPAMAPIWithTokenSource(baseURL string, source TokenSource, withBearerToken bool, opts ...ClientOption) PAMAPI {
+	return &pamAPI{
+		client: NewClientWithTokenSource(baseURL, true, source, withBearerToken).with(withLogSubsystem(pamLogSubsystem)).with(opts...),
+	}
+}
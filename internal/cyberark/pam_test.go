@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -118,6 +119,85 @@ func TestAddAccount(t *testing.T) {
 		assert.Empty(t, resp)
 		assert.Error(t, err)
 	})
+
+	t.Run("Folder", func(t *testing.T) {
+		safe, folder, platform, secret, port := "Databases", "BreakGlass", "MySQL", "secret", "3306"
+		manage, reason := false, "Break-glass account"
+
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			switch {
+			case req.Method == "POST":
+				assert.Equal(t, "/PasswordVault/WebServices/PIMServices.svc/Account", req.URL.Path)
+
+				body, _ := io.ReadAll(req.Body)
+				assert.JSONEq(t, `{"account": {
+					"safe": "Databases", "folder": "BreakGlass", "platformID": "MySQL", "accountName": "user",
+					"password": "secret", "disableAutoMgmt": true, "disableAutoMgmtReason": "Break-glass account",
+					"properties": [{"Key": "port", "Value": "3306"}]
+				}}`, string(body))
+				rw.WriteHeader(http.StatusCreated)
+			case req.Method == "GET":
+				assert.Equal(t, "/PasswordVault/API/Accounts", req.URL.Path)
+				assert.Equal(t, "safeName eq Databases", req.URL.Query().Get("filter"))
+
+				// The new account is only listed on the second page of the safe
+				other, total := "other", 101
+				page := cyberark.CredentialSearchResponse{Count: &total}
+				if req.URL.Query().Get("offset") == "0" {
+					for range 100 {
+						page.Accounts = append(page.Accounts, &cyberark.CredentialResponse{CredID: &other, Name: &other})
+					}
+				} else {
+					page.Accounts = []*cyberark.CredentialResponse{{CredID: &credID, Name: &name, SafeName: &safe}}
+				}
+				json.NewEncoder(rw).Encode(page)
+			default:
+				t.Errorf("unexpected request: %s %s", req.Method, req.URL)
+			}
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, true)
+
+		resp, err := client.AddAccount(context.Background(), cyberark.Credential{
+			Name:       &name,
+			SafeName:   &safe,
+			Folder:     &folder,
+			Platform:   &platform,
+			Secret:     &secret,
+			SecretMgmt: &cyberark.SecretManagement{AutomaticManagement: &manage, ManualManagementReason: &reason},
+			Props:      &cyberark.AccountProps{Port: &port},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, credID, *resp.CredID)
+		assert.False(t, manage)
+	})
+
+	t.Run("FolderAccountNotListed", func(t *testing.T) {
+		safe, folder, platform := "Databases", "BreakGlass", "MySQL"
+
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if req.Method == "POST" {
+				rw.WriteHeader(http.StatusCreated)
+				return
+			}
+			json.NewEncoder(rw).Encode(cyberark.CredentialSearchResponse{Accounts: []*cyberark.CredentialResponse{}})
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, true)
+
+		_, err := client.AddAccount(context.Background(), cyberark.Credential{
+			Name:     &name,
+			SafeName: &safe,
+			Folder:   &folder,
+			Platform: &platform,
+		})
+
+		// The error names the account that was added, so that it can be recovered
+		assert.EqualError(t, err, "account user was added to folder BreakGlass of safe Databases, but the safe does not list it, import it as Databases/user once it is listed")
+	})
 }
 
 func TestGetAccount(t *testing.T) {
@@ -287,6 +367,50 @@ func TestFilterAccounts_SearchAndFilter(t *testing.T) {
 	})
 }
 
+func TestListAccounts(t *testing.T) {
+	t.Run("Paginates", func(t *testing.T) {
+		total := 150
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			requests++
+			assert.Equal(t, "/PasswordVault/API/Accounts", req.URL.Path)
+			assert.Equal(t, fmt.Sprintf("safeName eq %s", safe), req.URL.Query().Get("filter"))
+			assert.Equal(t, "100", req.URL.Query().Get("limit"))
+
+			offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+			page := cyberark.CredentialSearchResponse{Count: &total}
+			for i := offset; i < total && i < offset+100; i++ {
+				id := strconv.Itoa(i)
+				page.Accounts = append(page.Accounts, &cyberark.CredentialResponse{CredID: &id})
+			}
+			json.NewEncoder(rw).Encode(page)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, true)
+
+		resp, err := client.ListAccounts(context.Background(), safe)
+
+		assert.NoError(t, err)
+		assert.Len(t, resp, total)
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("ErrorStatusCode", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+			http.Error(rw, "Internal Server Error", http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, true)
+
+		resp, err := client.ListAccounts(context.Background(), safe)
+
+		assert.Empty(t, resp)
+		assert.Error(t, err)
+	})
+}
+
 func TestUpdateAccount(t *testing.T) {
 	t.Run("UpdateAccount", func(t *testing.T) {
 		// Track if both endpoints are called
@@ -448,6 +572,76 @@ func TestUpdateAccount(t *testing.T) {
 		assert.Equal(t, name, *resp.Name)
 	})
 
+	t.Run("RemoteMachinesAccess", func(t *testing.T) {
+		existingMachines := "srv1;srv2"
+		machines, restricted := "srv1;srv3", true
+
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if req.Method == "GET" {
+				json.NewEncoder(rw).Encode(cyberark.CredentialResponse{
+					CredID:       &credID,
+					Name:         &name,
+					RemoteAccess: &cyberark.RemoteMachinesAccess{RemoteMachines: &existingMachines},
+				})
+				return
+			}
+
+			assert.Equal(t, "PATCH", req.Method)
+			body, err := io.ReadAll(req.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `[
+				{"op": "replace", "path": "/remoteMachinesAccess/remoteMachines", "value": "srv1;srv3"},
+				{"op": "replace", "path": "/remoteMachinesAccess/accessRestrictedToRemoteMachines", "value": true}
+			]`, string(body))
+
+			json.NewEncoder(rw).Encode(cyberark.CredentialResponse{
+				CredID:       &credID,
+				RemoteAccess: &cyberark.RemoteMachinesAccess{RemoteMachines: &machines, AccessRestrictedToRemoteMachines: &restricted},
+			})
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, true)
+
+		resp, err := client.UpdateAccount(context.Background(), credID, cyberark.Credential{
+			Name:         &name,
+			RemoteAccess: &cyberark.RemoteMachinesAccess{RemoteMachines: &machines, AccessRestrictedToRemoteMachines: &restricted},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, machines, *resp.RemoteAccess.RemoteMachines)
+	})
+
+	t.Run("RemoveRemoteMachines", func(t *testing.T) {
+		existingMachines := "srv1"
+		empty, unrestricted := "", false
+
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if req.Method == "GET" {
+				json.NewEncoder(rw).Encode(cyberark.CredentialResponse{
+					CredID:       &credID,
+					RemoteAccess: &cyberark.RemoteMachinesAccess{RemoteMachines: &existingMachines},
+				})
+				return
+			}
+
+			body, err := io.ReadAll(req.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `[{"op": "remove", "path": "/remoteMachinesAccess/remoteMachines"}]`, string(body))
+
+			json.NewEncoder(rw).Encode(cyberark.CredentialResponse{CredID: &credID})
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, true)
+
+		_, err := client.UpdateAccount(context.Background(), credID, cyberark.Credential{
+			RemoteAccess: &cyberark.RemoteMachinesAccess{RemoteMachines: &empty, AccessRestrictedToRemoteMachines: &unrestricted},
+		})
+
+		assert.NoError(t, err)
+	})
+
 	t.Run("ErrorStatusCode", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
 			http.Error(rw, "Internal Server Error", http.StatusInternalServerError)
@@ -664,6 +858,92 @@ func TestGetSafe(t *testing.T) {
 	})
 }
 
+func TestListSafes(t *testing.T) {
+	t.Run("Paginates", func(t *testing.T) {
+		total := 101
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			requests++
+			assert.Equal(t, "/PasswordVault/API/Safes", req.URL.Path)
+
+			offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+			page := cyberark.SafeSearchResponse{Count: &total}
+			for i := offset; i < total && i < offset+100; i++ {
+				safeName := fmt.Sprintf("safe-%d", i)
+				page.Safes = append(page.Safes, &cyberark.SafeData{Name: &safeName})
+			}
+			json.NewEncoder(rw).Encode(page)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, true)
+
+		resp, err := client.ListSafes(context.Background())
+
+		assert.NoError(t, err)
+		assert.Len(t, resp, total)
+		assert.Equal(t, "safe-100", *resp[100].Name)
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("ErrorStatusCode", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+			http.Error(rw, "Unauthorized", http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, true)
+
+		resp, err := client.ListSafes(context.Background())
+
+		assert.Empty(t, resp)
+		assert.Error(t, err)
+	})
+}
+
+func TestListSafeMembers(t *testing.T) {
+	t.Run("Paginates", func(t *testing.T) {
+		total := 120
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			requests++
+			assert.Equal(t, fmt.Sprintf("/PasswordVault/API/Safes/%s/Members", safe), req.URL.Path)
+
+			offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+			page := cyberark.SafeMemberSearchResponse{Count: &total}
+			for i := offset; i < total && i < offset+100; i++ {
+				memberName := fmt.Sprintf("member-%d", i)
+				page.Members = append(page.Members, &cyberark.Member{Member: &memberName})
+			}
+			json.NewEncoder(rw).Encode(page)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, true)
+
+		resp, err := client.ListSafeMembers(context.Background(), safe)
+
+		assert.NoError(t, err)
+		assert.Len(t, resp, total)
+		assert.Equal(t, "member-119", *resp[119].Member)
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("ErrorStatusCode", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+			http.Error(rw, "Not Found", http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, true)
+
+		resp, err := client.ListSafeMembers(context.Background(), safe)
+
+		assert.Empty(t, resp)
+		assert.Error(t, err)
+	})
+}
+
 func TestUpdateSafe(t *testing.T) {
 	t.Run("UpdateSafe", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
@@ -895,6 +1175,70 @@ func TestAddSafeMember(t *testing.T) {
 	})
 }
 
+func TestAddSyncMember(t *testing.T) {
+	testCases := []struct {
+		name        string
+		memberName  string
+		permissions cyberark.Permission
+	}{
+		{
+			name:       "SecretsHub",
+			memberName: cyberark.SecretsHubMember,
+			permissions: cyberark.Permission{
+				ViewSafeMembers:           true,
+				RetrieveAccounts:          true,
+				ListAccounts:              true,
+				AccessWithoutConfirmation: true,
+			},
+		},
+		{
+			name:       "ConjurSync",
+			memberName: cyberark.ConjurSyncMember,
+			permissions: cyberark.Permission{
+				UseAccounts:               true,
+				RetrieveAccounts:          true,
+				ListAccounts:              true,
+				AccessWithoutConfirmation: true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, "POST", req.Method)
+				assert.Equal(t, fmt.Sprintf("/PasswordVault/API/Safes/%s/Members", safe), req.URL.Path)
+
+				var member cyberark.Member
+				assert.NoError(t, json.NewDecoder(req.Body).Decode(&member))
+				assert.Equal(t, tc.memberName, *member.Member)
+				assert.Equal(t, "User", *member.MemberType)
+				assert.Equal(t, tc.permissions, member.Perm)
+
+				rw.WriteHeader(http.StatusCreated)
+				json.NewEncoder(rw).Encode(member)
+			}))
+			defer server.Close()
+
+			client := cyberark.NewPAMAPI(server.URL, token, true)
+
+			member, err := client.AddSyncMember(context.Background(), safe, tc.memberName)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.memberName, *member.Member)
+		})
+	}
+
+	t.Run("UnknownMember", func(t *testing.T) {
+		client := cyberark.NewPAMAPI("http://localhost:12345", token, true)
+
+		member, err := client.AddSyncMember(context.Background(), safe, "Administrator")
+
+		assert.EqualError(t, err, "Administrator is not a sync service user")
+		assert.Nil(t, member)
+	})
+}
+
 func TestGetSafeMember(t *testing.T) {
 	t.Run("GetSafeMember", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
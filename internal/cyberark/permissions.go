@@ -6,6 +6,12 @@ import (
 	"errors"
 )
 
+// Names of the component users which sync the accounts of the safes they are members of.
+const (
+	SecretsHubMember = "SecretsHub"
+	ConjurSyncMember = "ConjurSync"
+)
+
 /*
 =========================================
 * Functions
@@ -153,6 +159,33 @@ func Manager(userType *string, User *string) ([]byte, error) {
 	return thisBlock, nil
 }
 
+// ApplicationMember gets Application Permissions, which let the Credential Provider retrieve
+// accounts on behalf of an application ID
+// intakes a user type string and user string to bundle permissions
+func ApplicationMember(userType *string, User *string) ([]byte, error) {
+	Perm := Permission{
+		RetrieveAccounts: true,
+		ListAccounts:     true,
+	}
+
+	if User == nil || userType == nil {
+		return nil, errors.New("either User or User Type is nil")
+	}
+
+	userBlock := Member{
+		Member:     User,
+		MemberType: userType,
+		Perm:       Perm,
+	}
+
+	thisBlock, err := json.Marshal(userBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	return thisBlock, nil
+}
+
 // ConjurSync gets Conjur Component User Permissions
 func ConjurSync() ([]byte, error) {
 	Perm := Permission{
@@ -162,7 +195,7 @@ func ConjurSync() ([]byte, error) {
 		AccessWithoutConfirmation: true,
 	}
 
-	US := "ConjurSync"
+	US := ConjurSyncMember
 	UT := "User"
 
 	userBlock := Member{
@@ -188,7 +221,7 @@ func SecretsHub() ([]byte, error) {
 		AccessWithoutConfirmation: true,
 	}
 
-	US := "SecretsHub"
+	US := SecretsHubMember
 	UT := "User"
 
 	userBlock := Member{
@@ -204,3 +237,38 @@ func SecretsHub() ([]byte, error) {
 
 	return thisBlock, nil
 }
+
+// PermissionLevel returns the permission level ("full", "read", "approver", "manager" or
+// "application") whose permission set matches perm exactly, or an empty string when perm is a
+// custom set.
+func PermissionLevel(perm Permission) string {
+	levels := []struct {
+		name  string
+		build func(*string, *string) ([]byte, error)
+	}{
+		{"full", FullAdmin},
+		{"read", ReadOnly},
+		{"approver", Approver},
+		{"manager", Manager},
+		{"application", ApplicationMember},
+	}
+
+	placeholder := ""
+	for _, level := range levels {
+		block, err := level.build(&placeholder, &placeholder)
+		if err != nil {
+			continue
+		}
+
+		member := Member{}
+		if err := json.Unmarshal(block, &member); err != nil {
+			continue
+		}
+
+		if member.Perm == perm {
+			return level.name
+		}
+	}
+
+	return ""
+}
@@ -220,3 +220,38 @@ func TestSecretsHub(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestPermissionLevel(t *testing.T) {
+	user := "user"
+	userType := "User"
+
+	for _, level := range []string{"full", "read", "approver", "manager", "application"} {
+		t.Run(level, func(t *testing.T) {
+			var build func(*string, *string) ([]byte, error)
+			switch level {
+			case "full":
+				build = cyberark.FullAdmin
+			case "read":
+				build = cyberark.ReadOnly
+			case "approver":
+				build = cyberark.Approver
+			case "manager":
+				build = cyberark.Manager
+			case "application":
+				build = cyberark.ApplicationMember
+			}
+
+			block, err := build(&userType, &user)
+			require.NoError(t, err)
+
+			var member cyberark.Member
+			require.NoError(t, json.Unmarshal(block, &member))
+
+			assert.Equal(t, level, cyberark.PermissionLevel(member.Perm))
+		})
+	}
+
+	t.Run("custom", func(t *testing.T) {
+		assert.Equal(t, "", cyberark.PermissionLevel(cyberark.Permission{ListAccounts: true}))
+	})
+}
@@ -0,0 +1,255 @@
+package cyberark
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// Platforms is an interface for interacting with vault platforms.
+type Platforms interface {
+	ListPlatforms(ctx context.Context, filter PlatformFilter) ([]*Platform, error)
+	ListTargetPlatforms(ctx context.Context, search string) ([]*TargetPlatform, error)
+	DuplicateTargetPlatform(ctx context.Context, targetID int, name string, description string) (*TargetPlatform, error)
+	ActivateTargetPlatform(ctx context.Context, targetID int) error
+	DeactivateTargetPlatform(ctx context.Context, targetID int) error
+	DeleteTargetPlatform(ctx context.Context, targetID int) error
+	ImportPlatform(ctx context.Context, zip []byte) (string, error)
+}
+
+// PlatformCache shares the platforms of each backend between plan checks, so that they are listed
+// once instead of once per account. The zero value is ready to use, and a nil cache lists the
+// platforms every time.
+type PlatformCache struct {
+	mu        sync.Mutex
+	platforms map[string][]*Platform
+}
+
+// Platforms returns all platforms of the backend served by pam, listing them if they are not
+// cached yet or refresh is set. It also reports whether the platforms came from the cache. Failed
+// listings are not cached.
+func (c *PlatformCache) Platforms(ctx context.Context, backend string, pam PAMAPI, refresh bool) ([]*Platform, bool, error) {
+	if c == nil {
+		platforms, err := pam.ListPlatforms(ctx, PlatformFilter{})
+		return platforms, false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if platforms, ok := c.platforms[backend]; ok && !refresh {
+		return platforms, true, nil
+	}
+
+	platforms, err := pam.ListPlatforms(ctx, PlatformFilter{})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if c.platforms == nil {
+		c.platforms = map[string][]*Platform{}
+	}
+	c.platforms[backend] = platforms
+	return platforms, false, nil
+}
+
+// ListPlatforms lists the platforms matching the filter.
+func (a *pamAPI) ListPlatforms(ctx context.Context, filter PlatformFilter) ([]*Platform, error) {
+	params := map[string]string{}
+	if filter.Active != nil {
+		params["Active"] = strconv.FormatBool(*filter.Active)
+	}
+	if filter.PlatformType != "" {
+		params["PlatformType"] = filter.PlatformType
+	}
+	if filter.Search != "" {
+		params["Search"] = filter.Search
+	}
+
+	response, err := a.client.DoRequest(
+		ctx,
+		"GET",
+		"/PasswordVault/API/Platforms",
+		nil,
+		map[string]string{},
+		params,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	output := PlatformSearchResponse{}
+	err = json.NewDecoder(response.Body).Decode(&output)
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Platforms, nil
+}
+
+// ListTargetPlatforms lists the target platforms, optionally only those matching search.
+func (a *pamAPI) ListTargetPlatforms(ctx context.Context, search string) ([]*TargetPlatform, error) {
+	params := map[string]string{}
+	if search != "" {
+		params["search"] = search
+	}
+
+	response, err := a.client.DoRequest(
+		ctx,
+		"GET",
+		"/PasswordVault/API/Platforms/Targets",
+		nil,
+		map[string]string{},
+		params,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	output := TargetPlatformSearchResponse{}
+	err = json.NewDecoder(response.Body).Decode(&output)
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Platforms, nil
+}
+
+// DuplicateTargetPlatform creates a copy of a target platform with a new name and description.
+func (a *pamAPI) DuplicateTargetPlatform(ctx context.Context, targetID int, name string, description string) (*TargetPlatform, error) {
+	body, err := json.Marshal(map[string]string{
+		"Name":        name,
+		"Description": description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := a.client.DoRequest(
+		ctx,
+		"POST",
+		fmt.Sprintf("/PasswordVault/API/Platforms/Targets/%d/Duplicate", targetID),
+		bytes.NewBuffer(body),
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 200 && response.StatusCode != 201 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	platform := TargetPlatform{}
+	err = json.NewDecoder(response.Body).Decode(&platform)
+	if err != nil {
+		return nil, err
+	}
+
+	return &platform, nil
+}
+
+// ActivateTargetPlatform activates a target platform, so that accounts can be added with it.
+func (a *pamAPI) ActivateTargetPlatform(ctx context.Context, targetID int) error {
+	return a.setTargetPlatformState(ctx, targetID, "activate")
+}
+
+// DeactivateTargetPlatform deactivates a target platform.
+func (a *pamAPI) DeactivateTargetPlatform(ctx context.Context, targetID int) error {
+	return a.setTargetPlatformState(ctx, targetID, "deactivate")
+}
+
+// setTargetPlatformState activates or deactivates a target platform.
+func (a *pamAPI) setTargetPlatformState(ctx context.Context, targetID int, action string) error {
+	response, err := a.client.DoRequest(
+		ctx,
+		"POST",
+		fmt.Sprintf("/PasswordVault/API/Platforms/Targets/%d/%s", targetID, action),
+		nil,
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != 200 && response.StatusCode != 204 {
+		return APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	return nil
+}
+
+// DeleteTargetPlatform deletes a target platform.
+func (a *pamAPI) DeleteTargetPlatform(ctx context.Context, targetID int) error {
+	response, err := a.client.DoRequest(
+		ctx,
+		"DELETE",
+		fmt.Sprintf("/PasswordVault/API/Platforms/Targets/%d", targetID),
+		nil,
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != 204 {
+		return APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	return nil
+}
+
+// ImportPlatform imports a platform package ZIP file and returns the ID of the new platform.
+func (a *pamAPI) ImportPlatform(ctx context.Context, zip []byte) (string, error) {
+	body, err := json.Marshal(map[string]string{
+		"ImportFile": base64.StdEncoding.EncodeToString(zip),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	response, err := a.client.DoRequest(
+		ctx,
+		"POST",
+		"/PasswordVault/API/Platforms/Import",
+		bytes.NewBuffer(body),
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	if response.StatusCode != 200 && response.StatusCode != 201 {
+		return "", APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	output := struct {
+		PlatformID *string `json:"PlatformID"`
+	}{}
+	err = json.NewDecoder(response.Body).Decode(&output)
+	if err != nil {
+		return "", err
+	}
+
+	if output.PlatformID == nil {
+		return "", fmt.Errorf("platform import returned no platform ID")
+	}
+
+	return *output.PlatformID, nil
+}
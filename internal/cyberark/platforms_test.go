@@ -0,0 +1,158 @@
+package cyberark_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlatforms(t *testing.T) {
+	platformID := "WinDomain"
+	active := true
+	targetID := 7
+
+	t.Run("ListPlatforms", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "GET", req.Method)
+			assert.Equal(t, "/PasswordVault/API/Platforms", req.URL.Path)
+			assert.Equal(t, "true", req.URL.Query().Get("Active"))
+			assert.Equal(t, "Regular", req.URL.Query().Get("PlatformType"))
+			assert.Equal(t, "Win", req.URL.Query().Get("Search"))
+
+			json.NewEncoder(rw).Encode(cyberark.PlatformSearchResponse{
+				Platforms: []*cyberark.Platform{{General: &cyberark.PlatformGeneral{ID: &platformID, Active: &active}}},
+			})
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		platforms, err := client.ListPlatforms(context.Background(), cyberark.PlatformFilter{
+			Active:       &active,
+			PlatformType: "Regular",
+			Search:       "Win",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, platforms, 1)
+		assert.Equal(t, platformID, *platforms[0].General.ID)
+	})
+
+	t.Run("ListTargetPlatforms", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "/PasswordVault/API/Platforms/Targets", req.URL.Path)
+			assert.Empty(t, req.URL.RawQuery)
+
+			json.NewEncoder(rw).Encode(cyberark.TargetPlatformSearchResponse{
+				Platforms: []*cyberark.TargetPlatform{{ID: &targetID, PlatformID: &platformID}},
+			})
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		platforms, err := client.ListTargetPlatforms(context.Background(), "")
+
+		require.NoError(t, err)
+		require.Len(t, platforms, 1)
+		assert.Equal(t, targetID, *platforms[0].ID)
+	})
+
+	t.Run("DuplicateTargetPlatform", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "POST", req.Method)
+			assert.Equal(t, "/PasswordVault/API/Platforms/Targets/7/Duplicate", req.URL.Path)
+
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			assert.Equal(t, map[string]string{"Name": "Payments Windows", "Description": "Copy"}, body)
+
+			newID, newPlatformID := 8, "PaymentsWindows"
+			rw.WriteHeader(http.StatusCreated)
+			json.NewEncoder(rw).Encode(cyberark.TargetPlatform{ID: &newID, PlatformID: &newPlatformID})
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		platform, err := client.DuplicateTargetPlatform(context.Background(), targetID, "Payments Windows", "Copy")
+
+		require.NoError(t, err)
+		assert.Equal(t, 8, *platform.ID)
+		assert.Equal(t, "PaymentsWindows", *platform.PlatformID)
+	})
+
+	t.Run("ActivateAndDeactivate", func(t *testing.T) {
+		var paths []string
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "POST", req.Method)
+			paths = append(paths, req.URL.Path)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		require.NoError(t, client.ActivateTargetPlatform(context.Background(), targetID))
+		require.NoError(t, client.DeactivateTargetPlatform(context.Background(), targetID))
+		assert.Equal(t, []string{
+			"/PasswordVault/API/Platforms/Targets/7/activate",
+			"/PasswordVault/API/Platforms/Targets/7/deactivate",
+		}, paths)
+	})
+
+	t.Run("DeleteTargetPlatform", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "DELETE", req.Method)
+			assert.Equal(t, "/PasswordVault/API/Platforms/Targets/7", req.URL.Path)
+			rw.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		require.NoError(t, client.DeleteTargetPlatform(context.Background(), targetID))
+	})
+
+	t.Run("ImportPlatform", func(t *testing.T) {
+		zip := []byte("PK\x03\x04platform")
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "POST", req.Method)
+			assert.Equal(t, "/PasswordVault/API/Platforms/Import", req.URL.Path)
+
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			assert.Equal(t, base64.StdEncoding.EncodeToString(zip), body["ImportFile"])
+
+			json.NewEncoder(rw).Encode(map[string]string{"PlatformID": "CustomSSH"})
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		id, err := client.ImportPlatform(context.Background(), zip)
+
+		require.NoError(t, err)
+		assert.Equal(t, "CustomSSH", id)
+	})
+
+	t.Run("ImportPlatformError", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(rw).Encode(map[string]string{"ErrorMessage": "Invalid package"})
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		_, err := client.ImportPlatform(context.Background(), []byte("zip"))
+
+		assert.ErrorContains(t, err, "Invalid package")
+	})
+}
@@ -0,0 +1,86 @@
+package cyberark
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DefaultRedactedKeys lists the JSON keys and HTTP headers whose values are never logged.
+var DefaultRedactedKeys = []string{
+	"secret", "password", "initialPassword", "newPassword", "appClientSecret", "access_token", "Authorization",
+}
+
+// redactedValue replaces the values of redacted keys in logs.
+const redactedValue = "REDACTED"
+
+// Redactor masks sensitive values in logged request and response bodies and headers. Keys are
+// matched case-insensitively, at any depth of JSON bodies.
+type Redactor struct {
+	keys map[string]bool
+}
+
+// NewRedactor returns a Redactor of DefaultRedactedKeys and extraKeys.
+func NewRedactor(extraKeys ...string) *Redactor {
+	r := &Redactor{keys: map[string]bool{}}
+	for _, key := range append(append([]string{}, DefaultRedactedKeys...), extraKeys...) {
+		r.keys[strings.ToLower(key)] = true
+	}
+	return r
+}
+
+// Body returns body with the values of redacted keys masked. Bodies which are not JSON are not
+// logged, since their sensitive values cannot be found.
+func (r *Redactor) Body(body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return fmt.Sprintf("<%d bytes of non-JSON content>", len(body))
+	}
+
+	redacted, err := json.Marshal(r.redact(value))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes of non-JSON content>", len(body))
+	}
+	return string(redacted)
+}
+
+// redact masks the values of redacted keys in a decoded JSON value.
+func (r *Redactor) redact(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, v := range value {
+			if r.keys[strings.ToLower(key)] {
+				value[key] = redactedValue
+			} else {
+				value[key] = r.redact(v)
+			}
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = r.redact(v)
+		}
+	}
+	return value
+}
+
+// Headers returns the headers with the values of redacted keys masked.
+func (r *Redactor) Headers(headers http.Header) map[string]string {
+	redacted := make(map[string]string, len(headers))
+	for key := range headers {
+		if r.keys[strings.ToLower(key)] {
+			redacted[key] = redactedValue
+		} else {
+			redacted[key] = strings.Join(headers.Values(key), ", ")
+		}
+	}
+	return redacted
+}
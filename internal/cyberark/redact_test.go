@@ -0,0 +1,178 @@
+package cyberark_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactorBody(t *testing.T) {
+	tests := []struct {
+		name      string
+		extraKeys []string
+		body      string
+		expected  string
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:     "Account",
+			body:     `{"name": "account", "secretType": "password", "secret": "s3cr3t", "platformAccountProperties": {"Port": 22}}`,
+			expected: `{"name":"account","platformAccountProperties":{"Port":22},"secret":"REDACTED","secretType":"password"}`,
+		},
+		{
+			name:     "Logon",
+			body:     `{"username": "admin", "password": "p@ss", "newPassword": "n3w"}`,
+			expected: `{"newPassword":"REDACTED","password":"REDACTED","username":"admin"}`,
+		},
+		{
+			name:     "AddUser",
+			body:     `{"username": "jdoe", "userType": "EPVUser", "initialPassword": "Init1!"}`,
+			expected: `{"initialPassword":"REDACTED","userType":"EPVUser","username":"jdoe"}`,
+		},
+		{
+			name:     "CaseInsensitive",
+			body:     `{"Password": "p@ss", "SECRET": "s3cr3t"}`,
+			expected: `{"Password":"REDACTED","SECRET":"REDACTED"}`,
+		},
+		{
+			name:     "Nested",
+			body:     `{"value": [{"data": {"appClientSecret": "azure"}}, {"data": {"appClientId": "id"}}]}`,
+			expected: `{"value":[{"data":{"appClientSecret":"REDACTED"}},{"data":{"appClientId":"id"}}]}`,
+		},
+		{
+			name:     "ObjectValue",
+			body:     `{"secret": {"value": "s3cr3t"}, "access_token": "token"}`,
+			expected: `{"access_token":"REDACTED","secret":"REDACTED"}`,
+		},
+		{
+			name:      "ExtraKeys",
+			extraKeys: []string{"newPassword", "ACCESSKEY"},
+			body:      `{"newPassword": "n3w", "accessKey": "key", "password": "p@ss"}`,
+			expected:  `{"accessKey":"REDACTED","newPassword":"REDACTED","password":"REDACTED"}`,
+		},
+		{
+			name:     "LargeNumber",
+			body:     `{"id": 12345678901234567890}`,
+			expected: `{"id":12345678901234567890}`,
+		},
+		{
+			name:     "String",
+			body:     `"session-token"`,
+			expected: `"session-token"`,
+		},
+		{
+			name:     "NotJSON",
+			body:     `password=p@ss`,
+			expected: `<13 bytes of non-JSON content>`,
+		},
+		{
+			name:     "TrailingContent",
+			body:     `{"name": "account"} password=p@ss`,
+			expected: `<33 bytes of non-JSON content>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, cyberark.NewRedactor(tt.extraKeys...).Body([]byte(tt.body)))
+		})
+	}
+}
+
+func TestRedactorHeaders(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer token")
+	headers.Set("Content-Type", "application/json")
+	headers.Set("X-Api-Key", "key")
+
+	assert.Equal(t, map[string]string{
+		"Authorization": "REDACTED",
+		"Content-Type":  "application/json",
+		"X-Api-Key":     "key",
+	}, cyberark.NewRedactor().Headers(headers))
+
+	assert.Equal(t, map[string]string{
+		"Authorization": "REDACTED",
+		"Content-Type":  "application/json",
+		"X-Api-Key":     "REDACTED",
+	}, cyberark.NewRedactor("x-api-key").Headers(headers))
+}
+
+func TestDoRequestLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "12_3", "secret": "account-password", "userName": "admin", "data": {"appClientSecret": "azure-secret"}}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		subsystem string
+		call      func(logCtx context.Context) error
+	}{
+		{
+			name:      "PAM",
+			subsystem: "cyberark.pam",
+			call: func(logCtx context.Context) error {
+				api := cyberark.NewPAMAPI(server.URL, []byte("bearer-token"), true, cyberark.WithRedactedKeys("userName"), cyberark.WithLogContext(logCtx))
+				if _, err := api.GetAccount(context.Background(), "12_3"); err != nil {
+					return err
+				}
+				_, err := api.GetAccount(context.Background(), "12_3")
+				return err
+			},
+		},
+		{
+			name:      "SecretsHub",
+			subsystem: "cyberark.secretshub",
+			call: func(logCtx context.Context) error {
+				api := cyberark.NewSecretsHubAPI(server.URL, []byte("bearer-token"), cyberark.WithRedactedKeys("userName"), cyberark.WithLogContext(logCtx))
+				if _, err := api.GetAzureAkvSecretStore(context.Background(), "store-1"); err != nil {
+					return err
+				}
+				_, err := api.GetAzureAkvSecretStore(context.Background(), "store-1")
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			// Requests are logged through the context the client was created with, in which its
+			// subsystem is registered once
+			logCtx := tflogtest.RootLogger(context.Background(), &output)
+
+			require.NoError(t, tt.call(logCtx))
+
+			logs := output.String()
+			assert.NotContains(t, logs, "bearer-token")
+			assert.NotContains(t, logs, "account-password")
+			assert.NotContains(t, logs, "admin")
+			assert.NotContains(t, logs, "azure-secret")
+
+			entries, err := tflogtest.MultilineJSONDecode(strings.NewReader(logs))
+			require.NoError(t, err)
+			require.Len(t, entries, 2)
+
+			for _, entry := range entries {
+				assert.Equal(t, "Response from CyberArk API", entry["@message"])
+				assert.Equal(t, "provider."+tt.subsystem, entry["@module"])
+				assert.Equal(t, "GET", entry["method"])
+				assert.Equal(t, "200 OK", entry["response_status"])
+				assert.Equal(t, `{"data":{"appClientSecret":"REDACTED"},"id":"12_3","secret":"REDACTED","userName":"REDACTED"}`, entry["response_body"])
+				assert.Equal(t, "REDACTED", entry["request_headers"].(map[string]interface{})["Authorization"])
+				assert.Contains(t, entry, "latency_ms")
+			}
+		})
+	}
+}
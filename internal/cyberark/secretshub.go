@@ -6,6 +6,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -26,6 +28,9 @@ type SecretStore interface {
 	UpdateGcpSecretStore(ctx context.Context, storeID string, body SecretStoreInput[GcpData]) (*SecretStoreOutput[GcpData], error)
 	DeleteSecretStore(ctx context.Context, storeID string) error
 	SetSecretStoreState(ctx context.Context, storeID string, action string) error
+	TestSecretStoreConnection(ctx context.Context, storeID string) (*SecretStoreConnectionStatus, error)
+	ScanSecretStore(ctx context.Context, storeID string) (*ScanOutput, error)
+	GetScanStatus(ctx context.Context, storeID string) (*ScanOutput, error)
 }
 
 // SyncPolicy is an interface for interacting with SecretsHub's sync policies.
@@ -348,6 +353,162 @@ func (a *secretsHubAPI) SetSecretStoreState(ctx context.Context, storeID string,
 	return nil
 }
 
+// TestSecretStoreConnection checks that SecretsHub can reach the given secret store
+// with its configured credentials.
+func (a *secretsHubAPI) TestSecretStoreConnection(ctx context.Context, storeID string) (*SecretStoreConnectionStatus, error) {
+	response, err := a.client.DoRequest(
+		ctx,
+		"GET",
+		fmt.Sprintf("/api/secret-stores/%s/status/connection", storeID),
+		nil,
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	output := SecretStoreConnectionStatus{}
+	err = json.NewDecoder(response.Body).Decode(&output)
+	if err != nil {
+		return nil, err
+	}
+
+	if output.Status == nil {
+		return nil, fmt.Errorf("invalid connection status response for secret store %s", storeID)
+	}
+
+	return &output, nil
+}
+
+// ScanSecretStore triggers a scan of the given secret store in the SecretsHub.
+func (a *secretsHubAPI) ScanSecretStore(ctx context.Context, storeID string) (*ScanOutput, error) {
+	response, err := a.client.DoRequest(
+		ctx,
+		"POST",
+		fmt.Sprintf("/api/scan-definitions/secret-stores/%s/scan", storeID),
+		bytes.NewBuffer([]byte("{}")),
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 200 && response.StatusCode != 201 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	output := ScanOutput{}
+	err = json.NewDecoder(response.Body).Decode(&output)
+	if err != nil {
+		return nil, err
+	}
+
+	return &output, nil
+}
+
+// GetScanStatus retrieves the status of the latest scan of the given secret store.
+func (a *secretsHubAPI) GetScanStatus(ctx context.Context, storeID string) (*ScanOutput, error) {
+	response, err := a.client.DoRequest(
+		ctx,
+		"GET",
+		fmt.Sprintf("/api/scan-definitions/secret-stores/%s", storeID),
+		nil,
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	output := ScanOutput{}
+	err = json.NewDecoder(response.Body).Decode(&output)
+	if err != nil {
+		return nil, err
+	}
+
+	return &output, nil
+}
+
+// SecretStoreDrift returns the JSON paths of the fields in which a live secret store differs from
+// the desired input. Fields that are unset in the input, or that the API does not return (such as
+// the Azure application client secret), are not compared.
+func SecretStoreDrift[T AwsAsmData | AzureAkvData | GcpData](live *SecretStoreOutput[T], desired SecretStoreInput[T]) ([]string, error) {
+	if live == nil {
+		return nil, fmt.Errorf("live secret store must not be nil")
+	}
+
+	liveFields, err := flattenJSON(map[string]interface{}{
+		"name":        live.Name,
+		"description": live.Description,
+		"data":        live.Data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	desiredFields, err := flattenJSON(map[string]interface{}{
+		"name":        desired.Name,
+		"description": desired.Description,
+		"data":        desired.Data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	drift := []string{}
+	for key, want := range desiredFields {
+		got, ok := liveFields[key]
+		if want == nil || !ok || got == nil {
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			drift = append(drift, key)
+		}
+	}
+	sort.Strings(drift)
+
+	return drift, nil
+}
+
+// flattenJSON converts a value to its JSON representation and flattens nested objects into
+// dot separated keys.
+func flattenJSON(value interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var nested map[string]interface{}
+	if err := json.Unmarshal(raw, &nested); err != nil {
+		return nil, err
+	}
+
+	flat := map[string]interface{}{}
+	var walk func(prefix string, node map[string]interface{})
+	walk = func(prefix string, node map[string]interface{}) {
+		for key, val := range node {
+			if child, ok := val.(map[string]interface{}); ok {
+				walk(prefix+key+".", child)
+				continue
+			}
+			flat[prefix+key] = val
+		}
+	}
+	walk("", nested)
+
+	return flat, nil
+}
+
 // AddSyncPolicy adds a new sync policy to the SecretsHub.
 func (a *secretsHubAPI) AddSyncPolicy(ctx context.Context, pi PolicyInput) (*PolicyExternalOutput, error) {
 	body, err := json.Marshal(pi)
@@ -505,14 +666,25 @@ func (a *secretsHubAPI) DeleteSyncPolicy(ctx context.Context, policyID string) e
 	return nil
 }
 
+// secretsHubLogSubsystem is the tflog subsystem Secrets Hub requests are logged to.
+const secretsHubLogSubsystem = "cyberark.secretshub"
+
 // NewSecretsHubAPI creates a new SecretsHubAPI client.
-func NewSecretsHubAPI(baseURL string, authToken []byte) SecretsHubAPI {
+func NewSecretsHubAPI(baseURL string, authToken []byte, opts ...ClientOption) SecretsHubAPI {
 	return &secretsHubAPI{
-		client:    NewClientWithToken(baseURL, true, authToken, true),
+		client:    NewClientWithToken(baseURL, true, authToken, true).with(withLogSubsystem(secretsHubLogSubsystem)).with(opts...),
 		authToken: authToken,
 	}
 }
 
+// NewSecretsHubAPIWithTokenSource creates a new SecretsHubAPI client that authenticates with the
+// token returned by source, allowing the login to be deferred until the first request.
+func NewSecretsHubAPIWithTokenSource(baseURL string, source TokenSource, opts ...ClientOption) SecretsHubAPI {
+	return &secretsHubAPI{
+		client: NewClientWithTokenSource(baseURL, true, source, true).with(withLogSubsystem(secretsHubLogSubsystem)).with(opts...),
+	}
+}
+
 func (a *secretsHubAPI) GetSecretFilter(ctx context.Context, storeID string, filterID string) (*SecretFilterOutput, error) {
 	response, err := a.client.DoRequest(
 		ctx,
@@ -720,6 +720,149 @@ func TestSetSecretStoreState(t *testing.T) {
 	})
 }
 
+func TestTestSecretStoreConnection(t *testing.T) {
+	var (
+		storeID = "test-store-id"
+		token   = []byte("dummy_token")
+	)
+
+	t.Run("ConnectionOK", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, http.MethodGet, req.Method)
+			assert.Equal(t, fmt.Sprintf("/api/secret-stores/%s/status/connection", storeID), req.URL.Path)
+			rw.Write([]byte(`{"status": "OK"}`))
+		}))
+		defer server.Close()
+
+		client := cyberark.NewSecretsHubAPI(server.URL, token)
+		resp, err := client.TestSecretStoreConnection(context.Background(), storeID)
+		assert.NoError(t, err)
+		assert.True(t, resp.IsConnected())
+	})
+
+	t.Run("ConnectionFailed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.Write([]byte(`{"status": "FAIL", "message": "Unable to assume role"}`))
+		}))
+		defer server.Close()
+
+		client := cyberark.NewSecretsHubAPI(server.URL, token)
+		resp, err := client.TestSecretStoreConnection(context.Background(), storeID)
+		assert.NoError(t, err)
+		assert.False(t, resp.IsConnected())
+		assert.Equal(t, "Unable to assume role", *resp.Message)
+	})
+
+	t.Run("MissingStatus", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.Write([]byte(`{}`))
+		}))
+		defer server.Close()
+
+		client := cyberark.NewSecretsHubAPI(server.URL, token)
+		resp, err := client.TestSecretStoreConnection(context.Background(), storeID)
+		assert.Empty(t, resp)
+		assert.Error(t, err)
+	})
+
+	t.Run("ErrorStatusCode", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			http.Error(rw, "Not Found", http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewSecretsHubAPI(server.URL, token)
+		resp, err := client.TestSecretStoreConnection(context.Background(), storeID)
+		assert.Empty(t, resp)
+		assert.Error(t, err)
+	})
+}
+
+func TestScanSecretStore(t *testing.T) {
+	var (
+		storeID = "test-store-id"
+		scanID  = "scan-id"
+		status  = "IN_PROGRESS"
+		token   = []byte("dummy_token")
+	)
+
+	t.Run("ScanSecretStore", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, fmt.Sprintf("/api/scan-definitions/secret-stores/%s/scan", storeID), req.URL.Path)
+			rw.WriteHeader(http.StatusCreated)
+			json.NewEncoder(rw).Encode(cyberark.ScanOutput{ID: &scanID, Status: &status})
+		}))
+		defer server.Close()
+
+		client := cyberark.NewSecretsHubAPI(server.URL, token)
+		resp, err := client.ScanSecretStore(context.Background(), storeID)
+		assert.NoError(t, err)
+		assert.Equal(t, scanID, *resp.ID)
+		assert.Equal(t, status, *resp.Status)
+	})
+
+	t.Run("ErrorStatusCode", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			http.Error(rw, "Scan already running", http.StatusConflict)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewSecretsHubAPI(server.URL, token)
+		resp, err := client.ScanSecretStore(context.Background(), storeID)
+		assert.Empty(t, resp)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetScanStatus(t *testing.T) {
+	var (
+		storeID = "test-store-id"
+		scanID  = "scan-id"
+		status  = "SUCCESS"
+		token   = []byte("dummy_token")
+	)
+
+	t.Run("GetScanStatus", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, http.MethodGet, req.Method)
+			assert.Equal(t, fmt.Sprintf("/api/scan-definitions/secret-stores/%s", storeID), req.URL.Path)
+			json.NewEncoder(rw).Encode(cyberark.ScanOutput{ID: &scanID, Status: &status})
+		}))
+		defer server.Close()
+
+		client := cyberark.NewSecretsHubAPI(server.URL, token)
+		resp, err := client.GetScanStatus(context.Background(), storeID)
+		assert.NoError(t, err)
+		assert.Equal(t, status, *resp.Status)
+	})
+
+	t.Run("ErrorStatusCode", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			http.Error(rw, "Internal Server Error", http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewSecretsHubAPI(server.URL, token)
+		resp, err := client.GetScanStatus(context.Background(), storeID)
+		assert.Empty(t, resp)
+		assert.Error(t, err)
+		assert.False(t, cyberark.IsNotFound(err))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			http.Error(rw, `"Secret store not found"`, http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewSecretsHubAPI(server.URL, token)
+		_, err := client.GetScanStatus(context.Background(), storeID)
+		assert.True(t, cyberark.IsNotFound(err))
+		assert.ErrorContains(t, err, "HTTP status code 404")
+	})
+}
+
 func TestAddSyncPolicy(t *testing.T) {
 	var (
 		policy   = "test_policy"
@@ -934,3 +1077,76 @@ func TestDeleteSyncPolicy(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestSecretStoreDrift(t *testing.T) {
+	var (
+		name        = "test_store"
+		otherName   = "other_store"
+		description = "test description"
+		alias       = "alias"
+		otherAlias  = "other_alias"
+		region      = "us-east-1"
+		clientID    = "client-id"
+		secret      = "client-secret"
+		connType    = "CONNECTOR"
+		connectorID = "connector-1"
+		otherConnID = "connector-2"
+	)
+
+	tests := []struct {
+		name string
+		run  func() ([]string, error)
+		want []string
+	}{
+		{
+			name: "no drift",
+			run: func() ([]string, error) {
+				return cyberark.SecretStoreDrift(
+					&cyberark.SecretStoreOutput[cyberark.AwsAsmData]{Name: &name, Description: &description, Data: &cyberark.AwsAsmData{AccountAlias: &alias, RegionID: &region}},
+					cyberark.SecretStoreInput[cyberark.AwsAsmData]{Name: &name, Description: &description, Data: &cyberark.AwsAsmData{AccountAlias: &alias, RegionID: &region}},
+				)
+			},
+			want: []string{},
+		},
+		{
+			name: "top level and data drift",
+			run: func() ([]string, error) {
+				return cyberark.SecretStoreDrift(
+					&cyberark.SecretStoreOutput[cyberark.AwsAsmData]{Name: &otherName, Description: &description, Data: &cyberark.AwsAsmData{AccountAlias: &otherAlias, RegionID: &region}},
+					cyberark.SecretStoreInput[cyberark.AwsAsmData]{Name: &name, Description: &description, Data: &cyberark.AwsAsmData{AccountAlias: &alias, RegionID: &region}},
+				)
+			},
+			want: []string{"data.accountAlias", "name"},
+		},
+		{
+			name: "nested connector drift ignores secret not returned by the API",
+			run: func() ([]string, error) {
+				return cyberark.SecretStoreDrift(
+					&cyberark.SecretStoreOutput[cyberark.AzureAkvData]{Name: &name, Data: &cyberark.AzureAkvData{
+						AppClientID: &clientID,
+						Connector:   &cyberark.Connector{ConnectionType: &connType, ConnectorID: &otherConnID},
+					}},
+					cyberark.SecretStoreInput[cyberark.AzureAkvData]{Name: &name, Data: &cyberark.AzureAkvData{
+						AppClientID:     &clientID,
+						AppClientSecret: &secret,
+						Connector:       &cyberark.Connector{ConnectionType: &connType, ConnectorID: &connectorID},
+					}},
+				)
+			},
+			want: []string{"data.connectionConfig.connectorId"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.run()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("NilLiveStore", func(t *testing.T) {
+		_, err := cyberark.SecretStoreDrift(nil, cyberark.SecretStoreInput[cyberark.GcpData]{Name: &name})
+		assert.Error(t, err)
+	})
+}
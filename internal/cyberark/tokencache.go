@@ -0,0 +1,304 @@
+package cyberark
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// TokenCacheMargin is how long a cached token must remain valid to be used, so that it does not
+// expire during a Terraform run.
+const TokenCacheMargin = 5 * time.Minute
+
+// tokenCacheKeySize is the size of the key cache entries are encrypted with.
+const tokenCacheKeySize = 32
+
+// TokenCache stores access tokens on disk so that Terraform runs can share them instead of each
+// requesting a new token. Only tokens are stored, never the secrets they were fetched with.
+//
+// The cache directory must only be accessible to the current user, which is what protects the
+// tokens. Entries are encrypted with AES-GCM, but with a key kept in the same directory, so the
+// encryption only keeps tokens from appearing in plain text, for example in backups of the
+// directory, and does not protect them from anyone who can read it.
+type TokenCache struct {
+	dir string
+	now func() time.Time
+}
+
+// cachedToken is the plaintext of a cache entry.
+type cachedToken struct {
+	Token     []byte    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// DefaultTokenCacheDir returns the token cache directory under $XDG_CACHE_HOME, or the user cache
+// directory of the platform if it is not set.
+func DefaultTokenCacheDir() (string, error) {
+	cacheDir := os.Getenv("XDG_CACHE_HOME")
+	if cacheDir == "" {
+		var err error
+		cacheDir, err = os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+	}
+	return filepath.Join(cacheDir, "terraform-provider-cyberark", "tokens"), nil
+}
+
+// NewTokenCache creates a new TokenCache in dir.
+func NewTokenCache(dir string) *TokenCache {
+	return &TokenCache{
+		dir: dir,
+		now: time.Now,
+	}
+}
+
+// Check creates the cache directory if needed, and returns an error if the cache can not be used,
+// for example because other users can access the directory.
+func (c *TokenCache) Check() error {
+	return c.ensureDir()
+}
+
+// Fetch returns the cached token of the key, or fetches and caches a new one. Processes sharing
+// the cache fetch one at a time, so that only one of them requests a new token. Tokens without
+// an expiry are not cached. If the cache can not be used, the token is fetched without it.
+func (c *TokenCache) Fetch(ctx context.Context, key string, fetch func(ctx context.Context) ([]byte, time.Time, error)) ([]byte, error) {
+	if token, err := c.Get(key); err == nil && token != nil {
+		return token, nil
+	}
+
+	unlock, err := c.lock(key)
+	if err != nil {
+		token, _, err := fetch(ctx)
+		return token, err
+	}
+	defer unlock()
+
+	// Another process may have fetched a token while this one waited for the lock
+	if token, err := c.Get(key); err == nil && token != nil {
+		return token, nil
+	}
+
+	token, expiresAt, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !expiresAt.IsZero() {
+		_ = c.Put(key, token, expiresAt)
+	}
+	return token, nil
+}
+
+// Get returns the cached token of the key, or nil if there is none that remains valid for at
+// least TokenCacheMargin.
+func (c *TokenCache) Get(key string) ([]byte, error) {
+	if err := c.ensureDir(); err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := os.ReadFile(c.path(key, ".token"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := c.cipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, nil
+	}
+
+	plaintext, err := aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], []byte(key))
+	if err != nil {
+		// Entries encrypted with another key are ignored and replaced
+		return nil, nil
+	}
+
+	var entry cachedToken
+	if err := json.Unmarshal(plaintext, &entry); err != nil {
+		return nil, nil
+	}
+
+	if !c.now().Add(TokenCacheMargin).Before(entry.ExpiresAt) {
+		return nil, nil
+	}
+	return entry.Token, nil
+}
+
+// Put caches the token of the key until it expires.
+func (c *TokenCache) Put(key string, token []byte, expiresAt time.Time) error {
+	if err := c.ensureDir(); err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(cachedToken{Token: token, ExpiresAt: expiresAt})
+	if err != nil {
+		return err
+	}
+
+	aead, err := c.cipher(key)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(c.path(key, ".token"), aead.Seal(nonce, nonce, plaintext, []byte(key)))
+}
+
+// path returns the path of a file of the key. Keys are hashed so that tenants and client IDs do
+// not appear in file names.
+func (c *TokenCache) path(key, extension string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+extension)
+}
+
+// ensureDir creates the cache directory if needed, and returns an error if other users can
+// access it.
+func (c *TokenCache) ensureDir() error {
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(c.dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("token cache %s is not a directory", c.dir)
+	}
+	return checkPrivateDir(c.dir, info)
+}
+
+// lock acquires the lock file of the key, blocking until other processes release it.
+func (c *TokenCache) lock(key string) (func(), error) {
+	if err := c.ensureDir(); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(c.path(key, ".lock"), os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lockFile(file); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return func() {
+		_ = unlockFile(file)
+		file.Close()
+	}, nil
+}
+
+// cipher returns the AEAD of the key's entries, whose key is derived from the cache key file.
+func (c *TokenCache) cipher(key string) (cipher.AEAD, error) {
+	masterKey, err := c.masterKey()
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha256.New, masterKey)
+	mac.Write([]byte(key))
+
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// masterKey returns the key of the cache, creating it on first use.
+func (c *TokenCache) masterKey() ([]byte, error) {
+	keyPath := filepath.Join(c.dir, "key")
+
+	masterKey, err := os.ReadFile(keyPath)
+	if err == nil && len(masterKey) == tokenCacheKeySize {
+		return masterKey, nil
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	if err := c.ensureDir(); err != nil {
+		return nil, err
+	}
+
+	masterKey = make([]byte, tokenCacheKeySize)
+	if _, err := rand.Read(masterKey); err != nil {
+		return nil, err
+	}
+
+	// Link the complete key into place so that concurrent processes agree on a single key
+	tmp, err := writeTempFile(c.dir, masterKey)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp)
+
+	if err := os.Link(tmp, keyPath); err != nil && !errors.Is(err, fs.ErrExist) {
+		return nil, err
+	}
+
+	masterKey, err = os.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(masterKey) != tokenCacheKeySize {
+		return nil, fmt.Errorf("invalid token cache key %s", keyPath)
+	}
+	return masterKey, nil
+}
+
+// writeFileAtomic replaces the file at path with data, so that readers never see partial content.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := writeTempFile(filepath.Dir(path), data)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// writeTempFile writes data to a new file in dir that only the current user can access.
+func writeTempFile(dir string, data []byte) (string, error) {
+	file, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
+}
@@ -0,0 +1,186 @@
+package cyberark_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tokenCacheDir returns a token cache directory that does not exist yet. Test directories are
+// accessible to other users, so the cache must create its own.
+func tokenCacheDir(t *testing.T) string {
+	return filepath.Join(t.TempDir(), "tokens")
+}
+
+func TestTokenCache(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		dir := tokenCacheDir(t)
+		cache := cyberark.NewTokenCache(dir)
+
+		require.NoError(t, cache.Put("tenant/client", []byte("platform-token"), time.Now().Add(time.Hour)))
+
+		token, err := cyberark.NewTokenCache(dir).Get("tenant/client")
+		require.NoError(t, err)
+		assert.Equal(t, "platform-token", string(token))
+
+		token, err = cache.Get("tenant/other-client")
+		require.NoError(t, err)
+		assert.Nil(t, token)
+	})
+
+	t.Run("Encrypted", func(t *testing.T) {
+		dir := tokenCacheDir(t)
+		cache := cyberark.NewTokenCache(dir)
+		require.NoError(t, cache.Put("tenant/client", []byte("platform-token"), time.Now().Add(time.Hour)))
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		for _, entry := range entries {
+			content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+			require.NoError(t, err)
+			assert.False(t, bytes.Contains(content, []byte("platform-token")), entry.Name())
+			assert.NotContains(t, entry.Name(), "client")
+
+			info, err := entry.Info()
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), entry.Name())
+		}
+	})
+
+	t.Run("CreatesPrivateDirectory", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "terraform-provider-cyberark", "tokens")
+		cache := cyberark.NewTokenCache(dir)
+		require.NoError(t, cache.Put("tenant/client", []byte("platform-token"), time.Now().Add(time.Hour)))
+
+		info, err := os.Stat(dir)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+	})
+
+	t.Run("RefusesSharedDirectory", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("Windows does not use mode bits")
+		}
+
+		dir := t.TempDir()
+		require.NoError(t, os.Chmod(dir, 0o750))
+		cache := cyberark.NewTokenCache(dir)
+
+		require.ErrorContains(t, cache.Put("tenant/client", []byte("platform-token"), time.Now().Add(time.Hour)), "accessible to other users")
+		_, err := cache.Get("tenant/client")
+		require.ErrorContains(t, err, "accessible to other users")
+
+		// Tokens are still fetched, without being cached
+		fetches := 0
+		for range 2 {
+			token, err := cache.Fetch(context.Background(), "tenant/client", func(context.Context) ([]byte, time.Time, error) {
+				fetches++
+				return []byte("platform-token"), time.Now().Add(time.Hour), nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, "platform-token", string(token))
+		}
+		assert.Equal(t, 2, fetches)
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		cache := cyberark.NewTokenCache(tokenCacheDir(t))
+
+		// Tokens expiring within the safety margin are not used
+		require.NoError(t, cache.Put("tenant/client", []byte("platform-token"), time.Now().Add(cyberark.TokenCacheMargin-time.Second)))
+		token, err := cache.Get("tenant/client")
+		require.NoError(t, err)
+		assert.Nil(t, token)
+
+		require.NoError(t, cache.Put("tenant/client", []byte("platform-token"), time.Now().Add(cyberark.TokenCacheMargin+time.Minute)))
+		token, err = cache.Get("tenant/client")
+		require.NoError(t, err)
+		assert.Equal(t, "platform-token", string(token))
+	})
+
+	t.Run("FetchRefreshesExpiredToken", func(t *testing.T) {
+		cache := cyberark.NewTokenCache(tokenCacheDir(t))
+		require.NoError(t, cache.Put("tenant/client", []byte("old-token"), time.Now().Add(time.Minute)))
+
+		token, err := cache.Fetch(context.Background(), "tenant/client", func(context.Context) ([]byte, time.Time, error) {
+			return []byte("new-token"), time.Now().Add(time.Hour), nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "new-token", string(token))
+
+		token, err = cache.Get("tenant/client")
+		require.NoError(t, err)
+		assert.Equal(t, "new-token", string(token))
+	})
+
+	t.Run("FetchDoesNotCacheWithoutExpiry", func(t *testing.T) {
+		cache := cyberark.NewTokenCache(tokenCacheDir(t))
+
+		_, err := cache.Fetch(context.Background(), "tenant/client", func(context.Context) ([]byte, time.Time, error) {
+			return []byte("platform-token"), time.Time{}, nil
+		})
+		require.NoError(t, err)
+
+		token, err := cache.Get("tenant/client")
+		require.NoError(t, err)
+		assert.Nil(t, token)
+	})
+
+	t.Run("FetchError", func(t *testing.T) {
+		cache := cyberark.NewTokenCache(tokenCacheDir(t))
+
+		_, err := cache.Fetch(context.Background(), "tenant/client", func(context.Context) ([]byte, time.Time, error) {
+			return nil, time.Time{}, fmt.Errorf("invalid client")
+		})
+		assert.EqualError(t, err, "invalid client")
+	})
+
+	t.Run("ConcurrentFetch", func(t *testing.T) {
+		dir := tokenCacheDir(t)
+
+		var fetches atomic.Int32
+		fetch := func(context.Context) ([]byte, time.Time, error) {
+			fetches.Add(1)
+			time.Sleep(20 * time.Millisecond)
+			return []byte("platform-token"), time.Now().Add(time.Hour), nil
+		}
+
+		// Separate caches share the directory like separate Terraform processes
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				token, err := cyberark.NewTokenCache(dir).Fetch(context.Background(), "tenant/client", fetch)
+				assert.NoError(t, err)
+				assert.Equal(t, "platform-token", string(token))
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), fetches.Load())
+	})
+}
+
+func TestDefaultTokenCacheDir(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", "/tmp/cache")
+
+	dir, err := cyberark.DefaultTokenCacheDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/tmp/cache", "terraform-provider-cyberark", "tokens"), dir)
+}
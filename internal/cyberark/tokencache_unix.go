@@ -0,0 +1,32 @@
+//go:build unix
+
+package cyberark
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"syscall"
+)
+
+// checkPrivateDir returns an error if the directory is not owned by the current user, or if its
+// group or other users have any access to it.
+func checkPrivateDir(dir string, info fs.FileInfo) error {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok && int(stat.Uid) != os.Getuid() {
+		return fmt.Errorf("token cache directory %s is not owned by the current user", dir)
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		return fmt.Errorf("token cache directory %s is accessible to other users, restrict its mode to 0700", dir)
+	}
+	return nil
+}
+
+// lockFile acquires an exclusive lock of the file, blocking until it is available.
+func lockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_EX)
+}
+
+// unlockFile releases the lock of the file.
+func unlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,26 @@
+//go:build windows
+
+package cyberark
+
+import (
+	"io/fs"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// checkPrivateDir accepts any directory, as Windows does not use mode bits. The default cache
+// directory is in the profile of the user, whose access control list only grants the user access.
+func checkPrivateDir(string, fs.FileInfo) error {
+	return nil
+}
+
+// lockFile acquires an exclusive lock of the file, blocking until it is available.
+func lockFile(file *os.File) error {
+	return windows.LockFileEx(windows.Handle(file.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK, 0, 1, 0, &windows.Overlapped{})
+}
+
+// unlockFile releases the lock of the file.
+func unlockFile(file *os.File) error {
+	return windows.UnlockFileEx(windows.Handle(file.Fd()), 0, 1, 0, &windows.Overlapped{})
+}
@@ -1,6 +1,8 @@
 // Package cyberark provides a client for interacting with the CyberArk's SecretsHub APIs.
 package cyberark
 
+import "encoding/json"
+
 // Permission represents the safe member permissions
 type Permission struct {
 	ManageSafe                             bool `json:"manageSafe"`
@@ -31,9 +33,16 @@ type Permission struct {
 
 // Member represents member of a given type with permissions
 type Member struct {
-	Member     *string    `json:"memberName,omitempty"`
-	MemberType *string    `json:"memberType,omitempty"`
-	Perm       Permission `json:"permissions,omitempty"`
+	Member           *string    `json:"memberName,omitempty"`
+	MemberType       *string    `json:"memberType,omitempty"`
+	Perm             Permission `json:"permissions,omitempty"`
+	IsPredefinedUser *bool      `json:"isPredefinedUser,omitempty"`
+}
+
+// SafeMemberSearchResponse represents the safe member list response from the PAM API
+type SafeMemberSearchResponse struct {
+	Members []*Member `json:"value"`
+	Count   *int      `json:"count"`
 }
 
 // Shared Services Structs
@@ -49,15 +58,17 @@ type IdentityToken struct {
 
 // Credential represents the PAM credential
 type Credential struct {
-	Name       *string           `json:"name"`       // Custom Account Name of the credential
-	Address    *string           `json:"address"`    // Address of where the credential is used
-	UserName   *string           `json:"userName"`   // Username value
-	Platform   *string           `json:"platformId"` // Required: Management platform
-	SafeName   *string           `json:"safeName"`   // Required: Target Safe
-	SecretType *string           `json:"secretType"` // Type of secret (use password)
-	Secret     *string           `json:"secret"`     // Password Value
-	SecretMgmt *SecretManagement `json:"secretManagement"`
-	Props      *AccountProps     `json:"platformAccountProperties"`
+	Name         *string               `json:"name"`       // Custom Account Name of the credential
+	Address      *string               `json:"address"`    // Address of where the credential is used
+	UserName     *string               `json:"userName"`   // Username value
+	Platform     *string               `json:"platformId"` // Required: Management platform
+	SafeName     *string               `json:"safeName"`   // Required: Target Safe
+	SecretType   *string               `json:"secretType"` // Type of secret (use password)
+	Secret       *string               `json:"secret"`     // Password Value
+	SecretMgmt   *SecretManagement     `json:"secretManagement"`
+	Props        *AccountProps         `json:"platformAccountProperties"`
+	RemoteAccess *RemoteMachinesAccess `json:"remoteMachinesAccess,omitempty"`
+	Folder       *string               `json:"-"` // Folder of the safe to add the account to, Root by default
 }
 
 // AccountProps represents the properties of the PAM account
@@ -109,19 +120,26 @@ type SecretManagement struct {
 	LastVerified           *int64  `json:"lastVerifiedTime,omitempty"`
 }
 
+// RemoteMachinesAccess represents the machines an account can be used to connect to through PSM
+type RemoteMachinesAccess struct {
+	RemoteMachines                   *string `json:"remoteMachines,omitempty"` // Semicolon-separated list of machines
+	AccessRestrictedToRemoteMachines *bool   `json:"accessRestrictedToRemoteMachines,omitempty"`
+}
+
 // CredentialResponse represents the credential response from the PAM API
 type CredentialResponse struct {
-	Name         *string           `json:"name,omitempty"`
-	Address      *string           `json:"address,omitempty"`
-	UserName     *string           `json:"userName,omitempty"`
-	Platform     *string           `json:"platformId,omitempty"`
-	SafeName     *string           `json:"safeName,omitempty"`
-	SecretType   *string           `json:"secretType,omitempty"`
-	Secret       *string           `json:"secret,omitempty"`
-	SecretMgmt   *SecretManagement `json:"secretManagement,omitempty"`
-	Props        *AccountProps     `json:"platformAccountProperties,omitempty"`
-	CredID       *string           `json:"id,omitempty"`
-	CreationTime *int              `json:"lastModifiedTime,omitempty"`
+	Name         *string               `json:"name,omitempty"`
+	Address      *string               `json:"address,omitempty"`
+	UserName     *string               `json:"userName,omitempty"`
+	Platform     *string               `json:"platformId,omitempty"`
+	SafeName     *string               `json:"safeName,omitempty"`
+	SecretType   *string               `json:"secretType,omitempty"`
+	Secret       *string               `json:"secret,omitempty"`
+	SecretMgmt   *SecretManagement     `json:"secretManagement,omitempty"`
+	Props        *AccountProps         `json:"platformAccountProperties,omitempty"`
+	RemoteAccess *RemoteMachinesAccess `json:"remoteMachinesAccess,omitempty"`
+	CredID       *string               `json:"id,omitempty"`
+	CreationTime *int                  `json:"lastModifiedTime,omitempty"`
 }
 
 // CredentialSearchResponse represents the credential search response from the PAM API
@@ -148,11 +166,25 @@ type SafeData struct {
 	EnableOLAC           *bool   `json:"enableOLAC,omitempty"`
 }
 
+// SafeSearchResponse represents the safe list response from the PAM API
+type SafeSearchResponse struct {
+	Safes []*SafeData `json:"value"`
+	Count *int        `json:"count"`
+}
+
 // API represents the CyberArk's SecretsHub and PAM API
 type API struct {
 	PamAPI        PAMAPI
 	SecretsHubAPI SecretsHubAPI
 	PVWAAPI       PAMAPI
+	IdentityAPI   IdentityAPI
+	// Backends holds additional named vaults, keyed by backend name
+	Backends map[string]PAMAPI
+
+	// ValidatePlatforms enables the plan-time check that account platforms exist and are active
+	ValidatePlatforms bool
+	// Platforms caches the platforms listed by the plan-time check
+	Platforms *PlatformCache
 }
 
 // Secret stores API
@@ -220,6 +252,27 @@ type SecretStoresOutput[T AwsAsmData | AzureAkvData | GcpData] struct {
 	SecretStores []*SecretStoreOutput[T] `json:"secretStores"`
 }
 
+// SecretStoreConnectionStatus represents the result of a secret store connection test
+type SecretStoreConnectionStatus struct {
+	Status  *string `json:"status"`
+	Message *string `json:"message,omitempty"`
+}
+
+// IsConnected reports whether the connection test succeeded
+func (s *SecretStoreConnectionStatus) IsConnected() bool {
+	return s != nil && s.Status != nil && (*s.Status == "OK" || *s.Status == "SUCCESS")
+}
+
+// ScanOutput represents a secret store scan
+type ScanOutput struct {
+	ID          *string `json:"id"`
+	Status      *string `json:"status"`
+	Message     *string `json:"message,omitempty"`
+	TriggeredBy *string `json:"triggeredBy,omitempty"`
+	CreatedAt   *string `json:"createdAt,omitempty"`
+	UpdatedAt   *string `json:"updatedAt,omitempty"`
+}
+
 // Sync policy API
 
 // Source represents the policy source data
@@ -299,3 +352,314 @@ type SecretFilterOutput struct {
 	CreatedBy *string         `json:"createdBy"`
 	UpdatedBy *string         `json:"updatedBy"`
 }
+
+// Vault User Management Structs
+
+// VaultUser represents a vault user of the PVWA Users API
+type VaultUser struct {
+	ID                     *int                 `json:"id,omitempty"`
+	Username               *string              `json:"username,omitempty"`
+	UserType               *string              `json:"userType,omitempty"`
+	InitialPassword        *string              `json:"initialPassword,omitempty"` // Only sent when the user is added
+	Location               *string              `json:"location,omitempty"`
+	ExpiryDate             *int64               `json:"expiryDate,omitempty"` // Unix time in seconds
+	VaultAuthorization     []string             `json:"vaultAuthorization,omitempty"`
+	AuthenticationMethod   []string             `json:"authenticationMethod,omitempty"`
+	EnableUser             *bool                `json:"enableUser,omitempty"`
+	Suspended              *bool                `json:"suspended,omitempty"`
+	ChangePassOnNextLogon  *bool                `json:"changePassOnNextLogon,omitempty"`
+	PasswordNeverExpires   *bool                `json:"passwordNeverExpires,omitempty"`
+	DistinguishedName      *string              `json:"distinguishedName,omitempty"`
+	Description            *string              `json:"description,omitempty"`
+	Internet               *VaultUserInternet   `json:"internet,omitempty"`
+	PersonalDetails        *VaultUserPersonal   `json:"personalDetails,omitempty"`
+	UnAuthorizedInterfaces []string             `json:"unAuthorizedInterfaces,omitempty"`
+	GroupsMembership       []*VaultUserGroupRef `json:"groupsMembership,omitempty"`
+}
+
+// VaultUserInternet represents the internet details of a vault user
+type VaultUserInternet struct {
+	BusinessEmail *string `json:"businessEmail,omitempty"`
+}
+
+// VaultUserPersonal represents the personal details of a vault user
+type VaultUserPersonal struct {
+	FirstName *string `json:"firstName,omitempty"`
+	LastName  *string `json:"lastName,omitempty"`
+}
+
+// VaultUserGroupRef represents a group a vault user is a member of
+type VaultUserGroupRef struct {
+	GroupID   *int    `json:"groupID,omitempty"`
+	GroupName *string `json:"groupName,omitempty"`
+}
+
+// VaultGroup represents a vault group of the PVWA UserGroups API
+type VaultGroup struct {
+	ID          *int                `json:"id,omitempty"`
+	GroupName   *string             `json:"groupName,omitempty"`
+	Description *string             `json:"description,omitempty"`
+	Location    *string             `json:"location,omitempty"`
+	GroupType   *string             `json:"groupType,omitempty"`
+	Members     []*VaultGroupMember `json:"members,omitempty"` // Only returned with includeMembers
+}
+
+// VaultGroupMember represents a member of a vault group
+type VaultGroupMember struct {
+	ID       *int    `json:"id,omitempty"`
+	Username *string `json:"username,omitempty"`
+}
+
+// VaultGroupMemberInput represents the request to add a member to a vault group
+type VaultGroupMemberInput struct {
+	MemberID   *string `json:"memberId"`             // User name of the member
+	MemberType *string `json:"memberType,omitempty"` // vault or domain
+	DomainName *string `json:"domainName,omitempty"` // Required for domain members
+}
+
+// Identity Management Structs
+
+// IdentityResponse is the envelope of CyberArk Identity API responses.
+type IdentityResponse struct {
+	Success   bool            `json:"success"`
+	Result    json.RawMessage `json:"Result"`
+	Message   *string         `json:"Message"`
+	ErrorCode *string         `json:"ErrorCode"`
+}
+
+// IdentityRole represents a CyberArk Identity role.
+type IdentityRole struct {
+	ID          *string `json:"ID,omitempty"`
+	Name        *string `json:"Name,omitempty"`
+	Description *string `json:"Description,omitempty"`
+}
+
+// IdentityRoleMember represents a user, group or role that is a member of an Identity role.
+type IdentityRoleMember struct {
+	GUID *string `json:"Guid"`
+	Name *string `json:"Name"`
+	Type *string `json:"Type"` // User, Group or Role
+}
+
+// IdentityServiceUser represents an Identity service user that is an OAuth2 confidential client.
+type IdentityServiceUser struct {
+	ID          *string `json:"Uuid,omitempty"`
+	Name        *string `json:"Name,omitempty"`
+	DisplayName *string `json:"DisplayName,omitempty"`
+	Description *string `json:"Description,omitempty"`
+	Mail        *string `json:"Mail,omitempty"`
+	Password    *string `json:"Password,omitempty"`
+}
+
+// Platform Structs
+
+// Platform represents a platform returned by the platforms API.
+type Platform struct {
+	General    *PlatformGeneral    `json:"general"`
+	Properties *PlatformProperties `json:"properties"`
+}
+
+// PlatformGeneral holds the general details of a platform.
+type PlatformGeneral struct {
+	ID             *string `json:"id"`
+	Name           *string `json:"name"`
+	SystemType     *string `json:"systemType"`
+	Active         *bool   `json:"active"`
+	Description    *string `json:"description"`
+	PlatformBaseID *string `json:"platformBaseID"`
+	PlatformType   *string `json:"platformType"`
+}
+
+// PlatformProperties lists the account properties of a platform.
+type PlatformProperties struct {
+	Required []*PlatformProperty `json:"required"`
+	Optional []*PlatformProperty `json:"optional"`
+}
+
+// PlatformProperty represents an account property of a platform.
+type PlatformProperty struct {
+	Name        *string `json:"name"`
+	DisplayName *string `json:"displayName"`
+}
+
+// PlatformSearchResponse represents the response of a platform search.
+type PlatformSearchResponse struct {
+	Platforms []*Platform `json:"Platforms"`
+	Total     *int        `json:"Total"`
+}
+
+// PlatformFilter narrows a platform search.
+type PlatformFilter struct {
+	Active       *bool
+	PlatformType string
+	Search       string
+}
+
+// TargetPlatform represents a target platform, which accounts of target systems are managed with.
+type TargetPlatform struct {
+	ID             *int    `json:"ID"`
+	PlatformID     *string `json:"PlatformID"`
+	Name           *string `json:"Name"`
+	Description    *string `json:"Description,omitempty"`
+	Active         *bool   `json:"Active"`
+	SystemType     *string `json:"SystemType"`
+	AllowedSafes   *string `json:"AllowedSafes"`
+	PlatformBaseID *string `json:"PlatformBaseID"`
+	PlatformType   *string `json:"PlatformType"`
+}
+
+// TargetPlatformSearchResponse represents the response of a target platform search.
+type TargetPlatformSearchResponse struct {
+	Platforms []*TargetPlatform `json:"Platforms"`
+}
+
+// Application Structs
+
+// Application represents an application of the Credential Provider, which retrieves
+// accounts from the safes its application ID is a member of.
+type Application struct {
+	AppID               *string `json:"AppID"`
+	Description         *string `json:"Description,omitempty"`
+	Location            *string `json:"Location,omitempty"`
+	AccessPermittedFrom *int    `json:"AccessPermittedFrom,omitempty"`
+	AccessPermittedTo   *int    `json:"AccessPermittedTo,omitempty"`
+	ExpirationDate      *string `json:"ExpirationDate,omitempty"`
+	Disabled            *bool   `json:"Disabled,omitempty"`
+	BusinessOwnerFName  *string `json:"BusinessOwnerFName,omitempty"`
+	BusinessOwnerLName  *string `json:"BusinessOwnerLName,omitempty"`
+	BusinessOwnerEmail  *string `json:"BusinessOwnerEmail,omitempty"`
+	BusinessOwnerPhone  *string `json:"BusinessOwnerPhone,omitempty"`
+}
+
+// ApplicationAuthMethod represents a method the Credential Provider authenticates an
+// application with, e.g. the address of the machine it runs on or the hash of its binary.
+// The ID is a number or a numeric string, depending on the PVWA version.
+type ApplicationAuthMethod struct {
+	AuthID               json.Number `json:"authID,omitempty"`
+	AppID                *string     `json:"AppID,omitempty"`
+	AuthType             *string     `json:"AuthType"`
+	AuthValue            *string     `json:"AuthValue"`
+	IsFolder             *bool       `json:"IsFolder,omitempty"`
+	AllowInternalScripts *bool       `json:"AllowInternalScripts,omitempty"`
+	Comment              *string     `json:"Comment,omitempty"`
+}
+
+// Onboarding Rule Structs
+
+// OnboardingRule represents an automatic onboarding rule, which adds discovered accounts
+// matching its filters to a safe with a platform.
+type OnboardingRule struct {
+	RuleID                *int    `json:"RuleId,omitempty"`
+	RuleName              *string `json:"RuleName,omitempty"`
+	RuleDescription       *string `json:"RuleDescription,omitempty"`
+	TargetPlatformID      *string `json:"TargetPlatformId"`
+	TargetSafeName        *string `json:"TargetSafeName"`
+	IsAdminIDFilter       *bool   `json:"IsAdminIDFilter,omitempty"`
+	MachineTypeFilter     *string `json:"MachineTypeFilter,omitempty"`
+	SystemTypeFilter      *string `json:"SystemTypeFilter"`
+	UserNameFilter        *string `json:"UserNameFilter,omitempty"`
+	UserNameMethod        *string `json:"UserNameMethod,omitempty"`
+	AddressFilter         *string `json:"AddressFilter,omitempty"`
+	AddressMethod         *string `json:"AddressMethod,omitempty"`
+	AccountCategoryFilter *string `json:"AccountCategoryFilter,omitempty"`
+	ReconcileAccountID    *string `json:"ReconcileAccountId,omitempty"`
+	RulePrecedence        *int    `json:"RulePrecedence,omitempty"`
+}
+
+// OnboardingRuleSearchResponse represents the response of listing the onboarding rules.
+type OnboardingRuleSearchResponse struct {
+	Rules []*OnboardingRule `json:"AutomaticOnboardingRules"`
+	Total *int              `json:"Total"`
+}
+
+// Discovered Account Structs
+
+// DiscoveredAccount represents an account in the pending (discovered) accounts list, which
+// is reviewed before the account is onboarded to a safe.
+type DiscoveredAccount struct {
+	ID                   *string           `json:"id,omitempty"`
+	UserName             *string           `json:"userName"`
+	Address              *string           `json:"address"`
+	PlatformType         *string           `json:"platformType"`
+	DiscoveryDate        *int64            `json:"discoveryDate,omitempty"`
+	Domain               *string           `json:"domain,omitempty"`
+	AccountEnabled       *bool             `json:"accountEnabled,omitempty"`
+	OSGroups             *string           `json:"osGroups,omitempty"`
+	Privileged           *bool             `json:"privileged,omitempty"`
+	PrivilegedCriteria   *string           `json:"privilegedCriteria,omitempty"`
+	UserDisplayName      *string           `json:"userDisplayName,omitempty"`
+	Description          *string           `json:"description,omitempty"`
+	OSFamily             *string           `json:"osFamily,omitempty"`
+	OSVersion            *string           `json:"osVersion,omitempty"`
+	OrganizationalUnit   *string           `json:"organizationalUnit,omitempty"`
+	AdditionalProperties map[string]string `json:"additionalProperties,omitempty"`
+}
+
+// DiscoveredAccountResponse represents the response of adding a discovered account. Status is
+// "added" for new accounts and "updated" for accounts that were already pending.
+type DiscoveredAccountResponse struct {
+	ID     *string `json:"id"`
+	Status *string `json:"status"`
+}
+
+// DiscoveredAccountSearchResponse represents a page of discovered accounts.
+type DiscoveredAccountSearchResponse struct {
+	Accounts []*DiscoveredAccount `json:"value"`
+	Count    *int                 `json:"count"`
+}
+
+// OnboardDiscoveredAccountRequest selects the safe and platform a discovered account is
+// onboarded with.
+type OnboardDiscoveredAccountRequest struct {
+	SafeName   *string `json:"safeName"`
+	PlatformID *string `json:"platformId"`
+}
+
+// Account Group Structs
+
+// AccountGroup represents a group of accounts in a safe whose passwords the CPM changes
+// together, according to the group platform of the group.
+type AccountGroup struct {
+	GroupID         *string `json:"GroupID,omitempty"`
+	GroupName       *string `json:"GroupName"`
+	GroupPlatformID *string `json:"GroupPlatformID"`
+	Safe            *string `json:"Safe"`
+}
+
+// AccountGroupMember represents an account in an account group.
+type AccountGroupMember struct {
+	AccountID  *string `json:"AccountID"`
+	SafeName   *string `json:"SafeName,omitempty"`
+	PlatformID *string `json:"PlatformID,omitempty"`
+	Address    *string `json:"Address,omitempty"`
+	UserName   *string `json:"UserName,omitempty"`
+}
+
+// classicAccount represents an account added through the PIMServices API, which unlike the
+// Accounts API can add accounts to a folder of a safe.
+type classicAccount struct {
+	Safe                  *string           `json:"safe"`
+	Folder                *string           `json:"folder"`
+	PlatformID            *string           `json:"platformID"`
+	Address               *string           `json:"address,omitempty"`
+	AccountName           *string           `json:"accountName,omitempty"`
+	Password              *string           `json:"password,omitempty"`
+	UserName              *string           `json:"username,omitempty"`
+	DisableAutoMgmt       *bool             `json:"disableAutoMgmt,omitempty"`
+	DisableAutoMgmtReason *string           `json:"disableAutoMgmtReason,omitempty"`
+	Properties            []classicProperty `json:"properties,omitempty"`
+}
+
+// classicProperty represents a platform property of a classicAccount.
+type classicProperty struct {
+	Key   string `json:"Key"`
+	Value string `json:"Value"`
+}
+
+// StringValue dereferences an optional string, returning an empty string for nil.
+func StringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,299 @@
+package cyberark
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// UserManagement is an interface for interacting with vault users and groups.
+type UserManagement interface {
+	AddUser(ctx context.Context, user VaultUser) (*VaultUser, error)
+	GetUser(ctx context.Context, userID string) (*VaultUser, error)
+	UpdateUser(ctx context.Context, userID string, user VaultUser) (*VaultUser, error)
+	DeleteUser(ctx context.Context, userID string) error
+	AddGroup(ctx context.Context, group VaultGroup) (*VaultGroup, error)
+	GetGroup(ctx context.Context, groupID string) (*VaultGroup, error)
+	UpdateGroup(ctx context.Context, groupID string, group VaultGroup) (*VaultGroup, error)
+	DeleteGroup(ctx context.Context, groupID string) error
+	AddGroupMember(ctx context.Context, groupID string, member VaultGroupMemberInput) error
+	DeleteGroupMember(ctx context.Context, groupID string, memberName string) error
+}
+
+// AddUser adds a new vault user.
+func (a *pamAPI) AddUser(ctx context.Context, user VaultUser) (*VaultUser, error) {
+	body, err := json.Marshal(user)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := a.client.DoRequest(
+		ctx,
+		"POST",
+		"/PasswordVault/API/Users",
+		bytes.NewBuffer(body),
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 201 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	newUser := VaultUser{}
+	err = json.NewDecoder(response.Body).Decode(&newUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return &newUser, nil
+}
+
+// GetUser retrieves a vault user by its ID.
+func (a *pamAPI) GetUser(ctx context.Context, userID string) (*VaultUser, error) {
+	response, err := a.client.DoRequest(
+		ctx,
+		"GET",
+		fmt.Sprintf("/PasswordVault/API/Users/%s", userID),
+		nil,
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	user := VaultUser{}
+	err = json.NewDecoder(response.Body).Decode(&user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// UpdateUser updates a vault user. Properties that are not set are reset to their defaults.
+func (a *pamAPI) UpdateUser(ctx context.Context, userID string, user VaultUser) (*VaultUser, error) {
+	// The initial password can only be set when the user is added
+	user.InitialPassword = nil
+
+	body, err := json.Marshal(user)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := a.client.DoRequest(
+		ctx,
+		"PUT",
+		fmt.Sprintf("/PasswordVault/API/Users/%s", userID),
+		bytes.NewBuffer(body),
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	updatedUser := VaultUser{}
+	err = json.NewDecoder(response.Body).Decode(&updatedUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedUser, nil
+}
+
+// DeleteUser deletes a vault user.
+func (a *pamAPI) DeleteUser(ctx context.Context, userID string) error {
+	response, err := a.client.DoRequest(
+		ctx,
+		"DELETE",
+		fmt.Sprintf("/PasswordVault/API/Users/%s", userID),
+		nil,
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != 204 {
+		return APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	return nil
+}
+
+// AddGroup adds a new vault group.
+func (a *pamAPI) AddGroup(ctx context.Context, group VaultGroup) (*VaultGroup, error) {
+	body, err := json.Marshal(group)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := a.client.DoRequest(
+		ctx,
+		"POST",
+		"/PasswordVault/API/UserGroups",
+		bytes.NewBuffer(body),
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 201 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	newGroup := VaultGroup{}
+	err = json.NewDecoder(response.Body).Decode(&newGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &newGroup, nil
+}
+
+// GetGroup retrieves a vault group and its members by the ID of the group.
+func (a *pamAPI) GetGroup(ctx context.Context, groupID string) (*VaultGroup, error) {
+	response, err := a.client.DoRequest(
+		ctx,
+		"GET",
+		fmt.Sprintf("/PasswordVault/API/UserGroups/%s", groupID),
+		nil,
+		map[string]string{},
+		map[string]string{
+			"includeMembers": "true",
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	group := VaultGroup{}
+	err = json.NewDecoder(response.Body).Decode(&group)
+	if err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+// UpdateGroup updates the name and description of a vault group.
+func (a *pamAPI) UpdateGroup(ctx context.Context, groupID string, group VaultGroup) (*VaultGroup, error) {
+	body, err := json.Marshal(VaultGroup{GroupName: group.GroupName, Description: group.Description})
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := a.client.DoRequest(
+		ctx,
+		"PUT",
+		fmt.Sprintf("/PasswordVault/API/UserGroups/%s", groupID),
+		bytes.NewBuffer(body),
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		return nil, APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	updatedGroup := VaultGroup{}
+	err = json.NewDecoder(response.Body).Decode(&updatedGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedGroup, nil
+}
+
+// DeleteGroup deletes a vault group.
+func (a *pamAPI) DeleteGroup(ctx context.Context, groupID string) error {
+	response, err := a.client.DoRequest(
+		ctx,
+		"DELETE",
+		fmt.Sprintf("/PasswordVault/API/UserGroups/%s", groupID),
+		nil,
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != 204 {
+		return APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	return nil
+}
+
+// AddGroupMember adds a vault or domain user to a vault group.
+func (a *pamAPI) AddGroupMember(ctx context.Context, groupID string, member VaultGroupMemberInput) error {
+	body, err := json.Marshal(member)
+	if err != nil {
+		return err
+	}
+
+	response, err := a.client.DoRequest(
+		ctx,
+		"POST",
+		fmt.Sprintf("/PasswordVault/API/UserGroups/%s/Members", groupID),
+		bytes.NewBuffer(body),
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != 201 {
+		return APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	return nil
+}
+
+// DeleteGroupMember removes a member from a vault group.
+func (a *pamAPI) DeleteGroupMember(ctx context.Context, groupID string, memberName string) error {
+	response, err := a.client.DoRequest(
+		ctx,
+		"DELETE",
+		fmt.Sprintf("/PasswordVault/API/UserGroups/%s/Members/%s", groupID, url.PathEscape(memberName)),
+		nil,
+		map[string]string{},
+		map[string]string{},
+	)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != 204 {
+		return APIErrorFromResponse(response.StatusCode, response.Body)
+	}
+
+	return nil
+}
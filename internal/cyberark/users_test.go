@@ -0,0 +1,231 @@
+package cyberark_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultUsers(t *testing.T) {
+	username := "jdoe"
+	password := "Cyberark1!"
+	userID := 42
+	expiry := int64(1767225600)
+
+	t.Run("AddUser", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "POST", req.Method)
+			assert.Equal(t, "/PasswordVault/API/Users", req.URL.Path)
+
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			assert.Equal(t, username, body["username"])
+			assert.Equal(t, password, body["initialPassword"])
+			assert.Equal(t, float64(expiry), body["expiryDate"])
+			assert.Equal(t, []interface{}{"AddSafes", "AuditUsers"}, body["vaultAuthorization"])
+			assert.Equal(t, false, body["changePassOnNextLogon"])
+
+			rw.WriteHeader(http.StatusCreated)
+			json.NewEncoder(rw).Encode(cyberark.VaultUser{ID: &userID, Username: &username})
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+		changePassword := false
+
+		user, err := client.AddUser(context.Background(), cyberark.VaultUser{
+			Username:              &username,
+			InitialPassword:       &password,
+			ExpiryDate:            &expiry,
+			VaultAuthorization:    []string{"AddSafes", "AuditUsers"},
+			ChangePassOnNextLogon: &changePassword,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, userID, *user.ID)
+	})
+
+	t.Run("UpdateUserOmitsInitialPassword", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "PUT", req.Method)
+			assert.Equal(t, "/PasswordVault/API/Users/42", req.URL.Path)
+
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			assert.NotContains(t, body, "initialPassword")
+			assert.Equal(t, username, body["username"])
+
+			json.NewEncoder(rw).Encode(cyberark.VaultUser{ID: &userID, Username: &username})
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		user, err := client.UpdateUser(context.Background(), "42", cyberark.VaultUser{Username: &username, InitialPassword: &password})
+
+		require.NoError(t, err)
+		assert.Equal(t, username, *user.Username)
+	})
+
+	t.Run("GetUser", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "/PasswordVault/API/Users/42", req.URL.Path)
+			rw.Write([]byte(`{"id": 42, "username": "jdoe", "userType": "EPVUser", "location": "\\", "vaultAuthorization": ["AddSafes"]}`))
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		user, err := client.GetUser(context.Background(), "42")
+
+		require.NoError(t, err)
+		assert.Equal(t, "EPVUser", *user.UserType)
+		assert.Equal(t, `\`, *user.Location)
+		assert.Equal(t, []string{"AddSafes"}, user.VaultAuthorization)
+	})
+
+	t.Run("DeleteUser", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "DELETE", req.Method)
+			assert.Equal(t, "/PasswordVault/API/Users/42", req.URL.Path)
+			rw.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		assert.NoError(t, client.DeleteUser(context.Background(), "42"))
+	})
+
+	t.Run("ErrorStatusCode", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+			http.Error(rw, `{"ErrorCode": "PASWS013E", "ErrorMessage": "User not found"}`, http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		user, err := client.GetUser(context.Background(), "42")
+
+		assert.Nil(t, user)
+		assert.ErrorContains(t, err, "HTTP status code 404")
+		assert.ErrorContains(t, err, "User not found")
+	})
+}
+
+func TestVaultGroups(t *testing.T) {
+	groupName := "Auditors"
+	description := "Vault auditors"
+	groupID := 7
+
+	t.Run("AddGroup", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "POST", req.Method)
+			assert.Equal(t, "/PasswordVault/API/UserGroups", req.URL.Path)
+
+			var group cyberark.VaultGroup
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&group))
+			assert.Equal(t, groupName, *group.GroupName)
+
+			group.ID = &groupID
+			rw.WriteHeader(http.StatusCreated)
+			json.NewEncoder(rw).Encode(group)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		group, err := client.AddGroup(context.Background(), cyberark.VaultGroup{GroupName: &groupName, Description: &description})
+
+		require.NoError(t, err)
+		assert.Equal(t, groupID, *group.ID)
+	})
+
+	t.Run("GetGroupIncludesMembers", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "/PasswordVault/API/UserGroups/7", req.URL.Path)
+			assert.Equal(t, "true", req.URL.Query().Get("includeMembers"))
+			rw.Write([]byte(`{"id": 7, "groupName": "Auditors", "location": "\\", "members": [{"id": 42, "username": "jdoe"}]}`))
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		group, err := client.GetGroup(context.Background(), "7")
+
+		require.NoError(t, err)
+		require.Len(t, group.Members, 1)
+		assert.Equal(t, "jdoe", *group.Members[0].Username)
+	})
+
+	t.Run("UpdateGroup", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "PUT", req.Method)
+
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			assert.Equal(t, map[string]interface{}{"groupName": groupName, "description": description}, body)
+
+			rw.Write([]byte(`{"id": 7, "groupName": "Auditors"}`))
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+		location := `\Audit`
+
+		_, err := client.UpdateGroup(context.Background(), "7", cyberark.VaultGroup{GroupName: &groupName, Description: &description, Location: &location})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("AddGroupMember", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "POST", req.Method)
+			assert.Equal(t, "/PasswordVault/API/UserGroups/7/Members", req.URL.Path)
+
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			assert.Equal(t, map[string]interface{}{"memberId": "jdoe", "memberType": "vault"}, body)
+
+			rw.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+		memberID, memberType := "jdoe", "vault"
+
+		err := client.AddGroupMember(context.Background(), "7", cyberark.VaultGroupMemberInput{MemberID: &memberID, MemberType: &memberType})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("DeleteGroupMember", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "DELETE", req.Method)
+			assert.Equal(t, "/PasswordVault/API/UserGroups/7/Members/jdoe@example.com", req.URL.Path)
+			rw.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		assert.NoError(t, client.DeleteGroupMember(context.Background(), "7", "jdoe@example.com"))
+	})
+
+	t.Run("DeleteGroupErrorStatusCode", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+			http.Error(rw, "Forbidden", http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		client := cyberark.NewPAMAPI(server.URL, token, false)
+
+		assert.Error(t, client.DeleteGroup(context.Background(), "7"))
+	})
+}
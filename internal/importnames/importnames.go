@@ -0,0 +1,54 @@
+// Package importnames names the Terraform resources generated for existing CyberArk objects by the
+// cyberark_import_discovery data source and the cyberark-export command.
+package importnames
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+)
+
+// AccountKind returns the resource type suffix that manages the account, based on its platform
+// properties: aws_account, azure_account or db_account.
+func AccountKind(account *cybrapi.CredentialResponse) string {
+	if account.Props != nil {
+		if account.Props.AWSKID != nil || account.Props.AWSAccount != nil {
+			return "aws_account"
+		}
+		if account.Props.MAppID != nil || account.Props.MADID != nil {
+			return "azure_account"
+		}
+	}
+	return "db_account"
+}
+
+// invalidLabelChars matches characters that are not allowed in a Terraform resource label.
+var invalidLabelChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// Label converts an object name into a valid Terraform resource label.
+func Label(name string) string {
+	label := strings.Trim(invalidLabelChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "r_" + label
+	}
+	return label
+}
+
+// Labeler hands out Terraform labels that are unique per resource type.
+type Labeler map[string]bool
+
+// Label converts name into a valid label that has not been used for resourceType yet. Duplicate
+// labels get the first free numeric suffix, starting with _2.
+func (l Labeler) Label(resourceType, name string) string {
+	base := Label(name)
+	prefix := resourceType + "."
+
+	label := base
+	for n := 2; l[prefix+label]; n++ {
+		label = fmt.Sprintf("%s_%d", base, n)
+	}
+	l[prefix+label] = true
+	return label
+}
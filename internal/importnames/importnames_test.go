@@ -0,0 +1,52 @@
+package importnames
+
+import (
+	"testing"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountKind(t *testing.T) {
+	value := "value"
+	tests := map[string]struct {
+		account  cybrapi.CredentialResponse
+		expected string
+	}{
+		"NoProperties": {account: cybrapi.CredentialResponse{}, expected: "db_account"},
+		"AWS":          {account: cybrapi.CredentialResponse{Props: &cybrapi.AccountProps{AWSKID: &value}}, expected: "aws_account"},
+		"Azure":        {account: cybrapi.CredentialResponse{Props: &cybrapi.AccountProps{MADID: &value}}, expected: "azure_account"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, AccountKind(&tc.account))
+		})
+	}
+}
+
+func TestLabel(t *testing.T) {
+	tests := map[string]string{
+		"My Safe":        "my_safe",
+		"db-admin@prod":  "db_admin_prod",
+		"123 accounts":   "r_123_accounts",
+		"--":             "r_",
+		"already_valid1": "already_valid1",
+	}
+
+	for name, expected := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, expected, Label(name))
+		})
+	}
+}
+
+func TestLabeler(t *testing.T) {
+	labels := Labeler{}
+
+	assert.Equal(t, "safe_a", labels.Label("cyberark_safe", "safe a"))
+	assert.Equal(t, "safe_a_2", labels.Label("cyberark_safe", "safe-a"))
+	assert.Equal(t, "safe_a_2_2", labels.Label("cyberark_safe", "safe_a_2"))
+	assert.Equal(t, "safe_a_3", labels.Label("cyberark_safe", "Safe A"))
+	assert.Equal(t, "safe_a", labels.Label("cyberark_db_account", "safe a"))
+}
@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const accountGroupMembersRoute = "/PasswordVault/API/AccountGroups/{group}/Members"
+
+// accountGroupRoutes returns the routes of account group members for the given groups, keyed by ID
+// and mapped to the IDs of their members. Members added and removed through the API update groups,
+// and requests for other groups fail with 404.
+func accountGroupRoutes(t *testing.T, groups map[string][]string) map[string]http.HandlerFunc {
+	group := func(handler func(w http.ResponseWriter, r *http.Request, id string)) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			id := r.PathValue("group")
+			if _, ok := groups[id]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			handler(w, r, id)
+		}
+	}
+
+	return map[string]http.HandlerFunc{
+		"GET " + accountGroupMembersRoute: group(func(w http.ResponseWriter, _ *http.Request, id string) {
+			list := []*cybrapi.AccountGroupMember{}
+			for _, member := range groups[id] {
+				list = append(list, &cybrapi.AccountGroupMember{AccountID: &member})
+			}
+			writeJSON(w, http.StatusOK, list)
+		}),
+		"POST " + accountGroupMembersRoute: group(func(w http.ResponseWriter, r *http.Request, id string) {
+			member := cybrapi.AccountGroupMember{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&member))
+			groups[id] = append(groups[id], *member.AccountID)
+			w.WriteHeader(http.StatusCreated)
+		}),
+		"DELETE " + accountGroupMembersRoute + "/{account}": group(func(w http.ResponseWriter, r *http.Request, id string) {
+			groups[id] = slices.DeleteFunc(groups[id], func(member string) bool { return member == r.PathValue("account") })
+			w.WriteHeader(http.StatusNoContent)
+		}),
+	}
+}
+
+func TestSetAccountGroup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Add", func(t *testing.T) {
+		groups := map[string][]string{"1_1": {}}
+		pam := newPAMServer(t, accountGroupRoutes(t, groups))
+
+		var diags diag.Diagnostics
+		setAccountGroup(ctx, pam, "42_7", types.StringNull(), types.StringValue("1_1"), &diags)
+
+		require.False(t, diags.HasError(), diags)
+		assert.Equal(t, []string{"42_7"}, groups["1_1"])
+	})
+
+	t.Run("Move", func(t *testing.T) {
+		groups := map[string][]string{"1_1": {"42_7", "42_8"}, "1_2": {}}
+		pam := newPAMServer(t, accountGroupRoutes(t, groups))
+
+		var diags diag.Diagnostics
+		setAccountGroup(ctx, pam, "42_7", types.StringValue("1_1"), types.StringValue("1_2"), &diags)
+
+		require.False(t, diags.HasError(), diags)
+		assert.Equal(t, []string{"42_8"}, groups["1_1"])
+		assert.Equal(t, []string{"42_7"}, groups["1_2"])
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		// An empty group ID is the same as no group, so the account is only removed
+		groups := map[string][]string{"1_1": {"42_7"}}
+		pam := newPAMServer(t, accountGroupRoutes(t, groups))
+
+		var diags diag.Diagnostics
+		setAccountGroup(ctx, pam, "42_7", types.StringValue("1_1"), types.StringValue(""), &diags)
+
+		require.False(t, diags.HasError(), diags)
+		assert.Empty(t, groups["1_1"])
+	})
+
+	t.Run("Unchanged", func(t *testing.T) {
+		// No request is sent when the group does not change
+		pam := newPAMServer(t, nil)
+
+		var diags diag.Diagnostics
+		setAccountGroup(ctx, pam, "42_7", types.StringValue("1_1"), types.StringValue("1_1"), &diags)
+
+		assert.Empty(t, diags)
+	})
+
+	t.Run("MoveFromMissingGroup", func(t *testing.T) {
+		// The account is not added to the new group when it cannot be removed from the old one,
+		// as an account belongs to one group at most
+		groups := map[string][]string{"1_2": {}}
+		pam := newPAMServer(t, accountGroupRoutes(t, groups))
+
+		var diags diag.Diagnostics
+		setAccountGroup(ctx, pam, "42_7", types.StringValue("1_1"), types.StringValue("1_2"), &diags)
+
+		require.True(t, diags.HasError())
+		assert.Equal(t, "Error removing account from account group", diags[0].Summary())
+		assert.Empty(t, groups["1_2"])
+	})
+
+	t.Run("MissingGroup", func(t *testing.T) {
+		pam := newPAMServer(t, accountGroupRoutes(t, map[string][]string{}))
+
+		var diags diag.Diagnostics
+		setAccountGroup(ctx, pam, "42_7", types.StringNull(), types.StringValue("1_1"), &diags)
+
+		require.True(t, diags.HasError())
+		assert.Equal(t, "Error adding account to account group", diags[0].Summary())
+	})
+}
+
+func TestReadAccountGroup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Read", func(t *testing.T) {
+		pam := newPAMServer(t, accountGroupRoutes(t, map[string][]string{"1_1": {"42_7"}}))
+
+		var diags diag.Diagnostics
+		assert.Equal(t, types.StringValue("1_1"), readAccountGroup(ctx, pam, "42_7", types.StringValue("1_1"), &diags))
+		// The account was removed from the group outside of Terraform
+		assert.Equal(t, types.StringNull(), readAccountGroup(ctx, pam, "42_8", types.StringValue("1_1"), &diags))
+		assert.False(t, diags.HasError(), diags)
+	})
+
+	t.Run("NoGroup", func(t *testing.T) {
+		pam := newPAMServer(t, nil)
+
+		var diags diag.Diagnostics
+		assert.Equal(t, types.StringNull(), readAccountGroup(ctx, pam, "42_7", types.StringNull(), &diags))
+		assert.Empty(t, diags)
+	})
+
+	t.Run("ListFails", func(t *testing.T) {
+		pam := newPAMServer(t, map[string]http.HandlerFunc{
+			"GET " + accountGroupMembersRoute: respondStatus(http.StatusForbidden),
+		})
+
+		var diags diag.Diagnostics
+		assert.Equal(t, types.StringValue("1_1"), readAccountGroup(ctx, pam, "42_7", types.StringValue("1_1"), &diags))
+		require.True(t, diags.HasError())
+		assert.Equal(t, "Error reading account group members", diags[0].Summary())
+	})
+}
+
+func TestFindAccountGroup(t *testing.T) {
+	first, second := "1_1", "1_2"
+	groups := []*cybrapi.AccountGroup{{GroupID: &first}, {GroupID: &second}}
+
+	require.NotNil(t, findAccountGroup(groups, "1_2"))
+	assert.Equal(t, "1_2", *findAccountGroup(groups, "1_2").GroupID)
+	assert.Nil(t, findAccountGroup(groups, "1_3"))
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplicationModel(t *testing.T) {
+	data := applicationModel{
+		AppID:                  types.StringValue("billing"),
+		Description:            types.StringUnknown(),
+		Location:               types.StringValue("\\Payments"),
+		AccessPermittedFrom:    types.Int64Value(8),
+		AccessPermittedTo:      types.Int64Unknown(),
+		ExpirationDate:         types.StringValue("12-31-2027"),
+		Disabled:               types.BoolUnknown(),
+		BusinessOwnerFirstName: types.StringUnknown(),
+		BusinessOwnerLastName:  types.StringUnknown(),
+		BusinessOwnerEmail:     types.StringValue("owner@example.com"),
+		BusinessOwnerPhone:     types.StringUnknown(),
+	}
+
+	application := applicationFromModel(&data)
+	assert.Equal(t, "billing", *application.AppID)
+	assert.Nil(t, application.Description)
+	assert.Equal(t, 8, *application.AccessPermittedFrom)
+	assert.Nil(t, application.AccessPermittedTo)
+	assert.Nil(t, application.Disabled)
+
+	appID, location, date := "billing", "\\Payments", "12/31/2027"
+	from, to := 8, 24
+	setApplicationModel(&data, &cybrapi.Application{
+		AppID:               &appID,
+		Location:            &location,
+		AccessPermittedFrom: &from,
+		AccessPermittedTo:   &to,
+		ExpirationDate:      &date,
+	})
+
+	assert.Equal(t, types.StringValue("billing"), data.ID)
+	assert.Equal(t, types.StringValue(backendSelfHosted), data.Backend)
+	assert.Equal(t, types.StringValue(""), data.Description)
+	assert.Equal(t, types.Int64Value(24), data.AccessPermittedTo)
+	assert.Equal(t, types.BoolValue(false), data.Disabled)
+	assert.Equal(t, types.StringValue("owner@example.com"), data.BusinessOwnerEmail)
+	// The configured expiration date is kept in its own format
+	assert.Equal(t, types.StringValue("12-31-2027"), data.ExpirationDate)
+}
+
+func TestApplicationAuthMethods(t *testing.T) {
+	var methods []*cybrapi.ApplicationAuthMethod
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"authID": 1, "AuthType": "MachineAddress", "AuthValue": "10.0.0.1"},
+		{"authID": "2", "AuthType": "osUser", "AuthValue": "svc_billing"}
+	]`), &methods))
+
+	t.Run("Find", func(t *testing.T) {
+		assert.Equal(t, "svc_billing", *findApplicationAuthMethod(methods, "2").AuthValue)
+		assert.Equal(t, "10.0.0.1", *findApplicationAuthMethod(methods, "1").AuthValue)
+		assert.Nil(t, findApplicationAuthMethod(methods, "3"))
+	})
+
+	t.Run("Match", func(t *testing.T) {
+		assert.Equal(t, "1", matchApplicationAuthMethod(methods, "machineAddress", "10.0.0.1").AuthID.String())
+		assert.Nil(t, matchApplicationAuthMethod(methods, "machineAddress", "10.0.0.2"))
+		assert.Nil(t, matchApplicationAuthMethod(methods, "path", "svc_billing"))
+	})
+}
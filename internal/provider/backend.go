@@ -0,0 +1,158 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Vaults that safes and accounts can be managed in.
+const (
+	backendPrivilegeCloud = "privilege_cloud"
+	backendSelfHosted     = "self_hosted"
+)
+
+// backendAttribute returns the schema attribute that selects the vault a safe or account is managed in.
+func backendAttribute(defaultBackend string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: fmt.Sprintf("Vault the object is managed in: `%s` for Privilege Cloud, `%s` for PAM Self-Hosted "+
+			"through the PVWA configured on the provider, or the name of a provider `backend` block. Defaults to `%s`. "+
+			"Changing it forces a new resource.",
+			backendPrivilegeCloud, backendSelfHosted, defaultBackend),
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString(defaultBackend),
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplaceIf(backendChanged(defaultBackend),
+				"Changing the backend forces a new resource.", "Changing the backend forces a new resource."),
+		},
+	}
+}
+
+// backendChanged returns a function that requires replacing an object when its backend changes.
+// State written before the backend attribute existed has no backend, which stands for the default
+// backend, so those objects are not replaced when the default is planned.
+func backendChanged(defaultBackend string) stringplanmodifier.RequiresReplaceIfFunc {
+	return func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+		resp.RequiresReplace = !backendOrDefault(req.StateValue, defaultBackend).Equal(req.PlanValue)
+	}
+}
+
+// backendTypeName returns the resource type name for a safe or account resource. The
+// deprecated self-hosted aliases are named cyberark_pvwa_<suffix>.
+func backendTypeName(providerTypeName, suffix, defaultBackend string) string {
+	if defaultBackend == backendSelfHosted {
+		return providerTypeName + "_pvwa_" + suffix
+	}
+	return providerTypeName + "_" + suffix
+}
+
+// backendDeprecationMessage returns the deprecation message of a self-hosted alias, or an
+// empty string for the resource itself.
+func backendDeprecationMessage(suffix, defaultBackend string) string {
+	if defaultBackend != backendSelfHosted {
+		return ""
+	}
+	return fmt.Sprintf("Use cyberark_%s with backend = \"%s\" instead. Existing resources can be migrated without "+
+		"being recreated with a moved block.", suffix, backendSelfHosted)
+}
+
+// validateBackend adds an error if the backend is neither one of the built-in values nor a valid
+// backend block name. Whether a named backend is configured is checked when it is used.
+func validateBackend(backend types.String, diags *diag.Diagnostics) {
+	if backend.IsNull() || backend.IsUnknown() {
+		return
+	}
+
+	switch backend.ValueString() {
+	case backendPrivilegeCloud, backendSelfHosted:
+		// valid options
+	default:
+		if !backendNamePattern.MatchString(backend.ValueString()) {
+			diags.AddAttributeError(path.Root("backend"), "Invalid Backend",
+				fmt.Sprintf("Backend must be '%s', '%s' or the name of a provider backend block, got: %s",
+					backendPrivilegeCloud, backendSelfHosted, backend.ValueString()))
+		}
+	}
+}
+
+// backendOrDefault returns the backend, falling back to the default for state written before
+// the backend attribute existed.
+func backendOrDefault(backend types.String, defaultBackend string) types.String {
+	if backend.IsNull() || backend.IsUnknown() || backend.ValueString() == "" {
+		return types.StringValue(defaultBackend)
+	}
+	return backend
+}
+
+// pamAPIForBackend returns the PAM client for the backend and adds an error if it is not configured.
+func pamAPIForBackend(api *cybrapi.API, backend string, diags *diag.Diagnostics) cybrapi.PAMAPI {
+	switch backend {
+	case backendPrivilegeCloud:
+		return api.PamAPI
+	case backendSelfHosted:
+		if api.PVWAAPI == nil {
+			diags.AddError("Self-hosted backend not configured",
+				"The provider must be configured with pvwa_url, pvwa_username and pvwa_password to manage self-hosted objects.")
+		}
+		return api.PVWAAPI
+	}
+
+	pam, ok := api.Backends[backend]
+	if !ok {
+		diags.AddError("Backend not configured",
+			fmt.Sprintf("The provider has no backend block named %q.", backend))
+	}
+	return pam
+}
+
+// splitImportBackend splits an optional "privilege_cloud:", "self_hosted:" or "<backend block name>:"
+// prefix from an import ID.
+func splitImportBackend(api *cybrapi.API, id, defaultBackend string) (string, string) {
+	backends := []string{backendPrivilegeCloud, backendSelfHosted}
+	if api != nil {
+		for name := range api.Backends {
+			backends = append(backends, name)
+		}
+	}
+
+	for _, backend := range backends {
+		if rest, ok := strings.CutPrefix(id, backend+":"); ok {
+			return backend, rest
+		}
+	}
+	return defaultBackend, id
+}
+
+// pvwaAliasStateMover moves the state of a deprecated cyberark_pvwa_<suffix> resource to the
+// resource it aliases, setting the backend to self_hosted.
+func pvwaAliasStateMover(sourceSchema schema.Schema, suffix string, model any) resource.StateMover {
+	return resource.StateMover{
+		SourceSchema: &sourceSchema,
+		StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+			if !strings.HasSuffix(req.SourceTypeName, "_pvwa_"+suffix) || req.SourceState == nil {
+				return
+			}
+
+			resp.Diagnostics.Append(req.SourceState.Get(ctx, model)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			resp.Diagnostics.Append(resp.TargetState.Set(ctx, model)...)
+			resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("backend"), backendSelfHosted)...)
+		},
+	}
+}
@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitImportBackend(t *testing.T) {
+	tests := map[string]struct {
+		id, defaultBackend string
+		backend, rest      string
+	}{
+		"Default":        {"12_3", backendPrivilegeCloud, backendPrivilegeCloud, "12_3"},
+		"AliasDefault":   {"12_3", backendSelfHosted, backendSelfHosted, "12_3"},
+		"SelfHosted":     {"self_hosted:safe/account", backendPrivilegeCloud, backendSelfHosted, "safe/account"},
+		"PrivilegeCloud": {"privilege_cloud:name:safe", backendSelfHosted, backendPrivilegeCloud, "name:safe"},
+		"Named":          {"dr:safe/account", backendPrivilegeCloud, "dr", "safe/account"},
+		"UnknownPrefix":  {"other:safe", backendPrivilegeCloud, backendPrivilegeCloud, "other:safe"},
+	}
+
+	api := &cybrapi.API{Backends: map[string]cybrapi.PAMAPI{"dr": nil}}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			backend, rest := splitImportBackend(api, test.id, test.defaultBackend)
+			assert.Equal(t, test.backend, backend)
+			assert.Equal(t, test.rest, rest)
+		})
+	}
+}
+
+func TestBackendOrDefault(t *testing.T) {
+	assert.Equal(t, backendSelfHosted, backendOrDefault(types.StringNull(), backendSelfHosted).ValueString())
+	assert.Equal(t, backendPrivilegeCloud, backendOrDefault(types.StringValue(backendPrivilegeCloud), backendSelfHosted).ValueString())
+}
+
+func TestBackendAttributeRequiresReplace(t *testing.T) {
+	ctx := context.Background()
+	state := tfsdk.State{Raw: tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{})}
+	plan := tfsdk.Plan{Raw: tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{})}
+
+	tests := map[string]struct {
+		defaultBackend string
+		prior, planned types.String
+		replace        bool
+	}{
+		// State written before the backend attribute existed has no backend
+		"NullPriorDefault":      {backendPrivilegeCloud, types.StringNull(), types.StringValue(backendPrivilegeCloud), false},
+		"NullPriorAliasDefault": {backendSelfHosted, types.StringNull(), types.StringValue(backendSelfHosted), false},
+		"NullPriorOther":        {backendPrivilegeCloud, types.StringNull(), types.StringValue(backendSelfHosted), true},
+		"Unchanged":             {backendPrivilegeCloud, types.StringValue("dr"), types.StringValue("dr"), false},
+		"Changed":               {backendPrivilegeCloud, types.StringValue(backendPrivilegeCloud), types.StringValue("dr"), true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			attribute := backendAttribute(test.defaultBackend)
+			req := planmodifier.StringRequest{State: state, Plan: plan, StateValue: test.prior, PlanValue: test.planned}
+			resp := &planmodifier.StringResponse{PlanValue: test.planned}
+
+			for _, modifier := range attribute.PlanModifiers {
+				modifier.PlanModifyString(ctx, req, resp)
+			}
+			assert.Equal(t, test.replace, resp.RequiresReplace)
+		})
+	}
+}
+
+func TestValidateBackend(t *testing.T) {
+	diags := diag.Diagnostics{}
+	validateBackend(types.StringValue(backendSelfHosted), &diags)
+	validateBackend(types.StringNull(), &diags)
+	assert.False(t, diags.HasError())
+
+	validateBackend(types.StringValue("dr-site"), &diags)
+	assert.False(t, diags.HasError())
+
+	validateBackend(types.StringValue("dr site"), &diags)
+	assert.True(t, diags.HasError())
+}
+
+func TestPamAPIForBackend(t *testing.T) {
+	cloud := cybrapi.NewPAMAPI("https://cloud.example.com", []byte("token"), true)
+	api := &cybrapi.API{PamAPI: cloud}
+
+	diags := diag.Diagnostics{}
+	assert.Equal(t, cloud, pamAPIForBackend(api, backendPrivilegeCloud, &diags))
+	assert.False(t, diags.HasError())
+
+	assert.Nil(t, pamAPIForBackend(api, backendSelfHosted, &diags))
+	assert.True(t, diags.HasError())
+
+	selfHosted := cybrapi.NewPAMAPI("https://pvwa.example.com", []byte("token"), false)
+	api.PVWAAPI = selfHosted
+
+	diags = diag.Diagnostics{}
+	assert.Equal(t, selfHosted, pamAPIForBackend(api, backendSelfHosted, &diags))
+	assert.False(t, diags.HasError())
+
+	assert.Nil(t, pamAPIForBackend(api, "dr", &diags))
+	assert.True(t, diags.HasError())
+
+	dr := cybrapi.NewPAMAPI("https://dr.example.com", []byte("token"), false)
+	api.Backends = map[string]cybrapi.PAMAPI{"dr": dr}
+
+	diags = diag.Diagnostics{}
+	assert.Equal(t, dr, pamAPIForBackend(api, "dr", &diags))
+	assert.False(t, diags.HasError())
+}
+
+func TestBackendResourceSchemas(t *testing.T) {
+	ctx := context.Background()
+
+	for _, newResource := range []func() resource.Resource{
+		NewDBAccountResource, NewPVWADBAccountResource,
+		NewAWSAccountResource, NewPVWAAWSAccountResource,
+		NewAzureAccountResource, NewPVWAAzureAccountResource,
+		NewSafeResource, NewPVWASafeResource,
+	} {
+		r := newResource()
+
+		metadata := &resource.MetadataResponse{}
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "cyberark"}, metadata)
+
+		t.Run(metadata.TypeName, func(t *testing.T) {
+			schemaResponse := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResponse)
+			require.False(t, schemaResponse.Diagnostics.HasError())
+			require.False(t, schemaResponse.Schema.ValidateImplementation(ctx).HasError())
+
+			_, ok := schemaResponse.Schema.Attributes["backend"]
+			assert.True(t, ok)
+
+			// Only the deprecated aliases are deprecated and only the resources move state
+			movers := r.(resource.ResourceWithMoveState).MoveState(ctx)
+			if schemaResponse.Schema.DeprecationMessage != "" {
+				assert.Contains(t, metadata.TypeName, "_pvwa_")
+				assert.Empty(t, movers)
+			} else {
+				assert.NotContains(t, metadata.TypeName, "_pvwa_")
+				assert.Len(t, movers, 1)
+			}
+		})
+	}
+}
+
+func TestPVWAAliasStateMover(t *testing.T) {
+	ctx := context.Background()
+
+	target := &resource.SchemaResponse{}
+	NewDBAccountResource().Schema(ctx, resource.SchemaRequest{}, target)
+
+	mover := NewDBAccountResource().(resource.ResourceWithMoveState).MoveState(ctx)[0]
+
+	source := tfsdk.State{
+		Schema: *mover.SourceSchema,
+		Raw:    tftypes.NewValue(mover.SourceSchema.Type().TerraformType(ctx), nil),
+	}
+	require.False(t, source.Set(ctx, &dbCredModel{
+		Backend:  types.StringNull(),
+		ID:       types.StringValue("12_3"),
+		Name:     types.StringValue("db-admin"),
+		Safe:     types.StringValue("safe"),
+		Secret:   types.StringValue("secret"),
+		Username: types.StringValue("admin"),
+		Platform: types.StringValue("MySQL"),
+
+		RemoteMachines: types.SetNull(types.StringType),
+	}).HasError())
+
+	newResponse := func() *resource.MoveStateResponse {
+		return &resource.MoveStateResponse{
+			TargetState: tfsdk.State{
+				Schema: target.Schema,
+				Raw:    tftypes.NewValue(target.Schema.Type().TerraformType(ctx), nil),
+			},
+		}
+	}
+
+	t.Run("MovesAlias", func(t *testing.T) {
+		resp := newResponse()
+		mover.StateMover(ctx, resource.MoveStateRequest{SourceTypeName: "cyberark_pvwa_db_account", SourceState: &source}, resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		var moved dbCredModel
+		require.False(t, resp.TargetState.Get(ctx, &moved).HasError())
+		assert.Equal(t, "12_3", moved.ID.ValueString())
+		assert.Equal(t, "secret", moved.Secret.ValueString())
+		assert.Equal(t, backendSelfHosted, moved.Backend.ValueString())
+	})
+
+	t.Run("SkipsOtherTypes", func(t *testing.T) {
+		resp := newResponse()
+		mover.StateMover(ctx, resource.MoveStateRequest{SourceTypeName: "cyberark_pvwa_aws_account", SourceState: &source}, resp)
+		assert.False(t, resp.Diagnostics.HasError())
+		assert.True(t, resp.TargetState.Raw.IsNull())
+	})
+}
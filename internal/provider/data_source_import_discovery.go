@@ -0,0 +1,299 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/cyberark/terraform-provider-cyberark/internal/importnames"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &importDiscoveryDataSource{}
+	_ datasource.DataSourceWithConfigure      = &importDiscoveryDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &importDiscoveryDataSource{}
+)
+
+// discoveryKinds lists the kinds of objects the import discovery data source can enumerate.
+var discoveryKinds = []string{"safes", "accounts", "secret_stores", "sync_policies"}
+
+// NewImportDiscoveryDataSource is a helper function to simplify the provider implementation.
+func NewImportDiscoveryDataSource() datasource.DataSource {
+	return &importDiscoveryDataSource{}
+}
+
+// importDiscoveryDataSource is the data source implementation.
+type importDiscoveryDataSource struct {
+	api *cybrapi.API
+}
+
+// importDiscoveryModel describes the data source data model.
+type importDiscoveryModel struct {
+	Backend      types.String              `tfsdk:"backend"`
+	Include      []types.String            `tfsdk:"include"`
+	Resources    []discoveredResourceModel `tfsdk:"resources"`
+	ImportBlocks types.String              `tfsdk:"import_blocks"`
+}
+
+// discoveredResourceModel describes a single object that can be imported into Terraform.
+type discoveredResourceModel struct {
+	ResourceType types.String `tfsdk:"resource_type"`
+	Label        types.String `tfsdk:"label"`
+	ImportID     types.String `tfsdk:"import_id"`
+	Name         types.String `tfsdk:"name"`
+	Safe         types.String `tfsdk:"safe"`
+}
+
+// Metadata returns the data source type name.
+func (d *importDiscoveryDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_import_discovery"
+}
+
+// Schema returns the data source schema.
+func (d *importDiscoveryDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Import Discovery Data Source
+
+This data source enumerates the safes, accounts, secret stores and sync policies that already exist in a tenant, together with the resource type and ID needed to import each of them.
+
+The ` + "`import_blocks`" + ` attribute renders Terraform 1.5+ ` + "`import`" + ` blocks that can be written to a file and used with ` + "`terraform plan -generate-config-out`" + ` to bootstrap the configuration for an existing vault.`,
+		Attributes: map[string]schema.Attribute{
+			"backend": schema.StringAttribute{
+				Description: "Vault to discover safes and accounts in: `privilege_cloud` (default), `self_hosted` or the name of a provider `backend` block.",
+				Optional:    true,
+			},
+			"include": schema.ListAttribute{
+				Description: "Kinds of objects to discover. Valid values are `safes`, `accounts`, `secret_stores` and `sync_policies`. Defaults to all of them.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"resources": schema.ListNestedAttribute{
+				Description: "Discovered objects.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"resource_type": schema.StringAttribute{
+							Description: "Terraform resource type that manages the object.",
+							Computed:    true,
+						},
+						"label": schema.StringAttribute{
+							Description: "Suggested, unique resource label derived from the object name.",
+							Computed:    true,
+						},
+						"import_id": schema.StringAttribute{
+							Description: "ID to pass to `terraform import` or to an `import` block.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the object.",
+							Computed:    true,
+						},
+						"safe": schema.StringAttribute{
+							Description: "Safe containing the object, for accounts.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"import_blocks": schema.StringAttribute{
+				Description: "Terraform `import` blocks for all discovered objects.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *importDiscoveryDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(*cybrapi.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cybrapi.Api, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.api = api
+}
+
+// ValidateConfig validates the data source configuration.
+func (d *importDiscoveryDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data importDiscoveryModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateBackend(data.Backend, &resp.Diagnostics)
+
+	for _, kind := range data.Include {
+		if !kind.IsUnknown() && !slices.Contains(discoveryKinds, kind.ValueString()) {
+			resp.Diagnostics.AddError("Invalid Include Value",
+				fmt.Sprintf("Include values must be one of %v, got: %s", discoveryKinds, kind.ValueString()))
+		}
+	}
+}
+
+// Read enumerates the existing objects and sets the Terraform state.
+func (d *importDiscoveryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data importDiscoveryModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	include := discoveryKinds
+	if data.Include != nil {
+		include = []string{}
+		for _, kind := range data.Include {
+			include = append(include, kind.ValueString())
+		}
+	}
+
+	backend := backendOrDefault(data.Backend, backendPrivilegeCloud).ValueString()
+
+	pamAPI := pamAPIForBackend(d.api, backend, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Safes and accounts outside Privilege Cloud are imported with a backend prefix
+	importPrefix := ""
+	if backend != backendPrivilegeCloud {
+		importPrefix = backend + ":"
+	}
+
+	discovered := []discoveredResourceModel{}
+	add := func(resourceType, importID, name, safe string) {
+		item := discoveredResourceModel{
+			ResourceType: types.StringValue(resourceType),
+			ImportID:     types.StringValue(importID),
+			Name:         types.StringValue(name),
+			Safe:         types.StringNull(),
+		}
+		if safe != "" {
+			item.Safe = types.StringValue(safe)
+		}
+		discovered = append(discovered, item)
+	}
+
+	if slices.Contains(include, "safes") || slices.Contains(include, "accounts") {
+		safes, err := pamAPI.ListSafes(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing safes", err.Error())
+			return
+		}
+
+		for _, safe := range safes {
+			if safe.Name == nil || safe.URLID == nil {
+				continue
+			}
+
+			if slices.Contains(include, "safes") {
+				add("cyberark_safe", importPrefix+*safe.URLID, *safe.Name, "")
+			}
+
+			if slices.Contains(include, "accounts") {
+				accounts, err := pamAPI.ListAccounts(ctx, *safe.Name)
+				if err != nil {
+					resp.Diagnostics.AddError("Error listing accounts",
+						fmt.Sprintf("Error listing accounts in safe %s: %+v", *safe.Name, err))
+					return
+				}
+
+				for _, account := range accounts {
+					if account.CredID == nil || account.Name == nil {
+						continue
+					}
+					add("cyberark_"+importnames.AccountKind(account), importPrefix+*account.CredID, *account.Name, *safe.Name)
+				}
+			}
+		}
+	}
+
+	if slices.Contains(include, "secret_stores") {
+		awsStores, err := d.api.SecretsHubAPI.GetAwsAsmSecretStores(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing secret stores", err.Error())
+			return
+		}
+		for _, store := range awsStores.SecretStores {
+			add("cyberark_aws_secret_store", store.ID, cybrapi.StringValue(store.Name), "")
+		}
+
+		azureStores, err := d.api.SecretsHubAPI.GetAzureAkvSecretStores(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing secret stores", err.Error())
+			return
+		}
+		for _, store := range azureStores.SecretStores {
+			add("cyberark_azure_secret_store", store.ID, cybrapi.StringValue(store.Name), "")
+		}
+
+		gcpStores, err := d.api.SecretsHubAPI.GetGcpSecretStores(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing secret stores", err.Error())
+			return
+		}
+		for _, store := range gcpStores.SecretStores {
+			add("cyberark_gcp_secret_store", store.ID, cybrapi.StringValue(store.Name), "")
+		}
+	}
+
+	if slices.Contains(include, "sync_policies") {
+		policies, err := d.api.SecretsHubAPI.GetSyncPolicies(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing sync policies", err.Error())
+			return
+		}
+		for _, policy := range policies.Policies {
+			if policy.ID == nil {
+				continue
+			}
+			add("cyberark_sync_policy", *policy.ID, cybrapi.StringValue(policy.Name), "")
+		}
+	}
+
+	assignImportLabels(discovered)
+
+	data.Resources = discovered
+	data.ImportBlocks = types.StringValue(renderImportBlocks(discovered))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// assignImportLabels sets a label on every discovered object that is unique per resource type.
+func assignImportLabels(resources []discoveredResourceModel) {
+	labels := importnames.Labeler{}
+	for i := range resources {
+		label := labels.Label(resources[i].ResourceType.ValueString(), resources[i].Name.ValueString())
+		resources[i].Label = types.StringValue(label)
+	}
+}
+
+// renderImportBlocks renders a Terraform import block for every discovered object.
+func renderImportBlocks(resources []discoveredResourceModel) string {
+	var b strings.Builder
+	for _, r := range resources {
+		fmt.Fprintf(&b, "import {\n  to = %s.%s\n  id = %q\n}\n\n",
+			r.ResourceType.ValueString(), r.Label.ValueString(), r.ImportID.ValueString())
+	}
+	return b.String()
+}
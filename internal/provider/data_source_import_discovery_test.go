@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportDiscoveryDataSourceSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	schemaResponse := &datasource.SchemaResponse{}
+
+	NewImportDiscoveryDataSource().Schema(ctx, datasource.SchemaRequest{}, schemaResponse)
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	if diagnostics := schemaResponse.Schema.ValidateImplementation(ctx); diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestRenderImportBlocks(t *testing.T) {
+	resources := []discoveredResourceModel{
+		{ResourceType: types.StringValue("cyberark_safe"), ImportID: types.StringValue("Safe%20A"), Name: types.StringValue("Safe A")},
+		{ResourceType: types.StringValue("cyberark_safe"), ImportID: types.StringValue("safe_a"), Name: types.StringValue("safe_a")},
+		{ResourceType: types.StringValue("cyberark_db_account"), ImportID: types.StringValue("12_3"), Name: types.StringValue("Safe A")},
+	}
+
+	assignImportLabels(resources)
+
+	assert.Equal(t, "safe_a", resources[0].Label.ValueString())
+	assert.Equal(t, "safe_a_2", resources[1].Label.ValueString())
+	assert.Equal(t, "safe_a", resources[2].Label.ValueString())
+	assert.Equal(t, `import {
+  to = cyberark_safe.safe_a
+  id = "Safe%20A"
+}
+
+import {
+  to = cyberark_safe.safe_a_2
+  id = "safe_a"
+}
+
+import {
+  to = cyberark_db_account.safe_a
+  id = "12_3"
+}
+
+`, renderImportBlocks(resources))
+}
+
+func TestAssignImportLabelsCollisions(t *testing.T) {
+	resources := []discoveredResourceModel{
+		{ResourceType: types.StringValue("cyberark_safe"), Name: types.StringValue("safe a")},
+		{ResourceType: types.StringValue("cyberark_safe"), Name: types.StringValue("safe-a")},
+		{ResourceType: types.StringValue("cyberark_safe"), Name: types.StringValue("safe_a_2")},
+	}
+
+	assignImportLabels(resources)
+
+	assert.Equal(t, "safe_a", resources[0].Label.ValueString())
+	assert.Equal(t, "safe_a_2", resources[1].Label.ValueString())
+	assert.Equal(t, "safe_a_2_2", resources[2].Label.ValueString())
+}
@@ -0,0 +1,250 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &onboardingRulesDataSource{}
+	_ datasource.DataSourceWithConfigure      = &onboardingRulesDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &onboardingRulesDataSource{}
+)
+
+// NewOnboardingRulesDataSource is a helper function to simplify the provider implementation.
+func NewOnboardingRulesDataSource() datasource.DataSource {
+	return &onboardingRulesDataSource{}
+}
+
+// onboardingRulesDataSource is the data source implementation.
+type onboardingRulesDataSource struct {
+	api *cybrapi.API
+}
+
+// onboardingRulesModel describes the data source data model.
+type onboardingRulesModel struct {
+	Backend        types.String                 `tfsdk:"backend"`
+	TargetSafeName types.String                 `tfsdk:"target_safe_name"`
+	Rules          []onboardingRuleSummaryModel `tfsdk:"rules"`
+}
+
+// onboardingRuleSummaryModel describes a single onboarding rule.
+type onboardingRuleSummaryModel struct {
+	ID                    types.String `tfsdk:"id"`
+	RuleName              types.String `tfsdk:"rule_name"`
+	Description           types.String `tfsdk:"description"`
+	TargetPlatformID      types.String `tfsdk:"target_platform_id"`
+	TargetSafeName        types.String `tfsdk:"target_safe_name"`
+	SystemTypeFilter      types.String `tfsdk:"system_type_filter"`
+	MachineTypeFilter     types.String `tfsdk:"machine_type_filter"`
+	AccountCategoryFilter types.String `tfsdk:"account_category_filter"`
+	IsAdminIDFilter       types.Bool   `tfsdk:"is_admin_id_filter"`
+	UserNameFilter        types.String `tfsdk:"user_name_filter"`
+	UserNameMethod        types.String `tfsdk:"user_name_method"`
+	AddressFilter         types.String `tfsdk:"address_filter"`
+	AddressMethod         types.String `tfsdk:"address_method"`
+	ReconcileAccountID    types.String `tfsdk:"reconcile_account_id"`
+	RulePrecedence        types.Int64  `tfsdk:"rule_precedence"`
+}
+
+// Metadata returns the data source type name.
+func (d *onboardingRulesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_onboarding_rules"
+}
+
+// Schema returns the data source schema.
+func (d *onboardingRulesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Onboarding Rules Data Source
+
+This data source lists the automatic onboarding rules of a vault in the order of their precedence.
+
+For more information click [here](https://docs.cyberark.com/pam-self-hosted/latest/en/content/webservices/get%20automatic%20onboarding%20rules.htm).`,
+		Attributes: map[string]schema.Attribute{
+			"backend": schema.StringAttribute{
+				Description: "Vault to list the rules of: `privilege_cloud` (default), `self_hosted` or the name of a provider `backend` block.",
+				Optional:    true,
+			},
+			"target_safe_name": schema.StringAttribute{
+				Description: "Only list the rules that onboard accounts to this safe.",
+				Optional:    true,
+			},
+			"rules": schema.ListNestedAttribute{
+				Description: "The onboarding rules.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The numeric ID of the rule, which `cyberark_onboarding_rule` is imported with.",
+							Computed:    true,
+						},
+						"rule_name": schema.StringAttribute{
+							Description: "The name of the rule.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "The description of the rule.",
+							Computed:    true,
+						},
+						"target_platform_id": schema.StringAttribute{
+							Description: "The ID of the platform onboarded accounts are added with.",
+							Computed:    true,
+						},
+						"target_safe_name": schema.StringAttribute{
+							Description: "The name of the safe onboarded accounts are added to.",
+							Computed:    true,
+						},
+						"system_type_filter": schema.StringAttribute{
+							Description: "The system type of the accounts the rule applies to.",
+							Computed:    true,
+						},
+						"machine_type_filter": schema.StringAttribute{
+							Description: "The machine type of the accounts the rule applies to.",
+							Computed:    true,
+						},
+						"account_category_filter": schema.StringAttribute{
+							Description: "The category of the accounts the rule applies to.",
+							Computed:    true,
+						},
+						"is_admin_id_filter": schema.BoolAttribute{
+							Description: "Whether the rule only applies to accounts with the built-in administrator ID.",
+							Computed:    true,
+						},
+						"user_name_filter": schema.StringAttribute{
+							Description: "The user name of the accounts the rule applies to.",
+							Computed:    true,
+						},
+						"user_name_method": schema.StringAttribute{
+							Description: "How `user_name_filter` is compared.",
+							Computed:    true,
+						},
+						"address_filter": schema.StringAttribute{
+							Description: "The address of the accounts the rule applies to.",
+							Computed:    true,
+						},
+						"address_method": schema.StringAttribute{
+							Description: "How `address_filter` is compared.",
+							Computed:    true,
+						},
+						"reconcile_account_id": schema.StringAttribute{
+							Description: "The ID of the account that reconciles onboarded accounts.",
+							Computed:    true,
+						},
+						"rule_precedence": schema.Int64Attribute{
+							Description: "The precedence of the rule.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *onboardingRulesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(*cybrapi.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cybrapi.Api, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.api = api
+}
+
+// ValidateConfig validates the data source configuration.
+func (d *onboardingRulesDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var backend types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("backend"), &backend)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateBackend(backend, &resp.Diagnostics)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *onboardingRulesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data onboardingRulesModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Backend = backendOrDefault(data.Backend, backendPrivilegeCloud)
+	pam := pamAPIForBackend(d.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, err := pam.ListOnboardingRules(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading onboarding rules", err.Error())
+		return
+	}
+
+	data.Rules = onboardingRuleSummaries(rules, data.TargetSafeName.ValueString())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// onboardingRuleSummaries returns the rules that onboard accounts to the safe, or all rules if
+// safe is empty, in the order of their precedence.
+func onboardingRuleSummaries(rules []*cybrapi.OnboardingRule, safe string) []onboardingRuleSummaryModel {
+	summaries := []onboardingRuleSummaryModel{}
+	for _, rule := range rules {
+		if safe != "" && (rule.TargetSafeName == nil || *rule.TargetSafeName != safe) {
+			continue
+		}
+
+		summary := onboardingRuleSummaryModel{
+			ID:                    types.StringNull(),
+			RuleName:              types.StringPointerValue(rule.RuleName),
+			Description:           types.StringPointerValue(rule.RuleDescription),
+			TargetPlatformID:      types.StringPointerValue(rule.TargetPlatformID),
+			TargetSafeName:        types.StringPointerValue(rule.TargetSafeName),
+			SystemTypeFilter:      types.StringPointerValue(rule.SystemTypeFilter),
+			MachineTypeFilter:     types.StringPointerValue(rule.MachineTypeFilter),
+			AccountCategoryFilter: types.StringPointerValue(rule.AccountCategoryFilter),
+			IsAdminIDFilter:       types.BoolPointerValue(rule.IsAdminIDFilter),
+			UserNameFilter:        types.StringPointerValue(rule.UserNameFilter),
+			UserNameMethod:        types.StringPointerValue(rule.UserNameMethod),
+			AddressFilter:         types.StringPointerValue(rule.AddressFilter),
+			AddressMethod:         types.StringPointerValue(rule.AddressMethod),
+			ReconcileAccountID:    types.StringPointerValue(rule.ReconcileAccountID),
+			RulePrecedence:        types.Int64Null(),
+		}
+		if rule.RuleID != nil {
+			summary.ID = types.StringValue(strconv.Itoa(*rule.RuleID))
+		}
+		if rule.RulePrecedence != nil {
+			summary.RulePrecedence = types.Int64Value(int64(*rule.RulePrecedence))
+		}
+		summaries = append(summaries, summary)
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].RulePrecedence.ValueInt64() < summaries[j].RulePrecedence.ValueInt64()
+	})
+	return summaries
+}
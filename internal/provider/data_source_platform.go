@@ -0,0 +1,206 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &platformDataSource{}
+	_ datasource.DataSourceWithConfigure      = &platformDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &platformDataSource{}
+)
+
+// NewPlatformDataSource is a helper function to simplify the provider implementation.
+func NewPlatformDataSource() datasource.DataSource {
+	return &platformDataSource{}
+}
+
+// platformDataSource is the data source implementation.
+type platformDataSource struct {
+	api *cybrapi.API
+}
+
+// platformDataSourceModel describes the data source data model.
+type platformDataSourceModel struct {
+	Backend            types.String `tfsdk:"backend"`
+	PlatformID         types.String `tfsdk:"platform_id"`
+	Name               types.String `tfsdk:"name"`
+	Description        types.String `tfsdk:"description"`
+	SystemType         types.String `tfsdk:"system_type"`
+	PlatformType       types.String `tfsdk:"platform_type"`
+	PlatformBaseID     types.String `tfsdk:"platform_base_id"`
+	Active             types.Bool   `tfsdk:"active"`
+	TargetID           types.Int64  `tfsdk:"target_id"`
+	RequiredProperties types.List   `tfsdk:"required_properties"`
+	OptionalProperties types.List   `tfsdk:"optional_properties"`
+}
+
+// Metadata returns the data source type name.
+func (d *platformDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_platform"
+}
+
+// Schema returns the data source schema.
+func (d *platformDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Platform Data Source
+
+This data source looks up a platform by its ID, so that accounts can reference a platform that is known to exist and to be active, and lists the account properties the platform requires.
+
+For more information click [here](https://docs.cyberark.com/privilege-cloud-shared-services/latest/en/content/webservices/getplatforms.htm).`,
+		Attributes: map[string]schema.Attribute{
+			"backend": schema.StringAttribute{
+				Description: "Vault to look up the platform in: `privilege_cloud` (default), `self_hosted` or the name of a provider `backend` block.",
+				Optional:    true,
+			},
+			"platform_id": schema.StringAttribute{
+				Description: "The ID of the platform, as referenced by the `platform` attribute of accounts.",
+				Required:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the platform.",
+				Computed:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the platform.",
+				Computed:    true,
+			},
+			"system_type": schema.StringAttribute{
+				Description: "The type of the target systems of the platform.",
+				Computed:    true,
+			},
+			"platform_type": schema.StringAttribute{
+				Description: "The type of the platform, e.g. `Regular` or `Group`.",
+				Computed:    true,
+			},
+			"platform_base_id": schema.StringAttribute{
+				Description: "The ID of the platform the platform is based on.",
+				Computed:    true,
+			},
+			"active": schema.BoolAttribute{
+				Description: "Whether the platform is active, so that accounts can be added with it.",
+				Computed:    true,
+			},
+			"target_id": schema.Int64Attribute{
+				Description: "The numeric ID of the target platform, if the platform is one. It identifies the platform to duplicate in `cyberark_target_platform`.",
+				Computed:    true,
+			},
+			"required_properties": schema.ListAttribute{
+				Description: "The account properties the platform requires.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"optional_properties": schema.ListAttribute{
+				Description: "The optional account properties of the platform.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *platformDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(*cybrapi.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cybrapi.Api, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.api = api
+}
+
+// ValidateConfig validates the data source configuration.
+func (d *platformDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var backend types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("backend"), &backend)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateBackend(backend, &resp.Diagnostics)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *platformDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data platformDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Backend = backendOrDefault(data.Backend, backendPrivilegeCloud)
+	pam := pamAPIForBackend(d.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	platforms, err := pam.ListPlatforms(ctx, cybrapi.PlatformFilter{})
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading platforms", err.Error())
+		return
+	}
+
+	platform := findPlatform(platforms, data.PlatformID.ValueString())
+	if platform == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("platform_id"), "Platform not found",
+			fmt.Sprintf("The vault has no platform with the ID %s.", data.PlatformID.ValueString()))
+		return
+	}
+
+	general := platform.General
+	data.Name = types.StringPointerValue(general.Name)
+	data.Description = types.StringPointerValue(general.Description)
+	data.SystemType = types.StringPointerValue(general.SystemType)
+	data.PlatformType = types.StringPointerValue(general.PlatformType)
+	data.PlatformBaseID = types.StringPointerValue(general.PlatformBaseID)
+	data.Active = types.BoolPointerValue(general.Active)
+
+	var required, optional []*cybrapi.PlatformProperty
+	if platform.Properties != nil {
+		required, optional = platform.Properties.Required, platform.Properties.Optional
+	}
+	data.RequiredProperties = platformPropertyNames(required)
+	data.OptionalProperties = platformPropertyNames(optional)
+
+	// Only target platforms have a numeric ID
+	data.TargetID = types.Int64Null()
+	targets, err := pam.ListTargetPlatforms(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to read target platforms", err.Error())
+	} else if target := findTargetPlatform(targets, data.PlatformID.ValueString()); target != nil && target.ID != nil {
+		data.TargetID = types.Int64Value(int64(*target.ID))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// platformPropertyNames returns the names of the platform properties as a list.
+func platformPropertyNames(properties []*cybrapi.PlatformProperty) types.List {
+	names := []string{}
+	for _, property := range properties {
+		if property.Name != nil {
+			names = append(names, *property.Name)
+		}
+	}
+	return stringList(names)
+}
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func discoveredAccount(userName, address, safe string) discoveredAccountModel {
+	account := discoveredAccountModel{
+		UserName:          types.StringValue(userName),
+		Address:           types.StringValue(address),
+		PlatformType:      types.StringValue("Windows Server Local"),
+		Properties:        types.MapValueMust(types.StringType, nil),
+		OnboardSafeName:   types.StringNull(),
+		OnboardPlatformID: types.StringNull(),
+	}
+	if safe != "" {
+		account.OnboardSafeName = types.StringValue(safe)
+		account.OnboardPlatformID = types.StringValue("WinServerLocal")
+	}
+	return account
+}
+
+const (
+	discoveredAccountsRoute = "POST /PasswordVault/API/DiscoveredAccounts"
+	onboardRoute            = "POST /PasswordVault/API/DiscoveredAccounts/{id}/Onboard"
+)
+
+// addDiscoveredAccount returns a handler that records the user names of the added discovered
+// accounts and answers with an ID derived from the user name.
+func addDiscoveredAccount(t *testing.T, added *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		account := cybrapi.DiscoveredAccount{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&account))
+		assert.NotNil(t, account.DiscoveryDate)
+		*added = append(*added, *account.UserName)
+		writeJSON(w, http.StatusCreated, map[string]string{"id": "d-" + *account.UserName, "status": "added"})
+	}
+}
+
+// onboardDiscoveredAccount returns a handler that records the IDs of the onboarded discovered
+// accounts and answers with the ID of the new account.
+func onboardDiscoveredAccount(onboarded *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*onboarded = append(*onboarded, r.PathValue("id"))
+		writeJSON(w, http.StatusCreated, map[string]string{"id": "12_3"})
+	}
+}
+
+func TestDiscoveredAccountsApply(t *testing.T) {
+	ctx := context.Background()
+	r := &discoveredAccountsResource{}
+
+	t.Run("AddAndOnboard", func(t *testing.T) {
+		var added, onboarded []string
+		pam := newPAMServer(t, map[string]http.HandlerFunc{
+			discoveredAccountsRoute: addDiscoveredAccount(t, &added),
+			onboardRoute:            onboardDiscoveredAccount(&onboarded),
+		})
+
+		data := discoveredAccountsModel{Accounts: []discoveredAccountModel{
+			discoveredAccount("admin", "srv1", ""),
+			discoveredAccount("backup", "srv1", "Windows-Servers"),
+		}}
+		var diags diag.Diagnostics
+		r.apply(ctx, pam, &data, map[string]string{}, &diags)
+
+		require.False(t, diags.HasError(), diags)
+		assert.Equal(t, []string{"admin", "backup"}, added)
+		assert.Equal(t, []string{"d-backup"}, onboarded)
+		assert.Equal(t, map[string]string{"admin@srv1": "d-admin"}, mapStrings(data.AccountIDs))
+		assert.Equal(t, map[string]string{"backup@srv1": "12_3"}, mapStrings(data.OnboardedAccountIDs))
+
+		// Onboarded accounts left the discovered accounts list and are not added again
+		added, onboarded = nil, nil
+		r.apply(ctx, pam, &data, mapStrings(data.OnboardedAccountIDs), &diags)
+
+		require.False(t, diags.HasError(), diags)
+		assert.Equal(t, []string{"admin"}, added)
+		assert.Empty(t, onboarded)
+		assert.Equal(t, map[string]string{"backup@srv1": "12_3"}, mapStrings(data.OnboardedAccountIDs))
+	})
+
+	t.Run("OnboardedAccountRemoved", func(t *testing.T) {
+		var added []string
+		pam := newPAMServer(t, map[string]http.HandlerFunc{
+			discoveredAccountsRoute: addDiscoveredAccount(t, &added),
+		})
+
+		data := discoveredAccountsModel{Accounts: []discoveredAccountModel{discoveredAccount("admin", "srv1", "")}}
+		var diags diag.Diagnostics
+		r.apply(ctx, pam, &data, map[string]string{"backup@srv1": "12_3"}, &diags)
+
+		require.False(t, diags.HasError(), diags)
+		assert.Equal(t, []string{"admin"}, added)
+		assert.Empty(t, mapStrings(data.OnboardedAccountIDs))
+	})
+
+	t.Run("AddFails", func(t *testing.T) {
+		var added []string
+		pam := newPAMServer(t, map[string]http.HandlerFunc{
+			discoveredAccountsRoute: func(w http.ResponseWriter, r *http.Request) {
+				if len(added) == 1 {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				addDiscoveredAccount(t, &added)(w, r)
+			},
+		})
+
+		data := discoveredAccountsModel{Accounts: []discoveredAccountModel{
+			discoveredAccount("admin", "srv1", ""),
+			discoveredAccount("backup", "srv1", "Windows-Servers"),
+			discoveredAccount("svc", "srv1", ""),
+		}}
+		var diags diag.Diagnostics
+		r.apply(ctx, pam, &data, map[string]string{}, &diags)
+
+		// Adding stops at the first failure, and nothing is onboarded. The state keeps the added
+		// account, and the next apply adds the others again
+		require.True(t, diags.HasError())
+		assert.Contains(t, diags.Errors()[0].Detail(), "Added 1 of 3 accounts")
+		assert.Equal(t, []string{"admin"}, added)
+		assert.Equal(t, map[string]string{"admin@srv1": "d-admin"}, mapStrings(data.AccountIDs))
+		assert.Empty(t, mapStrings(data.OnboardedAccountIDs))
+		assert.Equal(t, []discoveredAccountModel{discoveredAccount("admin", "srv1", "")}, data.Accounts)
+	})
+
+	t.Run("OnboardFails", func(t *testing.T) {
+		var added, onboarded []string
+		pam := newPAMServer(t, map[string]http.HandlerFunc{
+			discoveredAccountsRoute: addDiscoveredAccount(t, &added),
+			onboardRoute: func(w http.ResponseWriter, r *http.Request) {
+				if r.PathValue("id") == "d-svc" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				onboardDiscoveredAccount(&onboarded)(w, r)
+			},
+		})
+
+		data := discoveredAccountsModel{Accounts: []discoveredAccountModel{
+			discoveredAccount("admin", "srv1", ""),
+			discoveredAccount("backup", "srv1", "Windows-Servers"),
+			discoveredAccount("svc", "srv1", "Missing-Safe"),
+		}}
+		var diags diag.Diagnostics
+		r.apply(ctx, pam, &data, map[string]string{}, &diags)
+
+		require.True(t, diags.HasError())
+		assert.Equal(t, "Error onboarding discovered account", diags.Errors()[0].Summary())
+		assert.Contains(t, diags.Errors()[0].Detail(), "svc@srv1 to safe Missing-Safe")
+
+		// The account onboarded before the failure is recorded and not added again, while the
+		// account that failed to onboard is left out of the state, so the next apply onboards it
+		assert.Equal(t, []string{"d-backup"}, onboarded)
+		assert.Equal(t, map[string]string{"admin@srv1": "d-admin", "svc@srv1": "d-svc"}, mapStrings(data.AccountIDs))
+		assert.Equal(t, map[string]string{"backup@srv1": "12_3"}, mapStrings(data.OnboardedAccountIDs))
+		assert.Equal(t, []discoveredAccountModel{
+			discoveredAccount("admin", "srv1", ""),
+			discoveredAccount("backup", "srv1", "Windows-Servers"),
+		}, data.Accounts)
+	})
+
+	t.Run("InvalidAccount", func(t *testing.T) {
+		// Nothing is sent to the vault, so there is no state to save
+		pam := newPAMServer(t, nil)
+
+		account := discoveredAccount("admin", "srv1", "")
+		account.Properties = types.MapValueMust(types.BoolType, map[string]attr.Value{"enabled": types.BoolValue(true)})
+		data := discoveredAccountsModel{Accounts: []discoveredAccountModel{account}}
+		var diags diag.Diagnostics
+
+		assert.False(t, r.apply(ctx, pam, &data, map[string]string{}, &diags))
+		assert.True(t, diags.HasError())
+	})
+}
+
+func TestDiscoveredAccountsID(t *testing.T) {
+	a, b := discoveredAccount("admin", "srv1", ""), discoveredAccount("backup", "srv2", "")
+
+	assert.Equal(t, discoveredAccountsID([]discoveredAccountModel{a, b}), discoveredAccountsID([]discoveredAccountModel{b, a}))
+	assert.NotEqual(t, discoveredAccountsID([]discoveredAccountModel{a}), discoveredAccountsID([]discoveredAccountModel{b}))
+	assert.Len(t, discoveredAccountsID([]discoveredAccountModel{a}), 16)
+}
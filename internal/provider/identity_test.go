@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"testing"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindIdentityRoleMember(t *testing.T) {
+	userID, roleID := "A1B2-C3", "role_2"
+	members := []*cybrapi.IdentityRoleMember{{GUID: &userID}, {}, {GUID: &roleID}}
+
+	assert.Equal(t, &userID, findIdentityRoleMember(members, "a1b2-c3").GUID)
+	assert.Equal(t, &roleID, findIdentityRoleMember(members, "role_2").GUID)
+	assert.Nil(t, findIdentityRoleMember(members, "missing"))
+	assert.Nil(t, findIdentityRoleMember(nil, "role_2"))
+}
+
+func TestSetIdentityServiceUserModel(t *testing.T) {
+	id, name := "user_1", "svc@example"
+	data := identityServiceUserModel{
+		Username:    types.StringValue(name),
+		Password:    types.StringValue("s3cr3t"),
+		DisplayName: types.StringUnknown(),
+		Description: types.StringValue("Terraform"),
+		Email:       types.StringUnknown(),
+	}
+
+	setIdentityServiceUserModel(&data, &cybrapi.IdentityServiceUser{ID: &id, Name: &name})
+
+	assert.Equal(t, types.StringValue(id), data.ID)
+	assert.Equal(t, types.StringValue("s3cr3t"), data.Password)
+	assert.Equal(t, types.StringValue(""), data.DisplayName)
+	assert.Equal(t, types.StringValue("Terraform"), data.Description)
+	assert.Equal(t, types.StringValue(""), data.Email)
+	assert.Equal(t, types.StringValue(name), data.ClientID)
+}
@@ -0,0 +1,116 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+)
+
+// importByNamePrefix marks an import ID that refers to an object by name instead of by ID.
+const importByNamePrefix = "name:"
+
+// importNameFromID returns the name from an import ID of the form "name:<name>".
+func importNameFromID(importID string) (string, bool) {
+	if !strings.HasPrefix(importID, importByNamePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(importID, importByNamePrefix), true
+}
+
+// uniqueImportID returns the only ID in ids, or an error explaining why the name could not be
+// resolved to exactly one object.
+func uniqueImportID(kind string, name string, ids []string) (string, error) {
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no %s named %q was found", kind, name)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%d objects of type %s are named %q (IDs: %s), import by ID instead",
+			len(ids), kind, name, strings.Join(ids, ", "))
+	}
+}
+
+// secretStoreIDsByName returns the IDs of all secret stores with the given name.
+func secretStoreIDsByName[T cybrapi.AwsAsmData | cybrapi.AzureAkvData | cybrapi.GcpData](stores *cybrapi.SecretStoresOutput[T], name string) []string {
+	ids := []string{}
+	for _, store := range stores.SecretStores {
+		if store.Name != nil && *store.Name == name {
+			ids = append(ids, store.ID)
+		}
+	}
+	return ids
+}
+
+// resolveSyncPolicyImportID resolves a "name:<policy name>" import ID to the sync policy ID.
+// Any other import ID is returned unchanged.
+func resolveSyncPolicyImportID(ctx context.Context, api cybrapi.SecretsHubAPI, importID string) (string, error) {
+	name, ok := importNameFromID(importID)
+	if !ok {
+		return importID, nil
+	}
+
+	policies, err := api.GetSyncPolicies(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	ids := []string{}
+	for _, policy := range policies.Policies {
+		if policy.Name != nil && *policy.Name == name && policy.ID != nil {
+			ids = append(ids, *policy.ID)
+		}
+	}
+
+	return uniqueImportID("sync policy", name, ids)
+}
+
+// resolveAccountImportID resolves an import ID of the form "<safe>/<account name>" to the account
+// ID. Any other import ID is returned unchanged, since account IDs never contain a slash.
+func resolveAccountImportID(ctx context.Context, api cybrapi.PAMAPI, importID string) (string, error) {
+	safeName, accountName, ok := strings.Cut(importID, "/")
+	if !ok {
+		return importID, nil
+	}
+
+	if safeName == "" || accountName == "" {
+		return "", fmt.Errorf("invalid import ID %q, expected <safe>/<account name>", importID)
+	}
+
+	accounts, err := api.ListAccounts(ctx, safeName)
+	if err != nil {
+		return "", err
+	}
+
+	ids := []string{}
+	for _, account := range accounts {
+		if account.Name != nil && *account.Name == accountName && account.CredID != nil {
+			ids = append(ids, *account.CredID)
+		}
+	}
+
+	return uniqueImportID("account", importID, ids)
+}
+
+// resolveSafeImportID resolves a "name:<safe name>" import ID to the safe URL ID.
+// Any other import ID is returned unchanged.
+func resolveSafeImportID(ctx context.Context, api cybrapi.PAMAPI, importID string) (string, error) {
+	name, ok := importNameFromID(importID)
+	if !ok {
+		return importID, nil
+	}
+
+	safe, err := api.GetSafe(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("no safe named %q was found: %w", name, err)
+	}
+
+	if safe.URLID == nil {
+		return "", fmt.Errorf("safe %q has no URL ID", name)
+	}
+
+	return *safe.URLID, nil
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportNameFromID(t *testing.T) {
+	name, ok := importNameFromID("name:my store")
+	assert.True(t, ok)
+	assert.Equal(t, "my store", name)
+
+	_, ok = importNameFromID("store-1234")
+	assert.False(t, ok)
+}
+
+func TestUniqueImportID(t *testing.T) {
+	id, err := uniqueImportID("secret store", "one", []string{"store-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "store-1", id)
+
+	_, err = uniqueImportID("secret store", "none", []string{})
+	assert.ErrorContains(t, err, `no secret store named "none"`)
+
+	_, err = uniqueImportID("secret store", "dup", []string{"store-1", "store-2"})
+	assert.ErrorContains(t, err, "store-1, store-2")
+}
+
+func TestResolveAccountImportID(t *testing.T) {
+	// The safe lists more accounts than fit in a page, and db-reader is on the second one
+	accounts := []*cybrapi.CredentialResponse{}
+	for i := range 150 {
+		id, name := fmt.Sprintf("12_%d", i), fmt.Sprintf("db-user-%d", i)
+		accounts = append(accounts, &cybrapi.CredentialResponse{CredID: &id, Name: &name})
+	}
+	id2, name2 := "12_4", "db-reader"
+	accounts[120] = &cybrapi.CredentialResponse{CredID: &id2, Name: &name2}
+
+	api := newPAMServer(t, map[string]http.HandlerFunc{
+		"GET /PasswordVault/API/Accounts": func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "safeName eq app-safe", r.URL.Query().Get("filter"))
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+			count := len(accounts)
+			writeJSON(w, http.StatusOK, cybrapi.CredentialSearchResponse{
+				Accounts: accounts[min(offset, count):min(offset+limit, count)],
+				Count:    &count,
+			})
+		},
+	})
+
+	t.Run("ByID", func(t *testing.T) {
+		id, err := resolveAccountImportID(context.Background(), api, "12_3")
+		assert.NoError(t, err)
+		assert.Equal(t, "12_3", id)
+	})
+
+	t.Run("BySafeAndName", func(t *testing.T) {
+		id, err := resolveAccountImportID(context.Background(), api, "app-safe/db-reader")
+		assert.NoError(t, err)
+		assert.Equal(t, id2, id)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := resolveAccountImportID(context.Background(), api, "app-safe/missing")
+		assert.Error(t, err)
+	})
+
+	t.Run("InvalidFormat", func(t *testing.T) {
+		_, err := resolveAccountImportID(context.Background(), api, "app-safe/")
+		assert.Error(t, err)
+	})
+}
+
+func TestResolveSyncPolicyImportID(t *testing.T) {
+	var (
+		id1  = "policy-1"
+		id2  = "policy-2"
+		name = "sync-app-safe"
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(rw).Encode(cybrapi.SyncResponse{
+			Policies: []*cybrapi.PolicyExternalOutput{
+				{ID: &id1, Name: &name},
+				{ID: &id2, Name: &name},
+			},
+		})
+	}))
+	defer server.Close()
+
+	api := cybrapi.NewSecretsHubAPI(server.URL, []byte("dummy_token"))
+
+	_, err := resolveSyncPolicyImportID(context.Background(), api, "name:sync-app-safe")
+	assert.ErrorContains(t, err, "import by ID instead")
+
+	id, err := resolveSyncPolicyImportID(context.Background(), api, "policy-3")
+	assert.NoError(t, err)
+	assert.Equal(t, "policy-3", id)
+}
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func onboardingRule(id, precedence int, safe string) *cybrapi.OnboardingRule {
+	platform, system, empty := "WinDomain", "Windows", ""
+	return &cybrapi.OnboardingRule{
+		RuleID:           &id,
+		RulePrecedence:   &precedence,
+		TargetSafeName:   &safe,
+		TargetPlatformID: &platform,
+		SystemTypeFilter: &system,
+		AddressFilter:    &empty,
+	}
+}
+
+func TestOptionalString(t *testing.T) {
+	empty, value := "", "srv"
+
+	assert.Equal(t, types.StringNull(), optionalString(nil, types.StringNull()))
+	assert.Equal(t, types.StringNull(), optionalString(&empty, types.StringNull()))
+	assert.Equal(t, types.StringNull(), optionalString(&empty, types.StringUnknown()))
+	assert.Equal(t, types.StringValue("srv"), optionalString(&value, types.StringNull()))
+	assert.Equal(t, types.StringValue(""), optionalString(&empty, types.StringValue("srv")))
+	assert.Equal(t, types.StringValue("srv"), optionalString(nil, types.StringValue("srv")))
+}
+
+func TestSetOnboardingRuleModel(t *testing.T) {
+	data := onboardingRuleModel{
+		RuleName:       types.StringUnknown(),
+		AddressFilter:  types.StringNull(),
+		RulePrecedence: types.Int64Unknown(),
+		UserNameMethod: types.StringValue("Equals"),
+	}
+
+	name := "Rule 12"
+	rule := onboardingRule(12, 3, "Windows-Servers")
+	rule.RuleName = &name
+	setOnboardingRuleModel(&data, rule)
+
+	assert.Equal(t, types.StringValue("12"), data.ID)
+	assert.Equal(t, types.StringValue(backendPrivilegeCloud), data.Backend)
+	assert.Equal(t, types.StringValue("Rule 12"), data.RuleName)
+	assert.Equal(t, types.StringNull(), data.AddressFilter)
+	assert.Equal(t, types.StringValue("Equals"), data.UserNameMethod)
+	assert.Equal(t, types.Int64Value(3), data.RulePrecedence)
+	assert.Equal(t, types.StringValue("Windows-Servers"), data.TargetSafeName)
+}
+
+func TestOnboardingRules(t *testing.T) {
+	rules := []*cybrapi.OnboardingRule{
+		onboardingRule(7, 2, "Unix"),
+		onboardingRule(5, 1, "Windows-Servers"),
+		onboardingRule(9, 3, "Windows-Servers"),
+	}
+
+	t.Run("Find", func(t *testing.T) {
+		require.NotNil(t, findOnboardingRule(rules, "9"))
+		assert.Equal(t, 9, *findOnboardingRule(rules, "9").RuleID)
+		assert.Nil(t, findOnboardingRule(rules, "1"))
+	})
+
+	t.Run("Summaries", func(t *testing.T) {
+		summaries := onboardingRuleSummaries(rules, "")
+		require.Len(t, summaries, 3)
+		assert.Equal(t, types.StringValue("5"), summaries[0].ID)
+		assert.Equal(t, types.StringValue("7"), summaries[1].ID)
+		assert.Equal(t, types.StringValue("9"), summaries[2].ID)
+	})
+
+	t.Run("SummariesOfSafe", func(t *testing.T) {
+		summaries := onboardingRuleSummaries(rules, "Windows-Servers")
+		require.Len(t, summaries, 2)
+		assert.Equal(t, types.StringValue("5"), summaries[0].ID)
+		assert.Equal(t, types.StringValue("9"), summaries[1].ID)
+	})
+}
+
+// onboardingRuleState returns the state of an onboarding rule resource holding data.
+func onboardingRuleState(t *testing.T, data onboardingRuleModel) tfsdk.State {
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	NewOnboardingRuleResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	require.False(t, state.Set(ctx, &data).HasError())
+	return state
+}
+
+func TestOnboardingRuleRead(t *testing.T) {
+	ctx := context.Background()
+	const rulesRoute = "GET /PasswordVault/API/AutomaticOnboardingRules/{$}"
+
+	prior := onboardingRuleModel{
+		Backend:          types.StringValue(backendPrivilegeCloud),
+		ID:               types.StringValue("12"),
+		TargetPlatformID: types.StringValue("WinDomain"),
+		TargetSafeName:   types.StringValue("Windows-Servers"),
+		SystemTypeFilter: types.StringValue("Windows"),
+		RulePrecedence:   types.Int64Value(1),
+	}
+
+	read := func(t *testing.T, handler http.HandlerFunc) *resource.ReadResponse {
+		pam := newPAMServer(t, map[string]http.HandlerFunc{rulesRoute: handler})
+		r := &onboardingRuleResource{api: &cybrapi.API{PamAPI: pam}}
+
+		state := onboardingRuleState(t, prior)
+		resp := &resource.ReadResponse{State: state}
+		r.Read(ctx, resource.ReadRequest{State: state}, resp)
+		return resp
+	}
+
+	t.Run("ChangedOutsideTerraform", func(t *testing.T) {
+		// Other rules were added before this one, and the vault returns its unset filters as empty strings
+		resp := read(t, func(w http.ResponseWriter, _ *http.Request) {
+			writeJSON(w, http.StatusOK, cybrapi.OnboardingRuleSearchResponse{
+				Rules: []*cybrapi.OnboardingRule{onboardingRule(5, 1, "Unix"), onboardingRule(12, 2, "Windows-Servers")},
+			})
+		})
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+		var data onboardingRuleModel
+		require.False(t, resp.State.Get(ctx, &data).HasError())
+		assert.Equal(t, types.Int64Value(2), data.RulePrecedence)
+		assert.Equal(t, types.StringNull(), data.AddressFilter)
+	})
+
+	t.Run("DeletedOutsideTerraform", func(t *testing.T) {
+		resp := read(t, func(w http.ResponseWriter, _ *http.Request) {
+			writeJSON(w, http.StatusOK, cybrapi.OnboardingRuleSearchResponse{
+				Rules: []*cybrapi.OnboardingRule{onboardingRule(5, 1, "Unix")},
+			})
+		})
+
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+		assert.True(t, resp.State.Raw.IsNull())
+	})
+
+	t.Run("ListFails", func(t *testing.T) {
+		resp := read(t, respondStatus(http.StatusInternalServerError))
+
+		require.True(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "Error reading onboarding rules", resp.Diagnostics.Errors()[0].Summary())
+		assert.False(t, resp.State.Raw.IsNull())
+	})
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+)
+
+// newPAMServer returns a PAM API backed by a test server that serves the given routes. Routes are
+// http.ServeMux patterns such as "DELETE /PasswordVault/API/Safes/{safe}/Members/{member}", and
+// requests matching none of them fail the test.
+func newPAMServer(t *testing.T, routes map[string]http.HandlerFunc) cybrapi.PAMAPI {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	for pattern, handler := range routes {
+		mux.HandleFunc(pattern, handler)
+	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotImplemented)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return cybrapi.NewPAMAPI(server.URL, []byte("token"), true)
+}
+
+// writeJSON writes v as the JSON body of a response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// respondStatus returns a handler that answers every request with the given status code.
+func respondStatus(status int) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+	}
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// findPlatform returns the platform with the given ID, or nil if the vault has none. Platform IDs
+// are case-insensitive.
+func findPlatform(platforms []*cybrapi.Platform, platformID string) *cybrapi.Platform {
+	for _, platform := range platforms {
+		if platform.General != nil && platform.General.ID != nil && strings.EqualFold(*platform.General.ID, platformID) {
+			return platform
+		}
+	}
+	return nil
+}
+
+// findTargetPlatform returns the target platform with the given platform ID, or nil if there is none.
+func findTargetPlatform(platforms []*cybrapi.TargetPlatform, platformID string) *cybrapi.TargetPlatform {
+	for _, platform := range platforms {
+		if platform.PlatformID != nil && strings.EqualFold(*platform.PlatformID, platformID) {
+			return platform
+		}
+	}
+	return nil
+}
+
+// checkPlatform adds an error to diags when the platform does not exist in the backend or is not
+// active. Failing to list the platforms is only a warning, as the provider user may not be
+// allowed to view them. The platforms are listed once per backend with the cache, and listed
+// again before reporting an error, as the platform may have been added or activated since.
+func checkPlatform(ctx context.Context, cache *cybrapi.PlatformCache, backend string, pam cybrapi.PAMAPI, platformID string, diags *diag.Diagnostics) {
+	platforms, cached, err := cache.Platforms(ctx, backend, pam, false)
+	if err == nil && cached && !platformActive(findPlatform(platforms, platformID)) {
+		platforms, _, err = cache.Platforms(ctx, backend, pam, true)
+	}
+	if err != nil {
+		diags.AddWarning("Unable to verify platform",
+			fmt.Sprintf("Could not verify that platform %s exists: %s", platformID, err))
+		return
+	}
+
+	platform := findPlatform(platforms, platformID)
+	if platform == nil {
+		diags.AddAttributeError(path.Root("platform"), "Platform not found",
+			fmt.Sprintf("The vault has no platform with the ID %s. Platform IDs can be looked up with the "+
+				"cyberark_platform data source.", platformID))
+		return
+	}
+
+	if !platformActive(platform) {
+		diags.AddAttributeError(path.Root("platform"), "Platform not active",
+			fmt.Sprintf("Platform %s is not active, so accounts cannot be added with it. Activate the platform, "+
+				"e.g. with the cyberark_target_platform resource.", platformID))
+	}
+}
+
+// platformActive reports whether the platform exists and is active. Platforms without an active
+// flag count as active.
+func platformActive(platform *cybrapi.Platform) bool {
+	return platform != nil && (platform.General.Active == nil || *platform.General.Active)
+}
+
+// modifyAccountPlan checks the platform of an account that is created or whose platform
+// changes, if platform validation is enabled on the provider.
+func modifyAccountPlan(ctx context.Context, api *cybrapi.API, defaultBackend string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the resource is destroyed
+	if api == nil || !api.ValidatePlatforms || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var platform, backend types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("platform"), &platform)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("backend"), &backend)...)
+	if resp.Diagnostics.HasError() || platform.IsNull() || platform.IsUnknown() || backend.IsUnknown() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var prior types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("platform"), &prior)...)
+		if resp.Diagnostics.HasError() || strings.EqualFold(prior.ValueString(), platform.ValueString()) {
+			return
+		}
+	}
+
+	backendName := backendOrDefault(backend, defaultBackend).ValueString()
+	pam := pamAPIForBackend(api, backendName, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checkPlatform(ctx, api.Platforms, backendName, pam, platform.ValueString(), &resp.Diagnostics)
+}
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+// listPlatforms returns a handler that lists the given platforms. The vault only filters
+// platforms when asked to, so the request must not carry any filter.
+func listPlatforms(t *testing.T, platforms ...*cybrapi.Platform) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.URL.RawQuery)
+		writeJSON(w, http.StatusOK, cybrapi.PlatformSearchResponse{Platforms: platforms})
+	}
+}
+
+// platform returns a platform with the given ID, which is inactive if active is false and has no
+// active flag if active is nil.
+func platform(id string, active *bool) *cybrapi.Platform {
+	return &cybrapi.Platform{General: &cybrapi.PlatformGeneral{ID: &id, Active: active}}
+}
+
+func TestCheckPlatform(t *testing.T) {
+	active, inactive := true, false
+	listed := []*cybrapi.Platform{
+		platform("WinDomain", &active),
+		platform("UnixSSH", &inactive),
+		platform("Oracle", nil),
+		{General: nil},
+	}
+
+	tests := []struct {
+		name     string
+		handler  http.HandlerFunc
+		platform string
+		summary  string
+		warning  bool
+	}{
+		{
+			name:     "Active",
+			handler:  listPlatforms(t, listed...),
+			platform: "windomain",
+		},
+		{
+			name:     "NoActiveFlag",
+			handler:  listPlatforms(t, listed...),
+			platform: "Oracle",
+		},
+		{
+			name:     "NotFound",
+			handler:  listPlatforms(t, listed...),
+			platform: "WinDomian",
+			summary:  "Platform not found",
+		},
+		{
+			name:     "Inactive",
+			handler:  listPlatforms(t, listed...),
+			platform: "UnixSSH",
+			summary:  "Platform not active",
+		},
+		{
+			name:     "ListFails",
+			handler:  respondStatus(http.StatusForbidden),
+			platform: "WinDomain",
+			summary:  "Unable to verify platform",
+			warning:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pam := newPAMServer(t, map[string]http.HandlerFunc{
+				"GET /PasswordVault/API/Platforms": tt.handler,
+			})
+			var diags diag.Diagnostics
+
+			checkPlatform(context.Background(), &cybrapi.PlatformCache{}, backendPrivilegeCloud, pam, tt.platform, &diags)
+
+			if tt.summary == "" {
+				assert.Empty(t, diags)
+				return
+			}
+			assert.Len(t, diags, 1)
+			assert.Equal(t, tt.summary, diags[0].Summary())
+			assert.Equal(t, tt.warning, diags.WarningsCount() == 1)
+		})
+	}
+}
+
+func TestCheckPlatformCache(t *testing.T) {
+	ctx := context.Background()
+	active := true
+
+	var listings int
+	listed := []*cybrapi.Platform{platform("WinDomain", &active)}
+	pam := newPAMServer(t, map[string]http.HandlerFunc{
+		"GET /PasswordVault/API/Platforms": func(w http.ResponseWriter, r *http.Request) {
+			listings++
+			listPlatforms(t, listed...)(w, r)
+		},
+	})
+	cache := &cybrapi.PlatformCache{}
+
+	// Accounts of the same backend share one listing
+	for range 3 {
+		var diags diag.Diagnostics
+		checkPlatform(ctx, cache, backendPrivilegeCloud, pam, "WinDomain", &diags)
+		assert.Empty(t, diags)
+	}
+	assert.Equal(t, 1, listings)
+
+	// A platform missing from the cached listing may have been added since, so it is listed again
+	listed = append(listed, platform("UnixSSH", &active))
+	var diags diag.Diagnostics
+	checkPlatform(ctx, cache, backendPrivilegeCloud, pam, "UnixSSH", &diags)
+	assert.Empty(t, diags)
+	assert.Equal(t, 2, listings)
+
+	// Other backends are listed separately
+	checkPlatform(ctx, cache, "dr", pam, "WinDomain", &diags)
+	assert.Empty(t, diags)
+	assert.Equal(t, 3, listings)
+}
@@ -3,11 +3,17 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
 
 	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -20,6 +26,13 @@ var (
 	_ provider.ProviderWithValidateConfig = &secretsHubProvider{}
 )
 
+// radiusOTPEnv is the environment variable the RADIUS one-time password is read from when
+// pvwa_radius_otp is not set.
+const radiusOTPEnv = "CYBERARK_PVWA_RADIUS_OTP"
+
+// validPVWALoginMethods lists the supported PVWA login methods.
+var validPVWALoginMethods = []string{"cyberark", "ldap", "windows", "radius"}
+
 const (
 	cloudAuthURL       = "https://%s.id.cyberark.cloud"
 	cloudPamURL        = "https://%s.privilegecloud.cyberark.cloud"
@@ -28,19 +41,67 @@ const (
 
 // secretsHubProvider defines the provider implementation.
 type secretsHubProvider struct {
-	version string
+	version  string
+	sessions *sessionRegistry
+}
+
+// sessionRegistry tracks the vault sessions opened by the provider so that they can be logged off
+// when the provider process shuts down.
+type sessionRegistry struct {
+	mu       sync.Mutex
+	sessions []*cybrapi.Session
+}
+
+// add registers a session to be closed on shutdown.
+func (r *sessionRegistry) add(session *cybrapi.Session) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sessions = append(r.sessions, session)
+}
+
+// closeAll logs off all registered sessions. Sessions are logged off concurrently, so that
+// each of them can use the whole time left before the provider process is killed.
+func (r *sessionRegistry) closeAll(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	errs := make([]error, len(r.sessions))
+	var wg sync.WaitGroup
+	for i, session := range r.sessions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := session.Close(ctx); err != nil {
+				errs[i] = fmt.Errorf("failed to log off PVWA session: %w", err)
+			}
+		}()
+	}
+	wg.Wait()
+	r.sessions = nil
+
+	return errors.Join(errs...)
 }
 
 // secretsHubProviderModel describes the provider data model.
 type secretsHubProviderModel struct {
-	Tenant          types.String `tfsdk:"tenant"`
-	ClientID        types.String `tfsdk:"client_id"`
-	ClientSecret    types.String `tfsdk:"client_secret"`
-	Domain          types.String `tfsdk:"domain"`
-	PVWAUsername    types.String `tfsdk:"pvwa_username"`
-	PVWAPassword    types.String `tfsdk:"pvwa_password"`
-	PVWAURL         types.String `tfsdk:"pvwa_url"`
-	PVWALoginMethod types.String `tfsdk:"pvwa_login_method"`
+	Tenant            types.String        `tfsdk:"tenant"`
+	ClientID          types.String        `tfsdk:"client_id"`
+	ClientSecret      types.String        `tfsdk:"client_secret"`
+	Domain            types.String        `tfsdk:"domain"`
+	PVWAUsername      types.String        `tfsdk:"pvwa_username"`
+	PVWAPassword      types.String        `tfsdk:"pvwa_password"`
+	PVWAURL           types.String        `tfsdk:"pvwa_url"`
+	PVWALoginMethod   types.String        `tfsdk:"pvwa_login_method"`
+	PVWARadiusOTP     types.String        `tfsdk:"pvwa_radius_otp"`
+	PVWARadiusMode    types.String        `tfsdk:"pvwa_radius_mode"`
+	PVWARadiusPush    types.Int64         `tfsdk:"pvwa_radius_push_timeout"`
+	TokenCache        types.Bool          `tfsdk:"token_cache"`
+	ValidatePlatforms types.Bool          `tfsdk:"validate_platforms"`
+	LogRedactedKeys   types.List          `tfsdk:"log_redacted_keys"`
+	Auth              *providerAuthModel  `tfsdk:"auth"`
+	PVWAAuth          *providerAuthModel  `tfsdk:"pvwa_auth"`
+	Backends          []backendBlockModel `tfsdk:"backend"`
 }
 
 // Metadata returns the provider type name.
@@ -63,8 +124,8 @@ func (p *secretsHubProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				Required:    true,
 			},
 			"client_secret": schema.StringAttribute{
-				Description: "CyberArk Client ID Password.",
-				Required:    true,
+				Description: "CyberArk Client ID Password. Required unless the `auth` block selects another method.",
+				Optional:    true,
 				Sensitive:   true,
 			},
 			"domain": schema.StringAttribute{
@@ -88,6 +149,44 @@ func (p *secretsHubProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				Description: "CyberArk PVWA Login Method.",
 				Optional:    true,
 			},
+			"pvwa_radius_otp": schema.StringAttribute{
+				Description: fmt.Sprintf("One-time password for the `radius` login method, or `push` to approve the logon on a device. "+
+					"Defaults to the `%s` environment variable.", radiusOTPEnv),
+				Optional:  true,
+				Sensitive: true,
+			},
+			"pvwa_radius_mode": schema.StringAttribute{
+				Description: "How the RADIUS one-time password is sent: `challenge` to answer the challenge of the PVWA (default) or " +
+					"`append` to append it to the password.",
+				Optional: true,
+			},
+			"pvwa_radius_push_timeout": schema.Int64Attribute{
+				Description: fmt.Sprintf("Seconds to wait for a RADIUS push logon to be approved. Defaults to %d.",
+					int64(cybrapi.DefaultRADIUSPushTimeout/time.Second)),
+				Optional: true,
+			},
+			"token_cache": schema.BoolAttribute{
+				Description: fmt.Sprintf("Cache ISPSS platform tokens in a private directory under `$XDG_CACHE_HOME`, so that "+
+					"Terraform runs reuse them until shortly before they expire. Defaults to the `%s` environment variable, "+
+					"or `false`.", tokenCacheEnv),
+				Optional: true,
+			},
+			"validate_platforms": schema.BoolAttribute{
+				Description: "Check during plans that the `platform` of accounts exists and is active in the vault of the " +
+					"account, instead of failing when the account is added. Defaults to `false`.",
+				Optional: true,
+			},
+			"log_redacted_keys": schema.ListAttribute{
+				Description: fmt.Sprintf("JSON keys and HTTP headers whose values are masked in the logged requests and responses "+
+					"of the CyberArk APIs, in addition to `%s`.", strings.Join(cybrapi.DefaultRedactedKeys, "`, `")),
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"auth":      authBlock(false),
+			"pvwa_auth": authBlock(true),
+			"backend":   backendBlock(),
 		},
 	}
 }
@@ -102,7 +201,6 @@ func (p *secretsHubProvider) ValidateConfig(ctx context.Context, req provider.Va
 	}
 
 	// Validate PVWA Login Method
-	validPVWALoginMethods := []string{"cyberark", "ldap", "windows", "radius"}
 	if data.PVWALoginMethod.ValueString() != "" {
 		valid := false
 		for _, method := range validPVWALoginMethods {
@@ -125,6 +223,11 @@ func (p *secretsHubProvider) ValidateConfig(ctx context.Context, req provider.Va
 		"pvwa_url":      data.PVWAURL,
 	}
 
+	// The password may be fetched from a vault instead
+	if authMethod(data.PVWAAuth, authMethodPassword) != authMethodPassword {
+		delete(pvwaAttributes, "pvwa_password")
+	}
+
 	// Check if any PVWA attribute is set
 	anySet := false
 	for _, attr := range pvwaAttributes {
@@ -143,6 +246,38 @@ func (p *secretsHubProvider) ValidateConfig(ctx context.Context, req provider.Va
 			}
 		}
 	}
+
+	// Validate RADIUS settings
+	switch data.PVWARadiusMode.ValueString() {
+	case "", cybrapi.RADIUSModeChallenge, cybrapi.RADIUSModeAppend:
+		// valid options
+	default:
+		resp.Diagnostics.AddAttributeError(path.Root("pvwa_radius_mode"), "Invalid PVWA RADIUS Mode",
+			fmt.Sprintf("PVWA RADIUS mode must be either '%s' or '%s', got: %s", cybrapi.RADIUSModeChallenge, cybrapi.RADIUSModeAppend, data.PVWARadiusMode.ValueString()))
+	}
+
+	if !data.PVWARadiusPush.IsNull() && !data.PVWARadiusPush.IsUnknown() && data.PVWARadiusPush.ValueInt64() <= 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("pvwa_radius_push_timeout"), "Invalid PVWA RADIUS Push Timeout",
+			"PVWA RADIUS push timeout must be a positive number of seconds")
+	}
+
+	validateAuth(data, &resp.Diagnostics)
+	validateBackendBlocks(data, &resp.Diagnostics)
+}
+
+// radiusOptions returns the RADIUS settings of PVWA logons. The OTP defaults to the
+// CYBERARK_PVWA_RADIUS_OTP environment variable.
+func radiusOptions(data secretsHubProviderModel) *cybrapi.RADIUSOptions {
+	otp := data.PVWARadiusOTP.ValueString()
+	if otp == "" {
+		otp = os.Getenv(radiusOTPEnv)
+	}
+
+	return &cybrapi.RADIUSOptions{
+		OTP:         otp,
+		Mode:        data.PVWARadiusMode.ValueString(),
+		PushTimeout: time.Duration(data.PVWARadiusPush.ValueInt64()) * time.Second,
+	}
 }
 
 // Configure parses the configuration data and initializes the provider.
@@ -154,26 +289,39 @@ func (p *secretsHubProvider) Configure(ctx context.Context, req provider.Configu
 		return
 	}
 
-	t := data.Tenant.ValueString()
-	cid := data.ClientID.ValueString()
 	d := data.Domain.ValueString()
 
+	// Logins are deferred until a resource first uses a client, so that plans which do not touch
+	// a backend never log in to it. Each backend logs in at most once, independently of the others.
+
 	// Create a client for Cyberark ISPSS (Identity Security Platform Shared Services)
-	identityAuthAPI := cybrapi.NewIdentityAuthAPI(fmt.Sprintf(cloudAuthURL, t))
+	tokenCache := newTokenCache(data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	token, err := identityAuthAPI.GetToken(ctx, cid, []byte(data.ClientSecret.ValueString()))
+	var redactedKeys []string
+	resp.Diagnostics.Append(data.LogRedactedKeys.ElementsAs(ctx, &redactedKeys, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	clientOptions := []cybrapi.ClientOption{cybrapi.WithRedactedKeys(redactedKeys...), cybrapi.WithLogContext(ctx)}
 
+	cloudLogin, err := newCloudLogin(fmt.Sprintf(cloudAuthURL, data.Tenant.ValueString()), data, tokenCache)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to get authentication token",
-			fmt.Sprintf("Failed to get authentication token from Cyberark ISPSS service: %+v", err))
+		resp.Diagnostics.AddAttributeError(path.Root("auth"), "Invalid Authentication Configuration", err.Error())
 		return
 	}
+	identityToken := cybrapi.NewLazyTokenSource(cloudLogin)
 
 	// Create a client for Cyberark PAM
-	pamAPI := cybrapi.NewPAMAPI(fmt.Sprintf(cloudPamURL, d), token, true)
+	pamAPI := cybrapi.NewPAMAPIWithTokenSource(fmt.Sprintf(cloudPamURL, d), identityToken, true, clientOptions...)
 
 	// Create a client for Cyberark SecretsHub
-	secretsHubAPI := cybrapi.NewSecretsHubAPI(fmt.Sprintf(cloudSecretsHubURL, d), token)
+	secretsHubAPI := cybrapi.NewSecretsHubAPIWithTokenSource(fmt.Sprintf(cloudSecretsHubURL, d), identityToken, clientOptions...)
+
+	// Create a client for Cyberark Identity, which manages the roles and users of the tenant
+	identityAPI := cybrapi.NewIdentityAPIWithTokenSource(fmt.Sprintf(cloudAuthURL, data.Tenant.ValueString()), identityToken, clientOptions...)
 
 	var pvwaAPI cybrapi.PAMAPI = nil
 	if data.PVWAURL.ValueString() != "" {
@@ -183,27 +331,49 @@ func (p *secretsHubProvider) Configure(ctx context.Context, req provider.Configu
 			loginMethod = data.PVWALoginMethod.ValueString()
 		}
 
-		pvwaAuthAPI := cybrapi.NewPVWAAuthAPI(data.PVWAURL.ValueString(), loginMethod)
-		pvwaToken, err := pvwaAuthAPI.GetToken(ctx, data.PVWAUsername.ValueString(), []byte(data.PVWAPassword.ValueString()))
+		pvwaUsername := data.PVWAUsername.ValueString()
+		pvwaPassword := data.PVWAPassword.ValueString()
 
+		pvwaAuthAPI := cybrapi.NewPVWAAuthAPI(data.PVWAURL.ValueString(), loginMethod)
+		pvwaAuthAPI.RADIUS = radiusOptions(data)
+		pvwaFetcher, err := newPVWAFetcher(pvwaAuthAPI, data)
 		if err != nil {
-			resp.Diagnostics.AddError("Failed to get PVWA authentication token",
-				fmt.Sprintf("Failed to get authentication token from Cyberark PVWA service: %+v", err))
+			resp.Diagnostics.AddAttributeError(path.Root("pvwa_auth"), "Invalid PVWA Authentication Configuration", err.Error())
 			return
 		}
 
-		pvwaAPI = cybrapi.NewPAMAPI(data.PVWAURL.ValueString(), pvwaToken, false)
+		pvwaSession := cybrapi.NewSession(func(ctx context.Context) ([]byte, error) {
+			token, err := pvwaFetcher.GetToken(ctx, pvwaUsername, []byte(pvwaPassword))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get authentication token from Cyberark PVWA service: %w", err)
+			}
+			return token, nil
+		}, pvwaAuthAPI.Logoff)
+		p.sessions.add(pvwaSession)
+
+		pvwaAPI = cybrapi.NewPAMAPIWithTokenSource(data.PVWAURL.ValueString(), pvwaSession.Token, false, clientOptions...)
 	}
 
+	backends := newNamedBackends(data, p.sessions, tokenCache, clientOptions...)
+
 	resp.DataSourceData = &cybrapi.API{
 		PamAPI:        pamAPI,
 		SecretsHubAPI: secretsHubAPI,
 		PVWAAPI:       pvwaAPI,
+		IdentityAPI:   identityAPI,
+		Backends:      backends,
+
+		ValidatePlatforms: data.ValidatePlatforms.ValueBool(),
 	}
 	resp.ResourceData = &cybrapi.API{
 		PamAPI:        pamAPI,
 		SecretsHubAPI: secretsHubAPI,
 		PVWAAPI:       pvwaAPI,
+		IdentityAPI:   identityAPI,
+		Backends:      backends,
+
+		ValidatePlatforms: data.ValidatePlatforms.ValueBool(),
+		Platforms:         &cybrapi.PlatformCache{},
 	}
 }
 
@@ -211,6 +381,9 @@ func (p *secretsHubProvider) Configure(ctx context.Context, req provider.Configu
 func (p *secretsHubProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewTokenDataSource,
+		NewImportDiscoveryDataSource,
+		NewPlatformDataSource,
+		NewOnboardingRulesDataSource,
 	}
 }
 
@@ -229,15 +402,41 @@ func (p *secretsHubProvider) Resources(_ context.Context) []func() resource.Reso
 		NewPVWASafeResource,
 		NewSyncPolicyResource,
 		NewSecretStoreStateResource,
+		NewSecretStoreScanResource,
 		NewGcpSecretStoreResource,
+		NewVaultUserResource,
+		NewVaultGroupResource,
+		NewVaultGroupMemberResource,
+		NewIdentityRoleResource,
+		NewIdentityRoleMemberResource,
+		NewIdentityServiceUserResource,
+		NewTargetPlatformResource,
+		NewApplicationResource,
+		NewApplicationAuthMethodResource,
+		NewOnboardingRuleResource,
+		NewDiscoveredAccountsResource,
+		NewAccountGroupResource,
+		NewAccountGroupMemberResource,
 	}
 }
 
 // New creates a new provider instance.
 func New(version string) func() provider.Provider {
-	return func() provider.Provider {
+	factory, _ := NewWithShutdown(version)
+	return factory
+}
+
+// NewWithShutdown creates a new provider instance and a function that logs off the PVWA sessions
+// opened by the provider. The shutdown function is meant to be called once the provider server
+// has stopped.
+func NewWithShutdown(version string) (func() provider.Provider, func(ctx context.Context) error) {
+	sessions := &sessionRegistry{}
+
+	factory := func() provider.Provider {
 		return &secretsHubProvider{
-			version: version,
+			version:  version,
+			sessions: sessions,
 		}
 	}
+	return factory, sessions.closeAll
 }
@@ -0,0 +1,377 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Methods the provider can authenticate with. client_secret and oidc apply to ISPSS, password to
+// the PVWA, and the ccp and conjur methods fetch the client secret or PVWA password from a vault.
+const (
+	authMethodClientSecret = "client_secret"
+	authMethodOIDC         = "oidc"
+	authMethodPassword     = "password"
+	authMethodCCP          = "ccp"
+	authMethodConjur       = "conjur"
+)
+
+// providerAuthModel describes how the provider authenticates to ISPSS or the PVWA.
+type providerAuthModel struct {
+	Method types.String `tfsdk:"method"`
+
+	JWTFile types.String `tfsdk:"jwt_file"`
+	JWTEnv  types.String `tfsdk:"jwt_env"`
+
+	CCPURL         types.String `tfsdk:"ccp_url"`
+	AppID          types.String `tfsdk:"app_id"`
+	Safe           types.String `tfsdk:"safe"`
+	Object         types.String `tfsdk:"object"`
+	ClientCertFile types.String `tfsdk:"client_cert_file"`
+	ClientKeyFile  types.String `tfsdk:"client_key_file"`
+	CACertFile     types.String `tfsdk:"ca_cert_file"`
+
+	ConjurURL       types.String `tfsdk:"conjur_url"`
+	ConjurAccount   types.String `tfsdk:"conjur_account"`
+	ConjurVariable  types.String `tfsdk:"conjur_variable"`
+	ConjurLogin     types.String `tfsdk:"conjur_login"`
+	ConjurAPIKey    types.String `tfsdk:"conjur_api_key"`
+	ConjurServiceID types.String `tfsdk:"conjur_authn_jwt_service_id"`
+}
+
+// authBlock returns the schema of the provider auth block, or of the pvwa_auth block if pvwa is set.
+func authBlock(pvwa bool) schema.SingleNestedBlock {
+	description := "How the provider authenticates to CyberArk Identity. Defaults to the `client_secret` of the service user."
+	methodDescription := "Authentication method: `client_secret` (default), `oidc` to exchange an OIDC token issued to the " +
+		"CI workload (GitHub Actions, GitLab, Terraform Cloud workload identity) for a platform token of `client_id`, " +
+		"or `ccp` or `conjur` to fetch the client secret from the Central Credential Provider or Conjur."
+	jwtDescription := "the OIDC token of the `oidc` method or the JWT of Conjur JWT authentication"
+	if pvwa {
+		description = "How the provider gets the PVWA password. Defaults to `pvwa_password`."
+		methodDescription = "Authentication method: `password` (default) to use `pvwa_password`, or `ccp` or `conjur` to fetch " +
+			"the PVWA password from the Central Credential Provider or Conjur."
+		jwtDescription = "the JWT of Conjur JWT authentication"
+	}
+
+	return schema.SingleNestedBlock{
+		Description: description,
+		Attributes: map[string]schema.Attribute{
+			"method": schema.StringAttribute{
+				Description: methodDescription,
+				Optional:    true,
+			},
+			"jwt_file": schema.StringAttribute{
+				Description: "Path of a file containing " + jwtDescription + ". The file is read on every login, so it may be rotated.",
+				Optional:    true,
+			},
+			"jwt_env": schema.StringAttribute{
+				Description: "Environment variable containing " + jwtDescription + ", e.g. `TFC_WORKLOAD_IDENTITY_TOKEN`.",
+				Optional:    true,
+			},
+			"ccp_url": schema.StringAttribute{
+				Description: "Central Credential Provider URL, e.g. https://ccp.example.com.",
+				Optional:    true,
+			},
+			"app_id": schema.StringAttribute{
+				Description: "Application ID the Central Credential Provider authorizes.",
+				Optional:    true,
+			},
+			"safe": schema.StringAttribute{
+				Description: "Safe of the account that holds the secret.",
+				Optional:    true,
+			},
+			"object": schema.StringAttribute{
+				Description: "Name of the account that holds the secret.",
+				Optional:    true,
+			},
+			"client_cert_file": schema.StringAttribute{
+				Description: "PEM client certificate file for Central Credential Provider client certificate authentication.",
+				Optional:    true,
+			},
+			"client_key_file": schema.StringAttribute{
+				Description: "PEM private key file of `client_cert_file`.",
+				Optional:    true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "PEM CA certificate file to verify the Central Credential Provider with, instead of the system roots.",
+				Optional:    true,
+			},
+			"conjur_url": schema.StringAttribute{
+				Description: "Conjur URL, e.g. https://conjur.example.com.",
+				Optional:    true,
+			},
+			"conjur_account": schema.StringAttribute{
+				Description: "Conjur account.",
+				Optional:    true,
+			},
+			"conjur_variable": schema.StringAttribute{
+				Description: "Path of the Conjur variable that holds the secret.",
+				Optional:    true,
+			},
+			"conjur_login": schema.StringAttribute{
+				Description: "Conjur user or host, e.g. `host/terraform/ci`, for API key authentication.",
+				Optional:    true,
+			},
+			"conjur_api_key": schema.StringAttribute{
+				Description: "API key of `conjur_login`.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"conjur_authn_jwt_service_id": schema.StringAttribute{
+				Description: "Service ID of the Conjur JWT authenticator, for JWT authentication with `jwt_file` or `jwt_env` " +
+					"instead of an API key.",
+				Optional: true,
+			},
+		},
+	}
+}
+
+// authMethod returns the configured authentication method, or defaultMethod if none is set.
+func authMethod(auth *providerAuthModel, defaultMethod string) string {
+	if auth == nil || auth.Method.ValueString() == "" {
+		return defaultMethod
+	}
+	return auth.Method.ValueString()
+}
+
+// validateAuth checks that the credentials of the authentication methods are configured.
+func validateAuth(data secretsHubProviderModel, diags *diag.Diagnostics) {
+	if data.Auth == nil || !data.Auth.Method.IsUnknown() {
+		switch method := authMethod(data.Auth, authMethodClientSecret); method {
+		case authMethodClientSecret:
+			if data.ClientSecret.IsNull() {
+				diags.AddAttributeError(path.Root("client_secret"), "Missing Client Secret",
+					"client_secret is required unless the auth block selects another method")
+			}
+		case authMethodOIDC:
+			validateJWTSource(data.Auth, path.Root("auth"), method, diags)
+		case authMethodCCP, authMethodConjur:
+			validateSecretSource(data.Auth, path.Root("auth"), diags)
+		default:
+			diags.AddAttributeError(path.Root("auth").AtName("method"), "Invalid Authentication Method",
+				fmt.Sprintf("Authentication method must be one of %v, got: %s",
+					[]string{authMethodClientSecret, authMethodOIDC, authMethodCCP, authMethodConjur}, method))
+		}
+	}
+
+	if data.PVWAAuth != nil && !data.PVWAAuth.Method.IsUnknown() {
+		switch method := authMethod(data.PVWAAuth, authMethodPassword); method {
+		case authMethodPassword:
+			// pvwa_password is validated with the other PVWA attributes
+		case authMethodCCP, authMethodConjur:
+			validateSecretSource(data.PVWAAuth, path.Root("pvwa_auth"), diags)
+		default:
+			diags.AddAttributeError(path.Root("pvwa_auth").AtName("method"), "Invalid Authentication Method",
+				fmt.Sprintf("PVWA authentication method must be one of %v, got: %s",
+					[]string{authMethodPassword, authMethodCCP, authMethodConjur}, method))
+		}
+	}
+}
+
+// validateJWTSource checks that exactly one of jwt_file and jwt_env is set.
+func validateJWTSource(auth *providerAuthModel, blockPath path.Path, method string, diags *diag.Diagnostics) {
+	if auth.JWTFile.IsUnknown() || auth.JWTEnv.IsUnknown() {
+		return
+	}
+	if auth.JWTFile.IsNull() == auth.JWTEnv.IsNull() {
+		diags.AddAttributeError(blockPath, "Invalid JWT Configuration",
+			fmt.Sprintf("Exactly one of jwt_file or jwt_env must be set with the %s method", method))
+	}
+}
+
+// validateSecretSource checks the attributes of the ccp and conjur methods.
+func validateSecretSource(auth *providerAuthModel, blockPath path.Path, diags *diag.Diagnostics) {
+	required := func(method string, attributes map[string]types.String) {
+		for name, value := range attributes {
+			if value.IsNull() {
+				diags.AddAttributeError(blockPath.AtName(name), "Missing Authentication Attribute",
+					fmt.Sprintf("%s is required with the %s method", name, method))
+			}
+		}
+	}
+
+	switch auth.Method.ValueString() {
+	case authMethodCCP:
+		required(authMethodCCP, map[string]types.String{
+			"ccp_url": auth.CCPURL,
+			"app_id":  auth.AppID,
+			"safe":    auth.Safe,
+			"object":  auth.Object,
+		})
+		if auth.ClientCertFile.IsNull() != auth.ClientKeyFile.IsNull() {
+			diags.AddAttributeError(blockPath, "Invalid Client Certificate Configuration",
+				"client_cert_file and client_key_file must be set together")
+		}
+	case authMethodConjur:
+		required(authMethodConjur, map[string]types.String{
+			"conjur_url":      auth.ConjurURL,
+			"conjur_account":  auth.ConjurAccount,
+			"conjur_variable": auth.ConjurVariable,
+		})
+		if auth.ConjurServiceID.IsNull() {
+			required("conjur API key", map[string]types.String{
+				"conjur_login":   auth.ConjurLogin,
+				"conjur_api_key": auth.ConjurAPIKey,
+			})
+		} else {
+			validateJWTSource(auth, blockPath, "conjur JWT", diags)
+		}
+	}
+}
+
+// newCloudLogin returns the ISPSS login of the provider configuration. Platform tokens are
+// shared through cache when it is not nil.
+func newCloudLogin(identityURL string, data secretsHubProviderModel, cache *cybrapi.TokenCache) (cybrapi.TokenSource, error) {
+	clientID := data.ClientID.ValueString()
+	cacheKey := tokenCacheKey(data.Tenant.ValueString(), clientID)
+
+	var (
+		fetcher    cybrapi.ExpiringTokenFetcher
+		credential func() ([]byte, error)
+	)
+
+	switch authMethod(data.Auth, authMethodClientSecret) {
+	case authMethodOIDC:
+		jwtFile := data.Auth.JWTFile.ValueString()
+		jwtEnv := data.Auth.JWTEnv.ValueString()
+
+		fetcher = cybrapi.NewOIDCAuthAPI(identityURL)
+		credential = func() ([]byte, error) {
+			return readJWT(jwtFile, jwtEnv)
+		}
+	case authMethodCCP, authMethodConjur:
+		source, err := newSecretSource(data.Auth)
+		if err != nil {
+			return nil, err
+		}
+
+		fetcher = &cybrapi.SourcedTokenFetcher{Fetcher: cybrapi.NewIdentityAuthAPI(identityURL), Source: source}
+		credential = func() ([]byte, error) {
+			return nil, nil
+		}
+	default:
+		clientSecret := data.ClientSecret.ValueString()
+
+		fetcher = cybrapi.NewIdentityAuthAPI(identityURL)
+		credential = func() ([]byte, error) {
+			return []byte(clientSecret), nil
+		}
+	}
+
+	return func(ctx context.Context) ([]byte, error) {
+		return fetchToken(ctx, cache, cacheKey, func(ctx context.Context) ([]byte, time.Time, error) {
+			secret, err := credential()
+			if err != nil {
+				return nil, time.Time{}, err
+			}
+
+			token, expiresAt, err := fetcher.GetExpiringToken(ctx, clientID, secret)
+			if err != nil {
+				return nil, time.Time{}, fmt.Errorf("failed to get authentication token from Cyberark ISPSS service: %w", err)
+			}
+			return token, expiresAt, nil
+		})
+	}, nil
+}
+
+// newPVWAFetcher returns the TokenFetcher of PVWA logons, which fetches the password from a vault
+// with the ccp and conjur methods of the pvwa_auth block.
+func newPVWAFetcher(authAPI *cybrapi.PVWAAuthAPI, data secretsHubProviderModel) (cybrapi.TokenFetcher, error) {
+	switch authMethod(data.PVWAAuth, authMethodPassword) {
+	case authMethodCCP, authMethodConjur:
+		source, err := newSecretSource(data.PVWAAuth)
+		if err != nil {
+			return nil, err
+		}
+		return &cybrapi.SourcedTokenFetcher{Fetcher: authAPI, Source: source}, nil
+	default:
+		return authAPI, nil
+	}
+}
+
+// newSecretSource returns the secret source of the ccp or conjur method.
+func newSecretSource(auth *providerAuthModel) (cybrapi.SecretSource, error) {
+	if auth.Method.ValueString() == authMethodConjur {
+		var authenticate cybrapi.ConjurAuthenticator
+		if auth.ConjurServiceID.ValueString() != "" {
+			jwtFile := auth.JWTFile.ValueString()
+			jwtEnv := auth.JWTEnv.ValueString()
+			authenticate = cybrapi.ConjurJWTAuthenticator(auth.ConjurServiceID.ValueString(), func() ([]byte, error) {
+				return readJWT(jwtFile, jwtEnv)
+			})
+		} else {
+			authenticate = cybrapi.ConjurAPIKeyAuthenticator(auth.ConjurLogin.ValueString(), []byte(auth.ConjurAPIKey.ValueString()))
+		}
+
+		return cybrapi.NewConjurSecretSource(auth.ConjurURL.ValueString(), auth.ConjurAccount.ValueString(),
+			auth.ConjurVariable.ValueString(), authenticate), nil
+	}
+
+	tlsConfig, err := ccpTLSConfig(auth)
+	if err != nil {
+		return nil, err
+	}
+
+	return cybrapi.NewCCPSecretSource(auth.CCPURL.ValueString(), auth.AppID.ValueString(), auth.Safe.ValueString(),
+		auth.Object.ValueString(), tlsConfig), nil
+}
+
+// ccpTLSConfig returns the TLS configuration of the Central Credential Provider, or nil if neither
+// a client certificate nor a CA certificate is configured.
+func ccpTLSConfig(auth *providerAuthModel) (*tls.Config, error) {
+	if auth.ClientCertFile.ValueString() == "" && auth.CACertFile.ValueString() == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if auth.ClientCertFile.ValueString() != "" {
+		certificate, err := tls.LoadX509KeyPair(auth.ClientCertFile.ValueString(), auth.ClientKeyFile.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load CCP client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	if auth.CACertFile.ValueString() != "" {
+		caCert, err := os.ReadFile(auth.CACertFile.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CCP CA certificate: %w", err)
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("failed to parse CCP CA certificate %s", auth.CACertFile.ValueString())
+		}
+	}
+
+	return tlsConfig, nil
+}
+
+// readJWT reads a JWT from a file, or from an environment variable if no file is given.
+func readJWT(file, env string) ([]byte, error) {
+	if file != "" {
+		jwt, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWT: %w", err)
+		}
+		return jwt, nil
+	}
+
+	jwt := os.Getenv(env)
+	if jwt == "" {
+		return nil, fmt.Errorf("failed to read JWT: environment variable %s is not set", env)
+	}
+	return []byte(jwt), nil
+}
@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAuth(t *testing.T) {
+	tests := map[string]struct {
+		data    secretsHubProviderModel
+		wantErr bool
+	}{
+		"ClientSecret": {
+			data: secretsHubProviderModel{ClientSecret: types.StringValue("secret")},
+		},
+		"MissingClientSecret": {
+			data:    secretsHubProviderModel{ClientSecret: types.StringNull()},
+			wantErr: true,
+		},
+		"OIDCFile": {
+			data: secretsHubProviderModel{ClientSecret: types.StringNull(), Auth: &providerAuthModel{
+				Method: types.StringValue(authMethodOIDC), JWTFile: types.StringValue("/tmp/token"), JWTEnv: types.StringNull()}},
+		},
+		"OIDCNoSource": {
+			data: secretsHubProviderModel{ClientSecret: types.StringNull(), Auth: &providerAuthModel{
+				Method: types.StringValue(authMethodOIDC), JWTFile: types.StringNull(), JWTEnv: types.StringNull()}},
+			wantErr: true,
+		},
+		"OIDCBothSources": {
+			data: secretsHubProviderModel{ClientSecret: types.StringNull(), Auth: &providerAuthModel{
+				Method: types.StringValue(authMethodOIDC), JWTFile: types.StringValue("/tmp/token"), JWTEnv: types.StringValue("TOKEN")}},
+			wantErr: true,
+		},
+		"CCP": {
+			data: secretsHubProviderModel{ClientSecret: types.StringNull(), Auth: &providerAuthModel{
+				Method: types.StringValue(authMethodCCP), CCPURL: types.StringValue("https://ccp.example.com"),
+				AppID: types.StringValue("Terraform"), Safe: types.StringValue("Automation"), Object: types.StringValue("ispss"),
+				ClientCertFile: types.StringValue("cert.pem"), ClientKeyFile: types.StringValue("key.pem")}},
+		},
+		"CCPMissingObject": {
+			data: secretsHubProviderModel{ClientSecret: types.StringNull(), Auth: &providerAuthModel{
+				Method: types.StringValue(authMethodCCP), CCPURL: types.StringValue("https://ccp.example.com"),
+				AppID: types.StringValue("Terraform"), Safe: types.StringValue("Automation")}},
+			wantErr: true,
+		},
+		"CCPCertificateWithoutKey": {
+			data: secretsHubProviderModel{ClientSecret: types.StringNull(), Auth: &providerAuthModel{
+				Method: types.StringValue(authMethodCCP), CCPURL: types.StringValue("https://ccp.example.com"),
+				AppID: types.StringValue("Terraform"), Safe: types.StringValue("Automation"), Object: types.StringValue("ispss"),
+				ClientCertFile: types.StringValue("cert.pem")}},
+			wantErr: true,
+		},
+		"ConjurAPIKey": {
+			data: secretsHubProviderModel{ClientSecret: types.StringNull(), Auth: &providerAuthModel{
+				Method: types.StringValue(authMethodConjur), ConjurURL: types.StringValue("https://conjur.example.com"),
+				ConjurAccount: types.StringValue("myorg"), ConjurVariable: types.StringValue("terraform/secret"),
+				ConjurLogin: types.StringValue("host/terraform"), ConjurAPIKey: types.StringValue("api-key")}},
+		},
+		"ConjurMissingAPIKey": {
+			data: secretsHubProviderModel{ClientSecret: types.StringNull(), Auth: &providerAuthModel{
+				Method: types.StringValue(authMethodConjur), ConjurURL: types.StringValue("https://conjur.example.com"),
+				ConjurAccount: types.StringValue("myorg"), ConjurVariable: types.StringValue("terraform/secret"),
+				ConjurLogin: types.StringValue("host/terraform")}},
+			wantErr: true,
+		},
+		"ConjurJWT": {
+			data: secretsHubProviderModel{ClientSecret: types.StringNull(), Auth: &providerAuthModel{
+				Method: types.StringValue(authMethodConjur), ConjurURL: types.StringValue("https://conjur.example.com"),
+				ConjurAccount: types.StringValue("myorg"), ConjurVariable: types.StringValue("terraform/secret"),
+				ConjurServiceID: types.StringValue("github"), JWTEnv: types.StringValue("ACTIONS_ID_TOKEN")}},
+		},
+		"PVWAConjurMissingVariable": {
+			data: secretsHubProviderModel{ClientSecret: types.StringValue("secret"), PVWAAuth: &providerAuthModel{
+				Method: types.StringValue(authMethodConjur), ConjurURL: types.StringValue("https://conjur.example.com"),
+				ConjurAccount: types.StringValue("myorg"), ConjurLogin: types.StringValue("host/terraform"),
+				ConjurAPIKey: types.StringValue("api-key")}},
+			wantErr: true,
+		},
+		"PVWAOIDC": {
+			data: secretsHubProviderModel{ClientSecret: types.StringValue("secret"), PVWAAuth: &providerAuthModel{
+				Method: types.StringValue(authMethodOIDC)}},
+			wantErr: true,
+		},
+		"InvalidMethod": {
+			data: secretsHubProviderModel{ClientSecret: types.StringValue("secret"), Auth: &providerAuthModel{
+				Method: types.StringValue("saml")}},
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := diag.Diagnostics{}
+			validateAuth(test.data, &diags)
+			assert.Equal(t, test.wantErr, diags.HasError(), diags)
+		})
+	}
+}
+
+func TestNewCloudLoginOIDC(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub": "organization:example:workspace:infra"}`))
+	jwt := "eyJhbGciOiJSUzI1NiJ9." + payload + ".c2lnbmF0dXJl"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "ci@cyberark.cloud.1234", r.PostForm.Get("client_id"))
+		assert.Equal(t, jwt, r.PostForm.Get("client_assertion"))
+		_, _ = w.Write([]byte(`{"access_token": "platform-token"}`))
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte(jwt+"\n"), 0o600))
+	t.Setenv("TEST_OIDC_TOKEN", jwt)
+
+	for name, auth := range map[string]*providerAuthModel{
+		"File": {Method: types.StringValue(authMethodOIDC), JWTFile: types.StringValue(tokenFile)},
+		"Env":  {Method: types.StringValue(authMethodOIDC), JWTEnv: types.StringValue("TEST_OIDC_TOKEN")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			login, err := newCloudLogin(server.URL, secretsHubProviderModel{
+				ClientID: types.StringValue("ci@cyberark.cloud.1234"),
+				Auth:     auth,
+			}, nil)
+			require.NoError(t, err)
+
+			token, err := login(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, "platform-token", string(token))
+		})
+	}
+
+	t.Run("MissingEnv", func(t *testing.T) {
+		login, err := newCloudLogin(server.URL, secretsHubProviderModel{
+			ClientID: types.StringValue("ci@cyberark.cloud.1234"),
+			Auth:     &providerAuthModel{Method: types.StringValue(authMethodOIDC), JWTEnv: types.StringValue("TEST_OIDC_UNSET")},
+		}, nil)
+		require.NoError(t, err)
+
+		_, err = login(context.Background())
+		assert.EqualError(t, err, "failed to read JWT: environment variable TEST_OIDC_UNSET is not set")
+	})
+}
+
+func TestNewCloudLoginCCP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/AIMWebService/api/Accounts":
+			assert.Equal(t, "ispss-client", r.URL.Query().Get("Object"))
+			_, _ = w.Write([]byte(`{"Content": "client-secret"}`))
+		case "/oauth2/platformtoken":
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "client-secret", r.PostForm.Get("client_secret"))
+			_, _ = w.Write([]byte(`{"access_token": "platform-token"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	login, err := newCloudLogin(server.URL, secretsHubProviderModel{
+		ClientID:     types.StringValue("automation@cyberark.cloud.1234"),
+		ClientSecret: types.StringNull(),
+		Auth: &providerAuthModel{
+			Method: types.StringValue(authMethodCCP), CCPURL: types.StringValue(server.URL),
+			AppID: types.StringValue("Terraform"), Safe: types.StringValue("Automation"), Object: types.StringValue("ispss-client"),
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	token, err := login(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "platform-token", string(token))
+}
+
+func TestNewPVWAFetcherConjur(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/authn/myorg/host%2Fterraform/authenticate":
+			_, _ = w.Write([]byte("conjur-token"))
+		case "/secrets/myorg/variable/pvwa%2Fpassword":
+			_, _ = w.Write([]byte(`pa"ss`))
+		case "/PasswordVault/API/auth/cyberark/Logon/":
+			var request cybrapi.PVWALogonRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Equal(t, `pa"ss`, request.Password)
+			_, _ = w.Write([]byte(`"session-token"`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	fetcher, err := newPVWAFetcher(cybrapi.NewPVWAAuthAPI(server.URL, "cyberark"), secretsHubProviderModel{
+		PVWAAuth: &providerAuthModel{
+			Method: types.StringValue(authMethodConjur), ConjurURL: types.StringValue(server.URL),
+			ConjurAccount: types.StringValue("myorg"), ConjurVariable: types.StringValue("pvwa/password"),
+			ConjurLogin: types.StringValue("host/terraform"), ConjurAPIKey: types.StringValue("api-key"),
+		},
+	})
+	require.NoError(t, err)
+
+	token, err := fetcher.GetToken(context.Background(), "admin", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "session-token", string(token))
+}
+
+func TestCCPTLSConfig(t *testing.T) {
+	tlsConfig, err := ccpTLSConfig(&providerAuthModel{})
+	assert.NoError(t, err)
+	assert.Nil(t, tlsConfig)
+
+	_, err = ccpTLSConfig(&providerAuthModel{
+		ClientCertFile: types.StringValue(filepath.Join(t.TempDir(), "missing.pem")),
+		ClientKeyFile:  types.StringValue(filepath.Join(t.TempDir(), "missing.key")),
+	})
+	assert.ErrorContains(t, err, "failed to load CCP client certificate")
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, []byte("not a certificate"), 0o600))
+	_, err = ccpTLSConfig(&providerAuthModel{CACertFile: types.StringValue(caFile)})
+	assert.ErrorContains(t, err, "failed to parse CCP CA certificate")
+}
@@ -0,0 +1,247 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// reservedBackendNames can not be used as backend block names because they would be ambiguous
+// in backend attributes or import IDs.
+var reservedBackendNames = []string{backendPrivilegeCloud, backendSelfHosted, "name"}
+
+// backendNamePattern matches valid backend block names.
+var backendNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
+// backendBlockModel describes an additional named vault the provider can manage objects in.
+type backendBlockModel struct {
+	Name types.String      `tfsdk:"name"`
+	Type types.String      `tfsdk:"type"`
+	URL  types.String      `tfsdk:"url"`
+	Auth *backendAuthModel `tfsdk:"auth"`
+}
+
+// backendAuthModel describes the credentials of a named backend. Unset values default to the
+// provider level credentials of the same kind.
+type backendAuthModel struct {
+	Tenant       types.String `tfsdk:"tenant"`
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	Username     types.String `tfsdk:"username"`
+	Password     types.String `tfsdk:"password"`
+	LoginMethod  types.String `tfsdk:"login_method"`
+}
+
+// backendBlock returns the schema of the provider backend blocks.
+func backendBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		Description: "Additional named Privilege Cloud or PAM Self-Hosted vaults. Resources select one with their " +
+			"`backend` attribute. Backends only log in when a resource first uses them.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Description: "Name resources use to select the backend.",
+					Required:    true,
+				},
+				"type": schema.StringAttribute{
+					Description: "Type of vault: `privilege_cloud` or `self_hosted`.",
+					Required:    true,
+				},
+				"url": schema.StringAttribute{
+					Description: "Privilege Cloud URL (https://<domain>.privilegecloud.cyberark.cloud) or PVWA URL.",
+					Required:    true,
+				},
+			},
+			Blocks: map[string]schema.Block{
+				"auth": schema.SingleNestedBlock{
+					Description: "Credentials of the backend. Unset values default to the provider level credentials " +
+						"(`tenant`, `client_id` and `client_secret` for Privilege Cloud, `pvwa_username`, `pvwa_password` and " +
+						"`pvwa_login_method` for PAM Self-Hosted). Backends log in with a client secret or password only, so " +
+						"`client_secret` or `password` must be set when the provider credentials use another method of the " +
+						"`auth` or `pvwa_auth` block, and the `radius` login method is not supported.",
+					Attributes: map[string]schema.Attribute{
+						"tenant": schema.StringAttribute{
+							Description: "CyberArk Shared Services Tenant of a Privilege Cloud backend.",
+							Optional:    true,
+						},
+						"client_id": schema.StringAttribute{
+							Description: "Client ID of a Privilege Cloud backend.",
+							Optional:    true,
+						},
+						"client_secret": schema.StringAttribute{
+							Description: "Client secret of a Privilege Cloud backend.",
+							Optional:    true,
+							Sensitive:   true,
+						},
+						"username": schema.StringAttribute{
+							Description: "PVWA username of a PAM Self-Hosted backend.",
+							Optional:    true,
+						},
+						"password": schema.StringAttribute{
+							Description: "PVWA password of a PAM Self-Hosted backend.",
+							Optional:    true,
+							Sensitive:   true,
+						},
+						"login_method": schema.StringAttribute{
+							Description: "PVWA login method of a PAM Self-Hosted backend.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// validateBackendBlocks checks backend names, types and login methods. Backends log in with a
+// client secret or PVWA password, so they must set their own when the provider level credentials
+// are fetched with the methods of the auth or pvwa_auth blocks, and cannot use RADIUS logons.
+func validateBackendBlocks(data secretsHubProviderModel, diags *diag.Diagnostics) {
+	seen := map[string]bool{}
+
+	for i, backend := range data.Backends {
+		blockPath := path.Root("backend").AtListIndex(i)
+
+		if !backend.Name.IsUnknown() {
+			name := backend.Name.ValueString()
+			switch {
+			case slices.Contains(reservedBackendNames, name):
+				diags.AddAttributeError(blockPath.AtName("name"), "Invalid Backend Name",
+					fmt.Sprintf("Backend name %q is reserved", name))
+			case !backendNamePattern.MatchString(name):
+				diags.AddAttributeError(blockPath.AtName("name"), "Invalid Backend Name",
+					fmt.Sprintf("Backend name %q must start with a letter and contain only letters, digits, '_' and '-'", name))
+			case seen[name]:
+				diags.AddAttributeError(blockPath.AtName("name"), "Duplicate Backend Name",
+					fmt.Sprintf("Backend name %q is used more than once", name))
+			}
+			seen[name] = true
+		}
+
+		if !backend.Type.IsUnknown() {
+			switch backend.Type.ValueString() {
+			case backendPrivilegeCloud, backendSelfHosted:
+				// valid options
+			default:
+				diags.AddAttributeError(blockPath.AtName("type"), "Invalid Backend Type",
+					fmt.Sprintf("Backend type must be either '%s' or '%s', got: %s", backendPrivilegeCloud, backendSelfHosted, backend.Type.ValueString()))
+			}
+		}
+
+		if backend.Auth != nil && backend.Auth.LoginMethod.ValueString() != "" &&
+			!slices.Contains(validPVWALoginMethods, backend.Auth.LoginMethod.ValueString()) {
+			diags.AddAttributeError(blockPath.AtName("auth").AtName("login_method"), "Invalid PVWA Login Method",
+				fmt.Sprintf("Invalid PVWA Login Method: %s. Valid methods are: %v", backend.Auth.LoginMethod.ValueString(), validPVWALoginMethods))
+		}
+
+		auth := backend.Auth
+		if auth == nil {
+			auth = &backendAuthModel{}
+		}
+		authPath := blockPath.AtName("auth")
+		name := backend.Name.ValueString()
+
+		switch backend.Type.ValueString() {
+		case backendPrivilegeCloud:
+			if method := authMethod(data.Auth, authMethodClientSecret); method != authMethodClientSecret && !isSet(auth.ClientSecret) {
+				diags.AddAttributeError(authPath.AtName("client_secret"), "Missing Backend Client Secret",
+					fmt.Sprintf("Backend %q would use the provider credentials, which are fetched with the %s method of the "+
+						"auth block. Backends only log in with a client secret, so set client_secret in the auth block of "+
+						"the backend.", name, method))
+			}
+		case backendSelfHosted:
+			if method := authMethod(data.PVWAAuth, authMethodPassword); method != authMethodPassword && !isSet(auth.Password) {
+				diags.AddAttributeError(authPath.AtName("password"), "Missing Backend Password",
+					fmt.Sprintf("Backend %q would use the provider PVWA credentials, whose password is fetched with the %s "+
+						"method of the pvwa_auth block. Backends only log in with a password, so set password in the auth "+
+						"block of the backend.", name, method))
+			}
+
+			if !auth.LoginMethod.IsUnknown() && stringOrDefault(auth.LoginMethod, data.PVWALoginMethod) == "radius" {
+				diags.AddAttributeError(authPath.AtName("login_method"), "Unsupported Backend Login Method",
+					fmt.Sprintf("Backend %q would log in with the radius method, but RADIUS one-time passwords are only "+
+						"sent to the provider level PVWA. Set another login_method in the auth block of the backend.", name))
+			}
+		}
+	}
+}
+
+// isSet reports whether s is configured, either to a non-empty value or to a value that is not
+// known yet.
+func isSet(s types.String) bool {
+	return s.IsUnknown() || s.ValueString() != ""
+}
+
+// newNamedBackends creates the clients of the named backends. Logins are deferred until a
+// backend is first used, and PVWA sessions are added to sessions to be logged off on shutdown.
+func newNamedBackends(data secretsHubProviderModel, sessions *sessionRegistry, cache *cybrapi.TokenCache, opts ...cybrapi.ClientOption) map[string]cybrapi.PAMAPI {
+	backends := map[string]cybrapi.PAMAPI{}
+
+	for _, backend := range data.Backends {
+		auth := backend.Auth
+		if auth == nil {
+			auth = &backendAuthModel{}
+		}
+
+		switch backend.Type.ValueString() {
+		case backendSelfHosted:
+			username := stringOrDefault(auth.Username, data.PVWAUsername)
+			password := stringOrDefault(auth.Password, data.PVWAPassword)
+			loginMethod := stringOrDefault(auth.LoginMethod, data.PVWALoginMethod)
+			if loginMethod == "" {
+				loginMethod = "cyberark"
+			}
+
+			authAPI := cybrapi.NewPVWAAuthAPI(backend.URL.ValueString(), loginMethod)
+			name := backend.Name.ValueString()
+			session := cybrapi.NewSession(func(ctx context.Context) ([]byte, error) {
+				token, err := authAPI.GetToken(ctx, username, []byte(password))
+				if err != nil {
+					return nil, fmt.Errorf("failed to get authentication token for backend %s: %w", name, err)
+				}
+				return token, nil
+			}, authAPI.Logoff)
+			sessions.add(session)
+
+			backends[name] = cybrapi.NewPAMAPIWithTokenSource(backend.URL.ValueString(), session.Token, false, opts...)
+		default:
+			tenant := stringOrDefault(auth.Tenant, data.Tenant)
+			clientID := stringOrDefault(auth.ClientID, data.ClientID)
+			clientSecret := stringOrDefault(auth.ClientSecret, data.ClientSecret)
+
+			authAPI := cybrapi.NewIdentityAuthAPI(fmt.Sprintf(cloudAuthURL, tenant))
+			name := backend.Name.ValueString()
+			cacheKey := tokenCacheKey(tenant, clientID)
+			login := func(ctx context.Context) ([]byte, error) {
+				return fetchToken(ctx, cache, cacheKey, func(ctx context.Context) ([]byte, time.Time, error) {
+					token, expiresAt, err := authAPI.GetExpiringToken(ctx, clientID, []byte(clientSecret))
+					if err != nil {
+						return nil, time.Time{}, fmt.Errorf("failed to get authentication token for backend %s: %w", name, err)
+					}
+					return token, expiresAt, nil
+				})
+			}
+			backends[backend.Name.ValueString()] = cybrapi.NewPAMAPIWithTokenSource(backend.URL.ValueString(), cybrapi.NewLazyTokenSource(login), true, opts...)
+		}
+	}
+
+	return backends
+}
+
+// stringOrDefault returns the value of s, or of fallback when s is not set.
+func stringOrDefault(s, fallback types.String) string {
+	if s.ValueString() != "" {
+		return s.ValueString()
+	}
+	return fallback.ValueString()
+}
@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBackendBlocks(t *testing.T) {
+	oidc := &providerAuthModel{Method: types.StringValue(authMethodOIDC)}
+	ccp := &providerAuthModel{Method: types.StringValue(authMethodCCP)}
+
+	tests := map[string]struct {
+		provider secretsHubProviderModel
+		backends []backendBlockModel
+		wantErr  bool
+	}{
+		"Valid": {
+			backends: []backendBlockModel{
+				{Name: types.StringValue("prod"), Type: types.StringValue(backendPrivilegeCloud)},
+				{Name: types.StringValue("dr"), Type: types.StringValue(backendSelfHosted),
+					Auth: &backendAuthModel{LoginMethod: types.StringValue("ldap")}},
+			},
+		},
+		"Reserved": {
+			backends: []backendBlockModel{{Name: types.StringValue(backendSelfHosted), Type: types.StringValue(backendSelfHosted)}},
+			wantErr:  true,
+		},
+		"InvalidName": {
+			backends: []backendBlockModel{{Name: types.StringValue("dr site"), Type: types.StringValue(backendSelfHosted)}},
+			wantErr:  true,
+		},
+		"Duplicate": {
+			backends: []backendBlockModel{
+				{Name: types.StringValue("dr"), Type: types.StringValue(backendSelfHosted)},
+				{Name: types.StringValue("dr"), Type: types.StringValue(backendPrivilegeCloud)},
+			},
+			wantErr: true,
+		},
+		"InvalidType": {
+			backends: []backendBlockModel{{Name: types.StringValue("dr"), Type: types.StringValue("conjur")}},
+			wantErr:  true,
+		},
+		"InvalidLoginMethod": {
+			backends: []backendBlockModel{{Name: types.StringValue("dr"), Type: types.StringValue(backendSelfHosted),
+				Auth: &backendAuthModel{LoginMethod: types.StringValue("saml")}}},
+			wantErr: true,
+		},
+		"InheritedOIDCCredentials": {
+			provider: secretsHubProviderModel{Auth: oidc},
+			backends: []backendBlockModel{{Name: types.StringValue("prod"), Type: types.StringValue(backendPrivilegeCloud),
+				Auth: &backendAuthModel{ClientID: types.StringValue("automation@cyberark.cloud.prod")}}},
+			wantErr: true,
+		},
+		"OwnClientSecret": {
+			provider: secretsHubProviderModel{Auth: oidc},
+			backends: []backendBlockModel{{Name: types.StringValue("prod"), Type: types.StringValue(backendPrivilegeCloud),
+				Auth: &backendAuthModel{ClientSecret: types.StringUnknown()}}},
+		},
+		"InheritedCCPPassword": {
+			provider: secretsHubProviderModel{PVWAAuth: ccp},
+			backends: []backendBlockModel{{Name: types.StringValue("dr"), Type: types.StringValue(backendSelfHosted)}},
+			wantErr:  true,
+		},
+		"OwnPassword": {
+			// The pvwa_auth block only applies to the provider level PVWA
+			provider: secretsHubProviderModel{Auth: oidc, PVWAAuth: ccp},
+			backends: []backendBlockModel{{Name: types.StringValue("dr"), Type: types.StringValue(backendSelfHosted),
+				Auth: &backendAuthModel{Password: types.StringValue("p@ss")}}},
+		},
+		"RADIUSLoginMethod": {
+			backends: []backendBlockModel{{Name: types.StringValue("dr"), Type: types.StringValue(backendSelfHosted),
+				Auth: &backendAuthModel{LoginMethod: types.StringValue("radius")}}},
+			wantErr: true,
+		},
+		"InheritedRADIUSLoginMethod": {
+			provider: secretsHubProviderModel{PVWALoginMethod: types.StringValue("radius")},
+			backends: []backendBlockModel{{Name: types.StringValue("dr"), Type: types.StringValue(backendSelfHosted)}},
+			wantErr:  true,
+		},
+		"OwnLoginMethod": {
+			provider: secretsHubProviderModel{PVWALoginMethod: types.StringValue("radius")},
+			backends: []backendBlockModel{{Name: types.StringValue("dr"), Type: types.StringValue(backendSelfHosted),
+				Auth: &backendAuthModel{LoginMethod: types.StringValue("ldap")}}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := diag.Diagnostics{}
+			test.provider.Backends = test.backends
+			validateBackendBlocks(test.provider, &diags)
+			assert.Equal(t, test.wantErr, diags.HasError(), diags)
+		})
+	}
+}
+
+func TestNewNamedBackendsLogsInLazily(t *testing.T) {
+	var logons, logoffs atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/PasswordVault/API/auth/ldap/Logon/":
+			logons.Add(1)
+			_, _ = w.Write([]byte(`"session-token"`))
+		case "/PasswordVault/API/Auth/Logoff":
+			logoffs.Add(1)
+		case "/PasswordVault/API/Accounts/12_3":
+			assert.Equal(t, "session-token", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"id": "12_3", "name": "account"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	sessions := &sessionRegistry{}
+	backends := newNamedBackends(secretsHubProviderModel{
+		PVWAUsername:    types.StringValue("provider-user"),
+		PVWAPassword:    types.StringValue("provider-password"),
+		PVWALoginMethod: types.StringValue("ldap"),
+		Backends: []backendBlockModel{{
+			Name: types.StringValue("dr"),
+			Type: types.StringValue(backendSelfHosted),
+			URL:  types.StringValue(server.URL),
+		}},
+	}, sessions, nil)
+	require.Contains(t, backends, "dr")
+	assert.Equal(t, int32(0), logons.Load())
+
+	for range 2 {
+		account, err := backends["dr"].GetAccount(context.Background(), "12_3")
+		require.NoError(t, err)
+		assert.Equal(t, "account", *account.Name)
+	}
+	assert.Equal(t, int32(1), logons.Load())
+
+	require.NoError(t, sessions.closeAll(context.Background()))
+	assert.Equal(t, int32(1), logoffs.Load())
+}
+
+func TestSessionRegistryCloseAllLogsOffConcurrently(t *testing.T) {
+	sessions := &sessionRegistry{}
+	var started sync.WaitGroup
+	started.Add(2)
+
+	for _, token := range []string{"first", "second"} {
+		// Each logoff waits for the other one, which only succeeds if they run concurrently
+		session := cybrapi.NewSession(
+			func(context.Context) ([]byte, error) { return []byte(token), nil },
+			func(ctx context.Context, _ []byte) error {
+				started.Done()
+				done := make(chan struct{})
+				go func() {
+					started.Wait()
+					close(done)
+				}()
+				select {
+				case <-done:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			},
+		)
+		_, err := session.Token(context.Background())
+		require.NoError(t, err)
+		sessions.add(session)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, sessions.closeAll(ctx))
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureSessionLifecycle(t *testing.T) {
+	ctx := context.Background()
+
+	var logons, logoffs atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/PasswordVault/API/auth/cyberark/Logon/":
+			logons.Add(1)
+			_, _ = w.Write([]byte(`"session-token"`))
+		case "/PasswordVault/API/Auth/Logoff":
+			logoffs.Add(1)
+		case "/PasswordVault/API/Accounts/12_3":
+			_, _ = w.Write([]byte(`{"id": "12_3", "name": "account"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	factory, shutdown := NewWithShutdown("test")
+	p := factory()
+	schemaResp := &fwprovider.SchemaResponse{}
+	p.Schema(ctx, fwprovider.SchemaRequest{}, schemaResp)
+
+	configType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(configType, map[string]tftypes.Value{
+			// The tenant does not resolve, so a login during Configure would fail
+			"tenant":                   tftypes.NewValue(tftypes.String, "invalid.localhost"),
+			"domain":                   tftypes.NewValue(tftypes.String, "invalid.localhost"),
+			"client_id":                tftypes.NewValue(tftypes.String, "automation@cyberark.cloud.invalid"),
+			"client_secret":            tftypes.NewValue(tftypes.String, "secret"),
+			"pvwa_url":                 tftypes.NewValue(tftypes.String, server.URL),
+			"pvwa_username":            tftypes.NewValue(tftypes.String, "user"),
+			"pvwa_password":            tftypes.NewValue(tftypes.String, "password"),
+			"pvwa_login_method":        tftypes.NewValue(tftypes.String, nil),
+			"pvwa_radius_otp":          tftypes.NewValue(tftypes.String, nil),
+			"pvwa_radius_mode":         tftypes.NewValue(tftypes.String, nil),
+			"pvwa_radius_push_timeout": tftypes.NewValue(tftypes.Number, nil),
+			"token_cache":              tftypes.NewValue(tftypes.Bool, nil),
+			"validate_platforms":       tftypes.NewValue(tftypes.Bool, nil),
+			"log_redacted_keys":        tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+			"backend":                  tftypes.NewValue(configType.AttributeTypes["backend"], nil),
+			"auth":                     tftypes.NewValue(configType.AttributeTypes["auth"], nil),
+			"pvwa_auth":                tftypes.NewValue(configType.AttributeTypes["pvwa_auth"], nil),
+		}),
+	}
+
+	resp := &fwprovider.ConfigureResponse{}
+	p.Configure(ctx, fwprovider.ConfigureRequest{Config: config}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, int32(0), logons.Load())
+
+	api, ok := resp.ResourceData.(*cybrapi.API)
+	require.True(t, ok)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := api.PVWAAPI.GetAccount(ctx, "12_3")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), logons.Load())
+	assert.Equal(t, int32(0), logoffs.Load())
+
+	require.NoError(t, shutdown(ctx))
+	assert.Equal(t, int32(1), logons.Load())
+	assert.Equal(t, int32(1), logoffs.Load())
+}
+
+func TestRadiusOptions(t *testing.T) {
+	t.Setenv(radiusOTPEnv, "123456")
+
+	options := radiusOptions(secretsHubProviderModel{PVWARadiusPush: types.Int64Value(30)})
+	assert.Equal(t, "123456", options.OTP)
+	assert.Equal(t, 30*time.Second, options.PushTimeout)
+
+	options = radiusOptions(secretsHubProviderModel{
+		PVWARadiusOTP:  types.StringValue(cybrapi.RADIUSPush),
+		PVWARadiusMode: types.StringValue(cybrapi.RADIUSModeAppend),
+	})
+	assert.Equal(t, cybrapi.RADIUSPush, options.OTP)
+	assert.Equal(t, cybrapi.RADIUSModeAppend, options.Mode)
+	assert.Zero(t, options.PushTimeout)
+}
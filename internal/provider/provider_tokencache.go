@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// tokenCacheEnv is the environment variable that enables the token cache when token_cache is
+// not set.
+const tokenCacheEnv = "CYBERARK_TOKEN_CACHE"
+
+// tokenCacheEnabled reports whether the provider configuration enables the on-disk token cache.
+// token_cache takes precedence over the CYBERARK_TOKEN_CACHE environment variable.
+func tokenCacheEnabled(data secretsHubProviderModel) bool {
+	if !data.TokenCache.IsNull() && !data.TokenCache.IsUnknown() {
+		return data.TokenCache.ValueBool()
+	}
+
+	enabled, err := strconv.ParseBool(os.Getenv(tokenCacheEnv))
+	return err == nil && enabled
+}
+
+// newTokenCache returns the token cache of the provider configuration, or nil when it is disabled.
+// A cache directory that can not be used disables the cache with a warning, as tokens can still be
+// fetched without it.
+func newTokenCache(data secretsHubProviderModel, diags *diag.Diagnostics) *cybrapi.TokenCache {
+	if !tokenCacheEnabled(data) {
+		return nil
+	}
+
+	dir, err := cybrapi.DefaultTokenCacheDir()
+	if err != nil {
+		diags.AddAttributeError(path.Root("token_cache"), "Invalid Token Cache Configuration", err.Error())
+		return nil
+	}
+
+	cache := cybrapi.NewTokenCache(dir)
+	if err := cache.Check(); err != nil {
+		diags.AddAttributeWarning(path.Root("token_cache"), "Token Cache Disabled",
+			fmt.Sprintf("Tokens are not cached because the cache directory %s can not be used: %s. The directory "+
+				"must be owned by the current user and have mode 0700.", dir, err))
+		return nil
+	}
+	return cache
+}
+
+// tokenCacheKey returns the key platform tokens of a client are cached under.
+func tokenCacheKey(tenant, clientID string) string {
+	return tenant + "/" + clientID
+}
+
+// fetchToken returns a token from cache, calling fetch when there is no valid cached token. fetch
+// is always called when cache is nil.
+func fetchToken(ctx context.Context, cache *cybrapi.TokenCache, key string, fetch func(context.Context) ([]byte, time.Time, error)) ([]byte, error) {
+	if cache == nil {
+		token, _, err := fetch(ctx)
+		return token, err
+	}
+	return cache.Fetch(ctx, key, fetch)
+}
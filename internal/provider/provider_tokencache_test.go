@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenCacheEnabled(t *testing.T) {
+	for name, tc := range map[string]struct {
+		env      string
+		value    types.Bool
+		expected bool
+	}{
+		"Default":         {value: types.BoolNull(), expected: false},
+		"Enabled":         {value: types.BoolValue(true), expected: true},
+		"EnvEnabled":      {env: "true", value: types.BoolNull(), expected: true},
+		"EnvInvalid":      {env: "yes", value: types.BoolNull(), expected: false},
+		"DisabledOverEnv": {env: "1", value: types.BoolValue(false), expected: false},
+		"EnabledOverEnv":  {env: "false", value: types.BoolValue(true), expected: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(tokenCacheEnv, tc.env)
+			assert.Equal(t, tc.expected, tokenCacheEnabled(secretsHubProviderModel{TokenCache: tc.value}))
+		})
+	}
+}
+
+func TestNewTokenCache(t *testing.T) {
+	enabled := secretsHubProviderModel{TokenCache: types.BoolValue(true)}
+
+	t.Run("Disabled", func(t *testing.T) {
+		t.Setenv(tokenCacheEnv, "")
+		var diags diag.Diagnostics
+		assert.Nil(t, newTokenCache(secretsHubProviderModel{TokenCache: types.BoolNull()}, &diags))
+		assert.Empty(t, diags)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		t.Setenv("XDG_CACHE_HOME", filepath.Join(t.TempDir(), "cache"))
+		var diags diag.Diagnostics
+		assert.NotNil(t, newTokenCache(enabled, &diags))
+		assert.Empty(t, diags)
+	})
+
+	t.Run("SharedDirectory", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("Windows does not use mode bits")
+		}
+
+		cacheHome := filepath.Join(t.TempDir(), "cache")
+		dir := filepath.Join(cacheHome, "terraform-provider-cyberark", "tokens")
+		require.NoError(t, os.MkdirAll(dir, 0o700))
+		require.NoError(t, os.Chmod(dir, 0o755))
+		t.Setenv("XDG_CACHE_HOME", cacheHome)
+
+		var diags diag.Diagnostics
+		assert.Nil(t, newTokenCache(enabled, &diags))
+		require.Len(t, diags, 1)
+		assert.Equal(t, diag.SeverityWarning, diags[0].Severity())
+		assert.Contains(t, diags[0].Detail(), dir)
+		assert.Contains(t, diags[0].Detail(), "0700")
+	})
+}
+
+func TestNewCloudLoginTokenCache(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"access_token": "platform-token", "expires_in": 3600}`))
+	}))
+	defer server.Close()
+
+	cache := cybrapi.NewTokenCache(filepath.Join(t.TempDir(), "tokens"))
+	data := secretsHubProviderModel{
+		Tenant:       types.StringValue("abc1234"),
+		ClientID:     types.StringValue("automation@cyberark.cloud.1234"),
+		ClientSecret: types.StringValue("secret"),
+	}
+
+	// Each login stands for a separate Terraform run
+	for i := 0; i < 3; i++ {
+		login, err := newCloudLogin(server.URL, data, cache)
+		require.NoError(t, err)
+
+		token, err := login(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "platform-token", string(token))
+	}
+	assert.Equal(t, int32(1), requests.Load())
+
+	// Other clients of the tenant do not share the token
+	data.ClientID = types.StringValue("ci@cyberark.cloud.1234")
+	login, err := newCloudLogin(server.URL, data, cache)
+	require.NoError(t, err)
+
+	_, err = login(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+}
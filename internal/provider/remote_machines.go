@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// remoteMachinesAttribute returns the schema of the remote_machines attribute of the account resources.
+func remoteMachinesAttribute() schema.SetAttribute {
+	return schema.SetAttribute{
+		Description: "The addresses of the machines the account can be used to connect to through PSM. Removing the attribute clears the list.",
+		ElementType: types.StringType,
+		Optional:    true,
+	}
+}
+
+// accessRestrictedToRemoteMachinesAttribute returns the schema of the access_restricted_to_remote_machines
+// attribute of the account resources.
+func accessRestrictedToRemoteMachinesAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Whether the account can only be used to connect to the `remote_machines`. Removing the attribute sets it to `false`.",
+		Optional:    true,
+	}
+}
+
+// remoteAccessFromModel returns the remote machines access of an account, or nil if neither the
+// planned nor the prior state set it. Attributes removed from the configuration are cleared.
+func remoteAccessFromModel(ctx context.Context, machines types.Set, restricted types.Bool, priorMachines types.Set, priorRestricted types.Bool, diags *diag.Diagnostics) *cybrapi.RemoteMachinesAccess {
+	var access *cybrapi.RemoteMachinesAccess
+
+	if !machines.IsNull() || !priorMachines.IsNull() {
+		joined := strings.Join(setStrings(ctx, machines, diags), ";")
+		access = &cybrapi.RemoteMachinesAccess{RemoteMachines: &joined}
+	}
+
+	if !restricted.IsNull() || !priorRestricted.IsNull() {
+		if access == nil {
+			access = &cybrapi.RemoteMachinesAccess{}
+		}
+		access.AccessRestrictedToRemoteMachines = restricted.ValueBoolPointer()
+		if restricted.IsNull() {
+			access.AccessRestrictedToRemoteMachines = new(bool)
+		}
+	}
+
+	return access
+}
+
+// readRemoteAccess returns the remote machines access attributes of an account. Attributes that
+// are not set in the prior state are left unset, so only configured attributes detect drift.
+func readRemoteAccess(access *cybrapi.RemoteMachinesAccess, machines types.Set, restricted types.Bool) (types.Set, types.Bool) {
+	if access == nil {
+		access = &cybrapi.RemoteMachinesAccess{}
+	}
+
+	if !machines.IsNull() {
+		var values []string
+		if access.RemoteMachines != nil {
+			for _, machine := range strings.Split(*access.RemoteMachines, ";") {
+				if machine = strings.TrimSpace(machine); machine != "" {
+					values = append(values, machine)
+				}
+			}
+		}
+		machines = stringSet(values)
+	}
+
+	if !restricted.IsNull() {
+		restricted = types.BoolValue(access.AccessRestrictedToRemoteMachines != nil && *access.AccessRestrictedToRemoteMachines)
+	}
+
+	return machines, restricted
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteAccessFromModel(t *testing.T) {
+	ctx := context.Background()
+	nullSet, nullBool := types.SetNull(types.StringType), types.BoolNull()
+
+	t.Run("Unset", func(t *testing.T) {
+		var diags diag.Diagnostics
+		assert.Nil(t, remoteAccessFromModel(ctx, nullSet, nullBool, nullSet, nullBool, &diags))
+	})
+
+	t.Run("Set", func(t *testing.T) {
+		var diags diag.Diagnostics
+		access := remoteAccessFromModel(ctx, stringSet([]string{"srv2", "srv1"}), types.BoolValue(true), nullSet, nullBool, &diags)
+
+		require.False(t, diags.HasError(), diags)
+		require.NotNil(t, access)
+		assert.Equal(t, "srv1;srv2", *access.RemoteMachines)
+		assert.True(t, *access.AccessRestrictedToRemoteMachines)
+	})
+
+	t.Run("Removed", func(t *testing.T) {
+		var diags diag.Diagnostics
+		access := remoteAccessFromModel(ctx, nullSet, nullBool, stringSet([]string{"srv1"}), types.BoolValue(true), &diags)
+
+		require.NotNil(t, access)
+		assert.Equal(t, "", *access.RemoteMachines)
+		assert.False(t, *access.AccessRestrictedToRemoteMachines)
+	})
+
+	t.Run("InvalidSet", func(t *testing.T) {
+		// Accounts check the diagnostics before sending the access, which would clear the machines
+		var diags diag.Diagnostics
+		machines := types.SetValueMust(types.Int64Type, []attr.Value{types.Int64Value(1)})
+		remoteAccessFromModel(ctx, machines, nullBool, nullSet, nullBool, &diags)
+
+		assert.True(t, diags.HasError())
+	})
+}
+
+func TestReadRemoteAccess(t *testing.T) {
+	machines, restricted := "srv1; srv2;", true
+	access := &cybrapi.RemoteMachinesAccess{RemoteMachines: &machines, AccessRestrictedToRemoteMachines: &restricted}
+
+	t.Run("Configured", func(t *testing.T) {
+		set, restrictedValue := readRemoteAccess(access, stringSet([]string{"srv1"}), types.BoolValue(false))
+		assert.Equal(t, stringSet([]string{"srv1", "srv2"}), set)
+		assert.Equal(t, types.BoolValue(true), restrictedValue)
+	})
+
+	t.Run("NotConfigured", func(t *testing.T) {
+		set, restrictedValue := readRemoteAccess(access, types.SetNull(types.StringType), types.BoolNull())
+		assert.True(t, set.IsNull())
+		assert.True(t, restrictedValue.IsNull())
+	})
+
+	t.Run("Cleared", func(t *testing.T) {
+		set, restrictedValue := readRemoteAccess(nil, stringSet([]string{"srv1"}), types.BoolValue(true))
+		assert.Equal(t, stringSet(nil), set)
+		assert.Equal(t, types.BoolValue(false), restrictedValue)
+	})
+}
@@ -0,0 +1,249 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &accountGroupResource{}
+	_ resource.ResourceWithConfigure      = &accountGroupResource{}
+	_ resource.ResourceWithImportState    = &accountGroupResource{}
+	_ resource.ResourceWithValidateConfig = &accountGroupResource{}
+)
+
+// NewAccountGroupResource is a helper function to simplify the provider implementation.
+func NewAccountGroupResource() resource.Resource {
+	return &accountGroupResource{}
+}
+
+// accountGroupResource defines the resource implementation.
+type accountGroupResource struct {
+	api *cybrapi.API
+}
+
+// accountGroupModel describes the resource data model.
+type accountGroupModel struct {
+	Backend         types.String `tfsdk:"backend"`
+	ID              types.String `tfsdk:"id"`
+	GroupName       types.String `tfsdk:"group_name"`
+	GroupPlatformID types.String `tfsdk:"group_platform_id"`
+	Safe            types.String `tfsdk:"safe"`
+	LastUpdated     types.String `tfsdk:"last_updated"`
+}
+
+// Metadata returns the resource type name.
+func (r *accountGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_group"
+}
+
+// Schema returns the resource schema.
+func (r *accountGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `CyberArk Account Group Resource
+
+This resource adds an account group to a safe. The CPM changes the passwords of all accounts in the group together, according to the group platform of the group, e.g. for the nodes of a clustered database or an administrator account shared by several machines. Accounts are added to the group with ` + "`cyberark_account_group_member`" + ` or the ` + "`account_group_id`" + ` attribute of the account resources.
+
+Account groups cannot be updated or deleted with the API, so every change forces a new resource and destroying the resource only removes it from the Terraform state.
+
+For more information click [here](https://docs.cyberark.com/pam-self-hosted/latest/en/content/webservices/add-account-group.htm).`,
+		Attributes: map[string]schema.Attribute{
+			"backend": backendAttribute(backendPrivilegeCloud),
+			"id": schema.StringAttribute{
+				Description: "The ID of the account group.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+			"group_name": schema.StringAttribute{
+				Description: "The name of the account group. Changing it forces a new resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"group_platform_id": schema.StringAttribute{
+				Description: "The ID of the group platform the passwords of the group are managed with. Changing it forces a new resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"safe": schema.StringAttribute{
+				Description: "The name of the safe of the group. Only accounts in this safe can be added to the group. Changing it forces a new resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *accountGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(*cybrapi.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cybrapi.Api, got: %T. Please report this issue to the provider developers", req.ProviderData),
+		)
+		return
+	}
+
+	r.api = api
+}
+
+// ValidateConfig validates the resource configuration.
+func (r *accountGroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var backend types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("backend"), &backend)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateBackend(backend, &resp.Diagnostics)
+}
+
+// Create a new resource.
+func (r *accountGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data accountGroupModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := pam.AddAccountGroup(ctx, cybrapi.AccountGroup{
+		GroupName:       data.GroupName.ValueStringPointer(),
+		GroupPlatformID: data.GroupPlatformID.ValueStringPointer(),
+		Safe:            data.Safe.ValueStringPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating account group", err.Error())
+		return
+	}
+
+	data.Backend = backendOrDefault(data.Backend, backendPrivilegeCloud)
+	data.ID = types.StringPointerValue(group.GroupID)
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read the resource and set the Terraform state.
+func (r *accountGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data accountGroupModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groups, err := pam.ListAccountGroups(ctx, data.Safe.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading account groups", err.Error())
+		return
+	}
+
+	group := findAccountGroup(groups, data.ID.ValueString())
+	if group == nil {
+		// The group was removed outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.GroupName = types.StringPointerValue(group.GroupName)
+	data.GroupPlatformID = types.StringPointerValue(group.GroupPlatformID)
+	if group.Safe != nil {
+		data.Safe = types.StringPointerValue(group.Safe)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update sets the Terraform state. Every attribute forces a new resource, so there is nothing to update.
+func (r *accountGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state accountGroupModel
+
+	// Read Terraform plan data and current state into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = state.ID
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the Terraform state. The API cannot delete account groups.
+func (r *accountGroupResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	tflog.Info(ctx, "Account groups cannot be deleted with the API and are kept in the safe")
+}
+
+// ImportState imports an existing account group by "<safe>/<group_id>", optionally prefixed
+// with "<backend>:".
+func (r *accountGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	backend, id := splitImportBackend(r.api, req.ID, backendPrivilegeCloud)
+
+	safe, groupID, ok := strings.Cut(id, "/")
+	if !ok || safe == "" || groupID == "" {
+		resp.Diagnostics.AddError("Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form <safe>/<group_id>, got: %s", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), groupID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("backend"), backend)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("safe"), safe)...)
+}
+
+// findAccountGroup returns the account group with the given ID, or nil if there is none.
+func findAccountGroup(groups []*cybrapi.AccountGroup, groupID string) *cybrapi.AccountGroup {
+	for _, group := range groups {
+		if group.GroupID != nil && *group.GroupID == groupID {
+			return group
+		}
+	}
+	return nil
+}
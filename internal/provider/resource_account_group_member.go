@@ -0,0 +1,296 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &accountGroupMemberResource{}
+	_ resource.ResourceWithConfigure      = &accountGroupMemberResource{}
+	_ resource.ResourceWithImportState    = &accountGroupMemberResource{}
+	_ resource.ResourceWithValidateConfig = &accountGroupMemberResource{}
+)
+
+// NewAccountGroupMemberResource is a helper function to simplify the provider implementation.
+func NewAccountGroupMemberResource() resource.Resource {
+	return &accountGroupMemberResource{}
+}
+
+// accountGroupMemberResource defines the resource implementation.
+type accountGroupMemberResource struct {
+	api *cybrapi.API
+}
+
+// accountGroupMemberModel describes the resource data model.
+type accountGroupMemberModel struct {
+	Backend     types.String `tfsdk:"backend"`
+	ID          types.String `tfsdk:"id"`
+	GroupID     types.String `tfsdk:"group_id"`
+	AccountID   types.String `tfsdk:"account_id"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+}
+
+// Metadata returns the resource type name.
+func (r *accountGroupMemberResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_group_member"
+}
+
+// Schema returns the resource schema.
+func (r *accountGroupMemberResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `CyberArk Account Group Member Resource
+
+This resource adds an account to an account group. The account must be in the safe of the group. Do not use it together with the ` + "`account_group_id`" + ` attribute of the account resources for the same account.
+
+For more information click [here](https://docs.cyberark.com/pam-self-hosted/latest/en/content/webservices/add-account-to-account-group.htm).`,
+		Attributes: map[string]schema.Attribute{
+			"backend": backendAttribute(backendPrivilegeCloud),
+			"id": schema.StringAttribute{
+				Description: "The ID of the membership, formatted as `<group_id>/<account_id>`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+			"group_id": schema.StringAttribute{
+				Description: "The ID of the account group. Changing it forces a new resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"account_id": schema.StringAttribute{
+				Description: "The ID of the account. Changing it forces a new resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *accountGroupMemberResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(*cybrapi.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cybrapi.Api, got: %T. Please report this issue to the provider developers", req.ProviderData),
+		)
+		return
+	}
+
+	r.api = api
+}
+
+// ValidateConfig validates the resource configuration.
+func (r *accountGroupMemberResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var backend types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("backend"), &backend)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateBackend(backend, &resp.Diagnostics)
+}
+
+// Create a new resource.
+func (r *accountGroupMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data accountGroupMemberModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := pam.AddAccountGroupMember(ctx, data.GroupID.ValueString(), data.AccountID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating account group member", err.Error())
+		return
+	}
+
+	data.Backend = backendOrDefault(data.Backend, backendPrivilegeCloud)
+	data.ID = types.StringValue(data.GroupID.ValueString() + "/" + data.AccountID.ValueString())
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read the resource and set the Terraform state.
+func (r *accountGroupMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data accountGroupMemberModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, err := pam.ListAccountGroupMembers(ctx, data.GroupID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading account group members", err.Error())
+		return
+	}
+
+	if !isAccountGroupMember(members, data.AccountID.ValueString()) {
+		// The account was removed from the group outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update sets the Terraform state. Every attribute forces a new resource, so there is nothing to update.
+func (r *accountGroupMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state accountGroupMemberModel
+
+	// Read Terraform plan data and current state into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = state.ID
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *accountGroupMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data accountGroupMemberModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := pam.DeleteAccountGroupMember(ctx, data.GroupID.ValueString(), data.AccountID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting account group member", err.Error())
+		return
+	}
+}
+
+// ImportState imports an existing membership by "<group_id>/<account_id>", optionally prefixed
+// with "<backend>:".
+func (r *accountGroupMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	backend, id := splitImportBackend(r.api, req.ID, backendPrivilegeCloud)
+
+	groupID, accountID, ok := strings.Cut(id, "/")
+	if !ok || groupID == "" || accountID == "" {
+		resp.Diagnostics.AddError("Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form <group_id>/<account_id>, got: %s", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("backend"), backend)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), groupID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("account_id"), accountID)...)
+}
+
+// isAccountGroupMember returns whether the account is one of the members.
+func isAccountGroupMember(members []*cybrapi.AccountGroupMember, accountID string) bool {
+	for _, member := range members {
+		if member.AccountID != nil && *member.AccountID == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+// accountGroupIDAttribute returns the schema of the account_group_id attribute of the account resources.
+func accountGroupIDAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "The ID of an account group in the safe of the account to add the account to. Do not use it together with `cyberark_account_group_member` for the same account.",
+		Optional:    true,
+	}
+}
+
+// setAccountGroup moves the account from the prior account group to the planned one, if they differ.
+func setAccountGroup(ctx context.Context, pam cybrapi.PAMAPI, accountID string, prior, planned types.String, diags *diag.Diagnostics) {
+	if prior.Equal(planned) {
+		return
+	}
+
+	if !prior.IsNull() && prior.ValueString() != "" {
+		err := pam.DeleteAccountGroupMember(ctx, prior.ValueString(), accountID)
+		if err != nil {
+			diags.AddError("Error removing account from account group", err.Error())
+			return
+		}
+	}
+
+	if !planned.IsNull() && planned.ValueString() != "" {
+		err := pam.AddAccountGroupMember(ctx, planned.ValueString(), accountID)
+		if err != nil {
+			diags.AddError("Error adding account to account group", err.Error())
+			return
+		}
+	}
+}
+
+// readAccountGroup returns the account group ID if the account is still in the group, and null otherwise.
+func readAccountGroup(ctx context.Context, pam cybrapi.PAMAPI, accountID string, groupID types.String, diags *diag.Diagnostics) types.String {
+	if groupID.IsNull() || groupID.ValueString() == "" {
+		return groupID
+	}
+
+	members, err := pam.ListAccountGroupMembers(ctx, groupID.ValueString())
+	if err != nil {
+		diags.AddError("Error reading account group members", err.Error())
+		return groupID
+	}
+
+	if !isAccountGroupMember(members, accountID) {
+		// The account was removed from the group outside of Terraform
+		return types.StringNull()
+	}
+	return groupID
+}
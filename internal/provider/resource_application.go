@@ -0,0 +1,359 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &applicationResource{}
+	_ resource.ResourceWithConfigure      = &applicationResource{}
+	_ resource.ResourceWithImportState    = &applicationResource{}
+	_ resource.ResourceWithValidateConfig = &applicationResource{}
+)
+
+// NewApplicationResource is a helper function to simplify the provider implementation.
+func NewApplicationResource() resource.Resource {
+	return &applicationResource{}
+}
+
+// applicationResource defines the resource implementation.
+type applicationResource struct {
+	api *cybrapi.API
+}
+
+// applicationModel describes the resource data model.
+type applicationModel struct {
+	Backend                types.String `tfsdk:"backend"`
+	ID                     types.String `tfsdk:"id"`
+	AppID                  types.String `tfsdk:"app_id"`
+	Description            types.String `tfsdk:"description"`
+	Location               types.String `tfsdk:"location"`
+	AccessPermittedFrom    types.Int64  `tfsdk:"access_permitted_from"`
+	AccessPermittedTo      types.Int64  `tfsdk:"access_permitted_to"`
+	ExpirationDate         types.String `tfsdk:"expiration_date"`
+	Disabled               types.Bool   `tfsdk:"disabled"`
+	BusinessOwnerFirstName types.String `tfsdk:"business_owner_first_name"`
+	BusinessOwnerLastName  types.String `tfsdk:"business_owner_last_name"`
+	BusinessOwnerEmail     types.String `tfsdk:"business_owner_email"`
+	BusinessOwnerPhone     types.String `tfsdk:"business_owner_phone"`
+	LastUpdated            types.String `tfsdk:"last_updated"`
+}
+
+// applicationStringAttribute returns an optional application attribute. The API cannot update
+// applications, so changing it forces a new resource.
+func applicationStringAttribute(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: description + " Changing it forces a new resource.",
+		Optional:    true,
+		Computed:    true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+			stringplanmodifier.RequiresReplaceIfConfigured(),
+		},
+	}
+}
+
+// applicationInt64Attribute returns an optional numeric application attribute which forces a
+// new resource when it changes.
+func applicationInt64Attribute(description string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Description: description + " Changing it forces a new resource.",
+		Optional:    true,
+		Computed:    true,
+		PlanModifiers: []planmodifier.Int64{
+			int64planmodifier.UseStateForUnknown(),
+			int64planmodifier.RequiresReplaceIfConfigured(),
+		},
+	}
+}
+
+// Metadata returns the resource type name.
+func (r *applicationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application"
+}
+
+// Schema returns the resource schema.
+func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `CyberArk Application Resource
+
+This resource manages an application of the Credential Provider. The machines, hashes and OS users the application is authenticated with are added with the ` + "`cyberark_application_auth_method`" + ` resource, and the application ID is granted access to a safe with ` + "`permission_level = \"application\"`" + `.
+
+For more information click [here](https://docs.cyberark.com/pam-self-hosted/latest/en/content/webservices/add%20application.htm).`,
+		Attributes: map[string]schema.Attribute{
+			"backend": backendAttribute(backendSelfHosted),
+			"id": schema.StringAttribute{
+				Description: "The application ID.",
+				Computed:    true,
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+			"app_id": schema.StringAttribute{
+				Description: "The application ID, which the application passes to the Credential Provider. Changing it forces a new resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description":           applicationStringAttribute("The description of the application."),
+			"location":              applicationStringAttribute("The location of the application in the Vault hierarchy. Defaults to `\\`."),
+			"access_permitted_from": applicationInt64Attribute("The hour from which the application may retrieve accounts, from 0 to 23. Defaults to 0."),
+			"access_permitted_to":   applicationInt64Attribute("The hour until which the application may retrieve accounts, from 0 to 24. Defaults to 24."),
+			"expiration_date":       applicationStringAttribute("The date the application expires, formatted as `MM-DD-YYYY`."),
+			"disabled": schema.BoolAttribute{
+				Description: "Whether the application is disabled. Defaults to `false`. Changing it forces a new resource.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+					boolplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"business_owner_first_name": applicationStringAttribute("The first name of the business owner of the application."),
+			"business_owner_last_name":  applicationStringAttribute("The last name of the business owner of the application."),
+			"business_owner_email":      applicationStringAttribute("The email address of the business owner of the application."),
+			"business_owner_phone":      applicationStringAttribute("The phone number of the business owner of the application."),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *applicationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(*cybrapi.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cybrapi.Api, got: %T. Please report this issue to the provider developers", req.ProviderData),
+		)
+		return
+	}
+
+	r.api = api
+}
+
+// ValidateConfig validates the resource configuration.
+func (r *applicationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data applicationModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateBackend(data.Backend, &resp.Diagnostics)
+
+	for _, hour := range []struct {
+		name  string
+		value types.Int64
+		max   int64
+	}{
+		{"access_permitted_from", data.AccessPermittedFrom, 23},
+		{"access_permitted_to", data.AccessPermittedTo, 24},
+	} {
+		if hour.value.IsNull() || hour.value.IsUnknown() {
+			continue
+		}
+		if hour.value.ValueInt64() < 0 || hour.value.ValueInt64() > hour.max {
+			resp.Diagnostics.AddAttributeError(path.Root(hour.name), "Invalid Hour",
+				fmt.Sprintf("%s must be between 0 and %d, got %d.", hour.name, hour.max, hour.value.ValueInt64()))
+		}
+	}
+
+	if !data.ExpirationDate.IsNull() && !data.ExpirationDate.IsUnknown() {
+		if _, err := time.Parse("01-02-2006", data.ExpirationDate.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("expiration_date"), "Invalid Expiration Date",
+				fmt.Sprintf("The expiration date must be formatted as MM-DD-YYYY, got %s.", data.ExpirationDate.ValueString()))
+		}
+	}
+}
+
+// Create a new resource.
+func (r *applicationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data applicationModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := pam.AddApplication(ctx, applicationFromModel(&data))
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating application", err.Error())
+		return
+	}
+
+	application, err := pam.GetApplication(ctx, data.AppID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading application", err.Error())
+		return
+	}
+
+	setApplicationModel(&data, application)
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read the resource and set the Terraform state.
+func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data applicationModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	application, err := pam.GetApplication(ctx, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading application", err.Error())
+		return
+	}
+
+	setApplicationModel(&data, application)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success. All attributes of
+// an application force a new resource, so only attributes that are no longer configured change.
+func (r *applicationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state applicationModel
+
+	// Read Terraform plan data and current state into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = state.ID
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *applicationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data applicationModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := pam.DeleteApplication(ctx, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting application", err.Error())
+		return
+	}
+}
+
+// ImportState imports an existing application by its application ID, optionally prefixed with "<backend>:".
+func (r *applicationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	backend, id := splitImportBackend(r.api, req.ID, backendSelfHosted)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("backend"), backend)...)
+}
+
+// applicationFromModel returns the application to add for the resource data.
+func applicationFromModel(data *applicationModel) cybrapi.Application {
+	application := cybrapi.Application{
+		AppID:              data.AppID.ValueStringPointer(),
+		Description:        knownStringPointer(data.Description),
+		Location:           knownStringPointer(data.Location),
+		ExpirationDate:     knownStringPointer(data.ExpirationDate),
+		Disabled:           knownBoolPointer(data.Disabled),
+		BusinessOwnerFName: knownStringPointer(data.BusinessOwnerFirstName),
+		BusinessOwnerLName: knownStringPointer(data.BusinessOwnerLastName),
+		BusinessOwnerEmail: knownStringPointer(data.BusinessOwnerEmail),
+		BusinessOwnerPhone: knownStringPointer(data.BusinessOwnerPhone),
+	}
+	if !data.AccessPermittedFrom.IsNull() && !data.AccessPermittedFrom.IsUnknown() {
+		from := int(data.AccessPermittedFrom.ValueInt64())
+		application.AccessPermittedFrom = &from
+	}
+	if !data.AccessPermittedTo.IsNull() && !data.AccessPermittedTo.IsUnknown() {
+		to := int(data.AccessPermittedTo.ValueInt64())
+		application.AccessPermittedTo = &to
+	}
+	return application
+}
+
+// setApplicationModel sets the resource data to the application returned by the API. Values
+// missing from the response are kept as they are.
+func setApplicationModel(data *applicationModel, application *cybrapi.Application) {
+	data.Backend = backendOrDefault(data.Backend, backendSelfHosted)
+	if application.AppID != nil {
+		data.AppID = types.StringPointerValue(application.AppID)
+	}
+	data.ID = data.AppID
+	data.Description = stringOrPrior(application.Description, data.Description)
+	data.Location = stringOrPrior(application.Location, data.Location)
+	data.BusinessOwnerFirstName = stringOrPrior(application.BusinessOwnerFName, data.BusinessOwnerFirstName)
+	data.BusinessOwnerLastName = stringOrPrior(application.BusinessOwnerLName, data.BusinessOwnerLastName)
+	data.BusinessOwnerEmail = stringOrPrior(application.BusinessOwnerEmail, data.BusinessOwnerEmail)
+	data.BusinessOwnerPhone = stringOrPrior(application.BusinessOwnerPhone, data.BusinessOwnerPhone)
+
+	// The PVWA returns the expiration date in the format of its locale, so a configured date is kept
+	if data.ExpirationDate.IsNull() || data.ExpirationDate.IsUnknown() {
+		data.ExpirationDate = stringOrPrior(application.ExpirationDate, data.ExpirationDate)
+	}
+
+	if application.AccessPermittedFrom != nil {
+		data.AccessPermittedFrom = types.Int64Value(int64(*application.AccessPermittedFrom))
+	} else if data.AccessPermittedFrom.IsUnknown() {
+		data.AccessPermittedFrom = types.Int64Value(0)
+	}
+	if application.AccessPermittedTo != nil {
+		data.AccessPermittedTo = types.Int64Value(int64(*application.AccessPermittedTo))
+	} else if data.AccessPermittedTo.IsUnknown() {
+		data.AccessPermittedTo = types.Int64Value(24)
+	}
+	if application.Disabled != nil {
+		data.Disabled = types.BoolValue(*application.Disabled)
+	} else if data.Disabled.IsUnknown() {
+		data.Disabled = types.BoolValue(false)
+	}
+}
@@ -0,0 +1,385 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &applicationAuthMethodResource{}
+	_ resource.ResourceWithConfigure      = &applicationAuthMethodResource{}
+	_ resource.ResourceWithImportState    = &applicationAuthMethodResource{}
+	_ resource.ResourceWithValidateConfig = &applicationAuthMethodResource{}
+)
+
+// applicationAuthTypes are the authentication methods the Credential Provider supports.
+var applicationAuthTypes = []string{"machineAddress", "osUser", "path", "hash", "certificateSerialNumber"}
+
+// NewApplicationAuthMethodResource is a helper function to simplify the provider implementation.
+func NewApplicationAuthMethodResource() resource.Resource {
+	return &applicationAuthMethodResource{}
+}
+
+// applicationAuthMethodResource defines the resource implementation.
+type applicationAuthMethodResource struct {
+	api *cybrapi.API
+}
+
+// applicationAuthMethodModel describes the resource data model.
+type applicationAuthMethodModel struct {
+	Backend              types.String `tfsdk:"backend"`
+	ID                   types.String `tfsdk:"id"`
+	AppID                types.String `tfsdk:"app_id"`
+	AuthID               types.String `tfsdk:"auth_id"`
+	AuthType             types.String `tfsdk:"auth_type"`
+	AuthValue            types.String `tfsdk:"auth_value"`
+	IsFolder             types.Bool   `tfsdk:"is_folder"`
+	AllowInternalScripts types.Bool   `tfsdk:"allow_internal_scripts"`
+	Comment              types.String `tfsdk:"comment"`
+	LastUpdated          types.String `tfsdk:"last_updated"`
+}
+
+// Metadata returns the resource type name.
+func (r *applicationAuthMethodResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_auth_method"
+}
+
+// Schema returns the resource schema.
+func (r *applicationAuthMethodResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `CyberArk Application Authentication Method Resource
+
+This resource adds a method the Credential Provider authenticates an application with, such as the address of a machine the application may run on, the OS user it runs as, the path or hash of its executable or the serial number of its client certificate. Authentication methods cannot be updated, so every change forces a new resource.
+
+For more information click [here](https://docs.cyberark.com/pam-self-hosted/latest/en/content/webservices/add%20authentication.htm).`,
+		Attributes: map[string]schema.Attribute{
+			"backend": backendAttribute(backendSelfHosted),
+			"id": schema.StringAttribute{
+				Description: "The ID of the authentication method, formatted as `<app_id>/<auth_id>`.",
+				Computed:    true,
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+			"app_id": schema.StringAttribute{
+				Description: "The ID of the application. Changing it forces a new resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"auth_id": schema.StringAttribute{
+				Description: "The ID of the authentication method within the application.",
+				Computed:    true,
+			},
+			"auth_type": schema.StringAttribute{
+				Description: "The type of the authentication method: " + strings.Join(applicationAuthTypes, ", ") + ". Changing it forces a new resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"auth_value": schema.StringAttribute{
+				Description: "The machine address, OS user, path, hash or certificate serial number the application is authenticated with. Changing it forces a new resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"is_folder": schema.BoolAttribute{
+				Description: "Whether a `path` is a folder, which all executables in it are authenticated by. Defaults to `false`. Changing it forces a new resource.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"allow_internal_scripts": schema.BoolAttribute{
+				Description: "Whether scripts run by the executable of a `path` are authenticated too. Defaults to `false`. Changing it forces a new resource.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Description: "A comment on a `hash`, e.g. the version of the executable. Changing it forces a new resource.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *applicationAuthMethodResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(*cybrapi.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cybrapi.Api, got: %T. Please report this issue to the provider developers", req.ProviderData),
+		)
+		return
+	}
+
+	r.api = api
+}
+
+// ValidateConfig validates the resource configuration.
+func (r *applicationAuthMethodResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data applicationAuthMethodModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateBackend(data.Backend, &resp.Diagnostics)
+
+	if data.AuthType.IsNull() || data.AuthType.IsUnknown() {
+		return
+	}
+
+	authType := data.AuthType.ValueString()
+	valid := false
+	for _, t := range applicationAuthTypes {
+		if t == authType {
+			valid = true
+		}
+	}
+	if !valid {
+		resp.Diagnostics.AddAttributeError(path.Root("auth_type"), "Invalid Authentication Type",
+			fmt.Sprintf("auth_type must be one of %s, got %s.", strings.Join(applicationAuthTypes, ", "), authType))
+		return
+	}
+
+	if authType != "path" {
+		for _, attr := range []struct {
+			name  string
+			value types.Bool
+		}{
+			{"is_folder", data.IsFolder},
+			{"allow_internal_scripts", data.AllowInternalScripts},
+		} {
+			if attr.value.ValueBool() {
+				resp.Diagnostics.AddAttributeError(path.Root(attr.name), "Invalid Configuration",
+					fmt.Sprintf("%s can only be set for the path authentication type.", attr.name))
+			}
+		}
+	}
+	if authType != "hash" && !data.Comment.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("comment"), "Invalid Configuration",
+			"comment can only be set for the hash authentication type.")
+	}
+}
+
+// Create a new resource.
+func (r *applicationAuthMethodResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data applicationAuthMethodModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	method := cybrapi.ApplicationAuthMethod{
+		AuthType:  data.AuthType.ValueStringPointer(),
+		AuthValue: data.AuthValue.ValueStringPointer(),
+		Comment:   data.Comment.ValueStringPointer(),
+	}
+	if data.AuthType.ValueString() == "path" {
+		method.IsFolder = data.IsFolder.ValueBoolPointer()
+		method.AllowInternalScripts = data.AllowInternalScripts.ValueBoolPointer()
+	}
+
+	err := pam.AddApplicationAuthMethod(ctx, data.AppID.ValueString(), method)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating application authentication method", err.Error())
+		return
+	}
+
+	// The API does not return the ID of the new method
+	methods, err := pam.ListApplicationAuthMethods(ctx, data.AppID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading application authentication methods", err.Error())
+		return
+	}
+
+	created := matchApplicationAuthMethod(methods, data.AuthType.ValueString(), data.AuthValue.ValueString())
+	if created == nil {
+		resp.Diagnostics.AddError("Error creating application authentication method",
+			fmt.Sprintf("The %s authentication method %s was added, but application %s does not list it.",
+				data.AuthType.ValueString(), data.AuthValue.ValueString(), data.AppID.ValueString()))
+		return
+	}
+
+	data.Backend = backendOrDefault(data.Backend, backendSelfHosted)
+	data.AuthID = types.StringValue(created.AuthID.String())
+	data.ID = types.StringValue(data.AppID.ValueString() + "/" + created.AuthID.String())
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read the resource and set the Terraform state.
+func (r *applicationAuthMethodResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data applicationAuthMethodModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	methods, err := pam.ListApplicationAuthMethods(ctx, data.AppID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading application authentication methods", err.Error())
+		return
+	}
+
+	method := findApplicationAuthMethod(methods, data.AuthID.ValueString())
+	if method == nil {
+		// The method was removed outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if method.AuthType != nil && !strings.EqualFold(*method.AuthType, data.AuthType.ValueString()) {
+		data.AuthType = types.StringPointerValue(method.AuthType)
+	}
+	data.AuthValue = types.StringPointerValue(method.AuthValue)
+	if method.IsFolder != nil {
+		data.IsFolder = types.BoolValue(*method.IsFolder)
+	} else if data.IsFolder.IsNull() {
+		data.IsFolder = types.BoolValue(false)
+	}
+	if method.AllowInternalScripts != nil {
+		data.AllowInternalScripts = types.BoolValue(*method.AllowInternalScripts)
+	} else if data.AllowInternalScripts.IsNull() {
+		data.AllowInternalScripts = types.BoolValue(false)
+	}
+	if method.Comment != nil && *method.Comment != "" {
+		data.Comment = types.StringPointerValue(method.Comment)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update sets the Terraform state. Every attribute forces a new resource, so there is nothing to update.
+func (r *applicationAuthMethodResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state applicationAuthMethodModel
+
+	// Read Terraform plan data and current state into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = state.ID
+	data.AuthID = state.AuthID
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *applicationAuthMethodResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data applicationAuthMethodModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := pam.DeleteApplicationAuthMethod(ctx, data.AppID.ValueString(), data.AuthID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting application authentication method", err.Error())
+		return
+	}
+}
+
+// ImportState imports an existing authentication method by "<app_id>/<auth_id>", optionally
+// prefixed with "<backend>:".
+func (r *applicationAuthMethodResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	backend, id := splitImportBackend(r.api, req.ID, backendSelfHosted)
+
+	appID, authID, ok := strings.Cut(id, "/")
+	if !ok || appID == "" || authID == "" {
+		resp.Diagnostics.AddError("Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form <app_id>/<auth_id>, got: %s", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("backend"), backend)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_id"), appID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("auth_id"), authID)...)
+}
+
+// findApplicationAuthMethod returns the authentication method with the given ID, or nil if the
+// application has none.
+func findApplicationAuthMethod(methods []*cybrapi.ApplicationAuthMethod, authID string) *cybrapi.ApplicationAuthMethod {
+	for _, method := range methods {
+		if method.AuthID.String() == authID {
+			return method
+		}
+	}
+	return nil
+}
+
+// matchApplicationAuthMethod returns the authentication method with the given type and value, or
+// nil if the application has none. Types are compared case-insensitively, as the PVWA does not
+// return them as they were added.
+func matchApplicationAuthMethod(methods []*cybrapi.ApplicationAuthMethod, authType, authValue string) *cybrapi.ApplicationAuthMethod {
+	for _, method := range methods {
+		if method.AuthType != nil && method.AuthValue != nil &&
+			strings.EqualFold(*method.AuthType, authType) && *method.AuthValue == authValue {
+			return method
+		}
+	}
+	return nil
+}
@@ -13,49 +13,68 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &awsAccountResource{}
-	_ resource.ResourceWithConfigure   = &awsAccountResource{}
-	_ resource.ResourceWithImportState = &awsAccountResource{}
+	_ resource.Resource                   = &awsAccountResource{}
+	_ resource.ResourceWithConfigure      = &awsAccountResource{}
+	_ resource.ResourceWithImportState    = &awsAccountResource{}
+	_ resource.ResourceWithValidateConfig = &awsAccountResource{}
+	_ resource.ResourceWithMoveState      = &awsAccountResource{}
+	_ resource.ResourceWithModifyPlan     = &awsAccountResource{}
 )
 
 // NewAWSAccountResource is a helper function to simplify the provider implementation.
 func NewAWSAccountResource() resource.Resource {
-	return &awsAccountResource{}
+	return &awsAccountResource{defaultBackend: backendPrivilegeCloud}
+}
+
+// NewPVWAAWSAccountResource returns the deprecated cyberark_pvwa_aws_account alias of the resource, which
+// manages accounts in the self-hosted vault by default.
+func NewPVWAAWSAccountResource() resource.Resource {
+	return &awsAccountResource{defaultBackend: backendSelfHosted}
 }
 
 // awsAccountResource defines the resource implementation.
 type awsAccountResource struct {
 	api *cybrapi.API
+
+	// defaultBackend is the backend used when the backend attribute is not set
+	defaultBackend string
 }
 
 // awsCredModel describes the resource data model.
 type awsCredModel struct {
-	Name                    types.String `tfsdk:"name"`
-	Address                 types.String `tfsdk:"address"`
-	Username                types.String `tfsdk:"username"`
-	Platform                types.String `tfsdk:"platform"`
-	Safe                    types.String `tfsdk:"safe"`
-	SecretType              types.String `tfsdk:"secret_type"`
-	Secret                  types.String `tfsdk:"secret"`
-	ID                      types.String `tfsdk:"id"`
-	LastUpdated             types.String `tfsdk:"last_updated"`
-	Manage                  types.Bool   `tfsdk:"sm_manage"`
-	ManageReason            types.String `tfsdk:"sm_manage_reason"`
-	AWSKID                  types.String `tfsdk:"aws_kid"`
-	AWSAccount              types.String `tfsdk:"aws_account_id"`
-	Alias                   types.String `tfsdk:"aws_alias"`
-	Region                  types.String `tfsdk:"aws_account_region"`
-	SecretNameInSecretStore types.String `tfsdk:"secret_name_in_secret_store"`
+	Backend                          types.String `tfsdk:"backend"`
+	Name                             types.String `tfsdk:"name"`
+	Address                          types.String `tfsdk:"address"`
+	Username                         types.String `tfsdk:"username"`
+	Platform                         types.String `tfsdk:"platform"`
+	Safe                             types.String `tfsdk:"safe"`
+	SecretType                       types.String `tfsdk:"secret_type"`
+	Secret                           types.String `tfsdk:"secret"`
+	ID                               types.String `tfsdk:"id"`
+	LastUpdated                      types.String `tfsdk:"last_updated"`
+	Manage                           types.Bool   `tfsdk:"sm_manage"`
+	ManageReason                     types.String `tfsdk:"sm_manage_reason"`
+	AccountGroupID                   types.String `tfsdk:"account_group_id"`
+	RemoteMachines                   types.Set    `tfsdk:"remote_machines"`
+	AccessRestrictedToRemoteMachines types.Bool   `tfsdk:"access_restricted_to_remote_machines"`
+	Folder                           types.String `tfsdk:"folder"`
+	AWSKID                           types.String `tfsdk:"aws_kid"`
+	AWSAccount                       types.String `tfsdk:"aws_account_id"`
+	Alias                            types.String `tfsdk:"aws_alias"`
+	Region                           types.String `tfsdk:"aws_account_region"`
+	SecretNameInSecretStore          types.String `tfsdk:"secret_name_in_secret_store"`
 }
 
 // Metadata returns the resource type name.
 func (r *awsAccountResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
-	resp.TypeName = req.ProviderTypeName + "_aws_account"
+	resp.TypeName = backendTypeName(req.ProviderTypeName, "aws_account", r.defaultBackend)
 }
 
 // Schema returns the resource schema.
@@ -63,12 +82,14 @@ func (r *awsAccountResource) Schema(_ context.Context, _ resource.SchemaRequest,
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `AWS Account Resource
 
-This resource is responsible for creating a new privileged account that contains all the required AWS information as mentioned below in Privilege Cloud.
+This resource is responsible for creating a new privileged account that contains all the required AWS information as mentioned below in Privilege Cloud or PAM Self-Hosted.
 
 For more information click [here](https://docs.cyberark.com/privilege-cloud-shared-services/latest/en/Content/WebServices/Add%20Account%20v10.htm).`,
+		DeprecationMessage: backendDeprecationMessage("aws_account", r.defaultBackend),
 		Attributes: map[string]schema.Attribute{
+			"backend": backendAttribute(r.defaultBackend),
 			"id": schema.StringAttribute{
-				Description: "CyberArk Privilege Cloud Credential ID- Generated from CyberArk after onboarding account into a safe.",
+				Description: "CyberArk Credential ID- Generated from CyberArk after onboarding account into a safe.",
 				Computed:    true,
 			},
 			"last_updated": schema.StringAttribute{
@@ -113,6 +134,16 @@ For more information click [here](https://docs.cyberark.com/privilege-cloud-shar
 				Description: "Automatic Management of a credential. Optional Value.",
 				Optional:    true,
 			},
+			"account_group_id":                     accountGroupIDAttribute(),
+			"remote_machines":                      remoteMachinesAttribute(),
+			"access_restricted_to_remote_machines": accessRestrictedToRemoteMachinesAttribute(),
+			"folder": schema.StringAttribute{
+				Description: "Folder of the safe to add the credential to. Defaults to the root folder of the safe. The API does not return the folder, so moves outside of Terraform are not detected. Changing it forces a new resource.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"sm_manage_reason": schema.StringAttribute{
 				Description: "If sm_manage is false, provide reason why credential is not managed.",
 				Optional:    true,
@@ -146,7 +177,7 @@ func (r *awsAccountResource) Configure(_ context.Context, req resource.Configure
 	api, ok := req.ProviderData.(*cybrapi.API)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected AzureAkvData Source Configure Type",
+			"Unexpected Resource Configure Type",
 			fmt.Sprintf("Expected *cybrapi.Api, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
@@ -156,6 +187,23 @@ func (r *awsAccountResource) Configure(_ context.Context, req resource.Configure
 	r.api = api
 }
 
+// ValidateConfig validates the resource configuration.
+func (r *awsAccountResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var backend types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("backend"), &backend)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateBackend(backend, &resp.Diagnostics)
+}
+
+// ModifyPlan checks the platform of the account when platform validation is enabled.
+func (r *awsAccountResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyAccountPlan(ctx, r.api, r.defaultBackend, req, resp)
+}
+
 // Create a new resource.
 func (r *awsAccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data awsCredModel
@@ -165,6 +213,16 @@ func (r *awsAccountResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remoteAccess := remoteAccessFromModel(ctx, data.RemoteMachines, data.AccessRestrictedToRemoteMachines, types.SetNull(types.StringType), types.BoolNull(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	newAccount := cybrapi.Credential{
 		Name:       data.Name.ValueStringPointer(),
 		Address:    data.Address.ValueStringPointer(),
@@ -184,9 +242,11 @@ func (r *awsAccountResource) Create(ctx context.Context, req resource.CreateRequ
 			AutomaticManagement:    data.Manage.ValueBoolPointer(),
 			ManualManagementReason: data.ManageReason.ValueStringPointer(),
 		},
+		RemoteAccess: remoteAccess,
+		Folder:       data.Folder.ValueStringPointer(),
 	}
 
-	accountSearch, err := r.api.PamAPI.FilterAccounts(
+	accountSearch, err := pam.FilterAccounts(
 		ctx,
 		"",
 		[]string{
@@ -208,7 +268,7 @@ func (r *awsAccountResource) Create(ctx context.Context, req resource.CreateRequ
 
 	if account == nil {
 		tflog.Info(ctx, "Account not found, creating new")
-		account, err = r.api.PamAPI.AddAccount(ctx, newAccount)
+		account, err = pam.AddAccount(ctx, newAccount)
 		if err != nil {
 			resp.Diagnostics.AddError("Error creating account", err.Error())
 			return
@@ -219,6 +279,7 @@ func (r *awsAccountResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	data.ID = types.StringPointerValue(account.CredID)
+	setAccountGroup(ctx, pam, data.ID.ValueString(), types.StringNull(), data.AccountGroupID, &resp.Diagnostics)
 
 	// Set last updated time to last updated time in the vault
 	if account.SecretMgmt != nil && account.SecretMgmt.ModifiedTime != nil {
@@ -242,21 +303,33 @@ func (r *awsAccountResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	newState, err := r.api.PamAPI.GetAccount(ctx, data.ID.ValueString())
+	data.Backend = backendOrDefault(data.Backend, r.defaultBackend)
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	newState, err := pam.GetAccount(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading account", err.Error())
 		return
 	}
 
 	data = awsCredModel{
-		Name:       types.StringPointerValue(newState.Name),
-		Address:    types.StringPointerValue(newState.Address),
-		Username:   types.StringPointerValue(newState.UserName),
-		Platform:   types.StringPointerValue(newState.Platform),
-		Safe:       types.StringPointerValue(newState.SafeName),
-		SecretType: types.StringPointerValue(newState.SecretType),
-		ID:         types.StringPointerValue(newState.CredID),
-		Secret:     data.Secret, // Secret is not returned by the API
+		Backend:                          data.Backend,
+		Name:                             types.StringPointerValue(newState.Name),
+		Address:                          types.StringPointerValue(newState.Address),
+		Username:                         types.StringPointerValue(newState.UserName),
+		Platform:                         types.StringPointerValue(newState.Platform),
+		Safe:                             types.StringPointerValue(newState.SafeName),
+		SecretType:                       types.StringPointerValue(newState.SecretType),
+		ID:                               types.StringPointerValue(newState.CredID),
+		Secret:                           data.Secret, // Secret is not returned by the API
+		AccountGroupID:                   data.AccountGroupID,
+		RemoteMachines:                   data.RemoteMachines,
+		AccessRestrictedToRemoteMachines: data.AccessRestrictedToRemoteMachines,
+		Folder:                           data.Folder, // Folder is not returned by the API
 	}
 
 	if newState.Props != nil {
@@ -280,6 +353,9 @@ func (r *awsAccountResource) Read(ctx context.Context, req resource.ReadRequest,
 		data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
 	}
 
+	data.RemoteMachines, data.AccessRestrictedToRemoteMachines = readRemoteAccess(newState.RemoteAccess, data.RemoteMachines, data.AccessRestrictedToRemoteMachines)
+	data.AccountGroupID = readAccountGroup(ctx, pam, data.ID.ValueString(), data.AccountGroupID, &resp.Diagnostics)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -296,6 +372,16 @@ func (r *awsAccountResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remoteAccess := remoteAccessFromModel(ctx, data.RemoteMachines, data.AccessRestrictedToRemoteMachines, state.RemoteMachines, state.AccessRestrictedToRemoteMachines, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	updatedAccount := cybrapi.Credential{
 		Name:     data.Name.ValueStringPointer(),
 		Address:  data.Address.ValueStringPointer(),
@@ -315,9 +401,10 @@ func (r *awsAccountResource) Update(ctx context.Context, req resource.UpdateRequ
 			AutomaticManagement:    data.Manage.ValueBoolPointer(),
 			ManualManagementReason: data.ManageReason.ValueStringPointer(),
 		},
+		RemoteAccess: remoteAccess,
 	}
 
-	account, err := r.api.PamAPI.UpdateAccount(ctx, state.ID.ValueString(), updatedAccount)
+	account, err := pam.UpdateAccount(ctx, state.ID.ValueString(), updatedAccount)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating account", err.Error())
 		return
@@ -325,6 +412,7 @@ func (r *awsAccountResource) Update(ctx context.Context, req resource.UpdateRequ
 
 	// Update the ID in case it changed
 	data.ID = types.StringPointerValue(account.CredID)
+	setAccountGroup(ctx, pam, data.ID.ValueString(), state.AccountGroupID, data.AccountGroupID, &resp.Diagnostics)
 
 	// Update last updated time
 	if account.SecretMgmt != nil && account.SecretMgmt.ModifiedTime != nil {
@@ -347,13 +435,49 @@ func (r *awsAccountResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	err := r.api.PamAPI.DeleteAccount(ctx, data.ID.ValueString())
+	data.Backend = backendOrDefault(data.Backend, r.defaultBackend)
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := pam.DeleteAccount(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting account", err.Error())
 		return
 	}
 }
 
+// ImportState imports an existing account by its ID or by "<safe>/<account name>",
+// optionally prefixed with "<backend>:".
 func (r *awsAccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	backend, importID := splitImportBackend(r.api, req.ID, r.defaultBackend)
+
+	pam := pamAPIForBackend(r.api, backend, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := resolveAccountImportID(ctx, pam, importID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing account", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("backend"), backend)...)
+}
+
+// MoveState moves the state of the deprecated cyberark_pvwa_aws_account alias to the resource.
+func (r *awsAccountResource) MoveState(ctx context.Context) []resource.StateMover {
+	if r.defaultBackend == backendSelfHosted {
+		return nil
+	}
+
+	alias := &awsAccountResource{defaultBackend: backendSelfHosted}
+	aliasSchema := &resource.SchemaResponse{}
+	alias.Schema(ctx, resource.SchemaRequest{}, aliasSchema)
+
+	return []resource.StateMover{pvwaAliasStateMover(aliasSchema.Schema, "aws_account", &awsCredModel{})}
 }
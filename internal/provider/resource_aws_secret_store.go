@@ -34,15 +34,17 @@ type awsSecretStoreResource struct {
 
 // awsSecretStoreModel describes the resource data model.
 type awsSecretStoreModel struct {
-	Name         types.String `tfsdk:"name"`
-	Description  types.String `tfsdk:"description"`
-	Type         types.String `tfsdk:"type"`
-	AccountAlias types.String `tfsdk:"aws_account_alias"`
-	AccountID    types.String `tfsdk:"aws_account_id"`
-	RegionID     types.String `tfsdk:"aws_account_region"`
-	RoleName     types.String `tfsdk:"aws_iam_role"`
-	ID           types.String `tfsdk:"id"`
-	LastUpdated  types.String `tfsdk:"last_updated"`
+	Name               types.String `tfsdk:"name"`
+	Description        types.String `tfsdk:"description"`
+	Type               types.String `tfsdk:"type"`
+	AccountAlias       types.String `tfsdk:"aws_account_alias"`
+	AccountID          types.String `tfsdk:"aws_account_id"`
+	RegionID           types.String `tfsdk:"aws_account_region"`
+	RoleName           types.String `tfsdk:"aws_iam_role"`
+	ID                 types.String `tfsdk:"id"`
+	LastUpdated        types.String `tfsdk:"last_updated"`
+	ValidateConnection types.Bool   `tfsdk:"validate_connection"`
+	AdoptExisting      types.Bool   `tfsdk:"adopt_existing"`
 }
 
 // Metadata returns the resource type name.
@@ -66,6 +68,14 @@ For more information click [here](https://docs.cyberark.com/secrets-hub-privileg
 			"last_updated": schema.StringAttribute{
 				Computed: true,
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Manage an existing secret store with the same name instead of failing. The existing store is compared with the configuration and updated if it differs.",
+				Optional:    true,
+			},
+			"validate_connection": schema.BoolAttribute{
+				Description: "Test the connection from Secrets Hub to the secret store after it is created or updated, and fail the apply with the service's diagnostic message if the test does not succeed.",
+				Optional:    true,
+			},
 			"name": schema.StringAttribute{
 				Description: "Custom Secret Store Name for customizing the object name in a secret store.",
 				Required:    true,
@@ -146,23 +156,32 @@ func (r *awsSecretStoreResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	for _, store := range stores.SecretStores {
-		if *store.Name == data.Name.ValueString() && *store.Data.AccountAlias == data.AccountAlias.ValueString() {
-			tflog.Info(ctx, fmt.Sprintf("Secret store with name %s and account alias %s already exists", data.Name.ValueString(), data.AccountAlias.ValueString()))
+	existing := findSecretStoreByName(stores, data.Name.ValueString())
+	if existing != nil && !data.AdoptExisting.ValueBool() {
+		addSecretStoreExistsError(&resp.Diagnostics, "cyberark_aws_secret_store", data.Name.ValueString(), existing.ID)
+		return
+	}
 
-			// We assume that secret store is already created
-			data.ID = types.StringValue(store.ID)
-			data.LastUpdated = types.StringPointerValue(store.UpdatedAt)
-			// Save data into Terraform state
-			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	var output *cybrapi.SecretStoreOutput[cybrapi.AwsAsmData]
+	if existing != nil {
+		output = adoptSecretStore(ctx, existing.ID, newStore,
+			r.api.SecretsHubAPI.GetAwsAsmSecretStore,
+			func(ctx context.Context, storeID string) (*cybrapi.SecretStoreOutput[cybrapi.AwsAsmData], error) {
+				return r.api.SecretsHubAPI.UpdateAwsSecretStore(ctx, storeID, newStore)
+			},
+			false,
+			&resp.Diagnostics,
+		)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		tflog.Info(ctx, "Secret store not found, creating new")
+		output, err = r.api.SecretsHubAPI.AddAwsAsmSecretStore(ctx, newStore)
+		if err != nil {
+			resp.Diagnostics.AddError("Error creating secret store", err.Error())
 			return
 		}
-	}
-
-	output, err := r.api.SecretsHubAPI.AddAwsAsmSecretStore(ctx, newStore)
-	if err != nil {
-		resp.Diagnostics.AddError("Error creating secret store", err.Error())
-		return
 	}
 
 	data.ID = types.StringValue(output.ID)
@@ -170,6 +189,10 @@ func (r *awsSecretStoreResource) Create(ctx context.Context, req resource.Create
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if data.ValidateConnection.ValueBool() {
+		validateSecretStoreConnection(ctx, r.api.SecretsHubAPI, output.ID, &resp.Diagnostics)
+	}
 }
 
 // Read the resource state. The Read method is used to sync an existing resource with Terraform's state when Terraform is already aware of the resource.
@@ -194,6 +217,9 @@ func (r *awsSecretStoreResource) Read(ctx context.Context, req resource.ReadRequ
 		Type:        types.StringPointerValue(output.Type),
 		ID:          types.StringValue(output.ID),
 		LastUpdated: types.StringPointerValue(output.UpdatedAt),
+		// Not stored by the API
+		ValidateConnection: data.ValidateConnection,
+		AdoptExisting:      data.AdoptExisting,
 	}
 
 	if output.Data != nil {
@@ -241,6 +267,10 @@ func (r *awsSecretStoreResource) Update(ctx context.Context, req resource.Update
 	data.LastUpdated = types.StringPointerValue(output.UpdatedAt)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if data.ValidateConnection.ValueBool() {
+		validateSecretStoreConnection(ctx, r.api.SecretsHubAPI, output.ID, &resp.Diagnostics)
+	}
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -259,8 +289,22 @@ func (r *awsSecretStoreResource) Delete(ctx context.Context, req resource.Delete
 	}
 }
 
-// ImportState imports an existing AWS Secret Store resource into Terraform. It retrieves the resource from CyberArk SecretsHub using the provided ID,
-// sets the Terraform state to match the resource, and handles any errors.
+// ImportState imports an existing secret store by its ID or by "name:<store name>".
 func (r *awsSecretStoreResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id := req.ID
+	if name, ok := importNameFromID(req.ID); ok {
+		stores, err := r.api.SecretsHubAPI.GetAwsAsmSecretStores(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing secret store", err.Error())
+			return
+		}
+
+		id, err = uniqueImportID("secret store", name, secretStoreIDsByName(stores, name))
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing secret store", err.Error())
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
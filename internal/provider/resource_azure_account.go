@@ -13,52 +13,71 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &azureAccountResource{}
-	_ resource.ResourceWithConfigure   = &azureAccountResource{}
-	_ resource.ResourceWithImportState = &azureAccountResource{}
+	_ resource.Resource                   = &azureAccountResource{}
+	_ resource.ResourceWithConfigure      = &azureAccountResource{}
+	_ resource.ResourceWithImportState    = &azureAccountResource{}
+	_ resource.ResourceWithValidateConfig = &azureAccountResource{}
+	_ resource.ResourceWithMoveState      = &azureAccountResource{}
+	_ resource.ResourceWithModifyPlan     = &azureAccountResource{}
 )
 
 // NewAzureAccountResource is a helper function to simplify the provider implementation.
 func NewAzureAccountResource() resource.Resource {
-	return &azureAccountResource{}
+	return &azureAccountResource{defaultBackend: backendPrivilegeCloud}
+}
+
+// NewPVWAAzureAccountResource returns the deprecated cyberark_pvwa_azure_account alias of the resource, which
+// manages accounts in the self-hosted vault by default.
+func NewPVWAAzureAccountResource() resource.Resource {
+	return &azureAccountResource{defaultBackend: backendSelfHosted}
 }
 
 // azureAccountResource is the resource implementation.
 type azureAccountResource struct {
 	api *cybrapi.API
+
+	// defaultBackend is the backend used when the backend attribute is not set
+	defaultBackend string
 }
 
 // azureCredModel describes the resource data model.
 type azureCredModel struct {
-	Name                    types.String `tfsdk:"name"`
-	Address                 types.String `tfsdk:"address"`
-	Username                types.String `tfsdk:"username"`
-	Platform                types.String `tfsdk:"platform"`
-	Safe                    types.String `tfsdk:"safe"`
-	SecretType              types.String `tfsdk:"secret_type"`
-	Secret                  types.String `tfsdk:"secret"`
-	ID                      types.String `tfsdk:"id"`
-	LastUpdated             types.String `tfsdk:"last_updated"`
-	Manage                  types.Bool   `tfsdk:"sm_manage"`
-	ManageReason            types.String `tfsdk:"sm_manage_reason"`
-	MAppID                  types.String `tfsdk:"ms_app_id"`
-	MAppObjectID            types.String `tfsdk:"ms_app_obj_id"`
-	MKID                    types.String `tfsdk:"ms_key_id"`
-	MADID                   types.String `tfsdk:"ms_ad_id"`
-	MDur                    types.String `tfsdk:"ms_duration"`
-	MPop                    types.String `tfsdk:"ms_pop"`
-	MKeyDesc                types.String `tfsdk:"ms_key_desc"`
-	SecretNameInSecretStore types.String `tfsdk:"secret_name_in_secret_store"`
+	Backend                          types.String `tfsdk:"backend"`
+	Name                             types.String `tfsdk:"name"`
+	Address                          types.String `tfsdk:"address"`
+	Username                         types.String `tfsdk:"username"`
+	Platform                         types.String `tfsdk:"platform"`
+	Safe                             types.String `tfsdk:"safe"`
+	SecretType                       types.String `tfsdk:"secret_type"`
+	Secret                           types.String `tfsdk:"secret"`
+	ID                               types.String `tfsdk:"id"`
+	LastUpdated                      types.String `tfsdk:"last_updated"`
+	Manage                           types.Bool   `tfsdk:"sm_manage"`
+	ManageReason                     types.String `tfsdk:"sm_manage_reason"`
+	AccountGroupID                   types.String `tfsdk:"account_group_id"`
+	RemoteMachines                   types.Set    `tfsdk:"remote_machines"`
+	AccessRestrictedToRemoteMachines types.Bool   `tfsdk:"access_restricted_to_remote_machines"`
+	Folder                           types.String `tfsdk:"folder"`
+	MAppID                           types.String `tfsdk:"ms_app_id"`
+	MAppObjectID                     types.String `tfsdk:"ms_app_obj_id"`
+	MKID                             types.String `tfsdk:"ms_key_id"`
+	MADID                            types.String `tfsdk:"ms_ad_id"`
+	MDur                             types.String `tfsdk:"ms_duration"`
+	MPop                             types.String `tfsdk:"ms_pop"`
+	MKeyDesc                         types.String `tfsdk:"ms_key_desc"`
+	SecretNameInSecretStore          types.String `tfsdk:"secret_name_in_secret_store"`
 }
 
 // Metadata returns the resource type name.
 func (r *azureAccountResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
-	resp.TypeName = req.ProviderTypeName + "_azure_account"
+	resp.TypeName = backendTypeName(req.ProviderTypeName, "azure_account", r.defaultBackend)
 }
 
 // Schema returns the resource schema.
@@ -66,12 +85,14 @@ func (r *azureAccountResource) Schema(_ context.Context, _ resource.SchemaReques
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `Microsoft Azure Account Resource
 
-This resource is responsible for creating a new privileged account that contains all the required Azure information as mentioned below in Privilege Cloud.
+This resource is responsible for creating a new privileged account that contains all the required Azure information as mentioned below in Privilege Cloud or PAM Self-Hosted.
 
 For more information click [here](https://docs.cyberark.com/privilege-cloud-shared-services/latest/en/Content/WebServices/Add%20Account%20v10.htm).`,
+		DeprecationMessage: backendDeprecationMessage("azure_account", r.defaultBackend),
 		Attributes: map[string]schema.Attribute{
+			"backend": backendAttribute(r.defaultBackend),
 			"id": schema.StringAttribute{
-				Description: "CyberArk Privilege Cloud Credential ID- Generated from CyberArk after onboarding account into a safe.",
+				Description: "CyberArk Credential ID- Generated from CyberArk after onboarding account into a safe.",
 				Computed:    true,
 			},
 			"last_updated": schema.StringAttribute{
@@ -115,6 +136,16 @@ For more information click [here](https://docs.cyberark.com/privilege-cloud-shar
 				Description: "Name of the credential object.",
 				Optional:    true,
 			},
+			"account_group_id":                     accountGroupIDAttribute(),
+			"remote_machines":                      remoteMachinesAttribute(),
+			"access_restricted_to_remote_machines": accessRestrictedToRemoteMachinesAttribute(),
+			"folder": schema.StringAttribute{
+				Description: "Folder of the safe to add the credential to. Defaults to the root folder of the safe. The API does not return the folder, so moves outside of Terraform are not detected. Changing it forces a new resource.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"sm_manage_reason": schema.StringAttribute{
 				Description: "If sm_manage is false, provide reason why credential is not managed.",
 				Optional:    true,
@@ -160,7 +191,7 @@ func (r *azureAccountResource) Configure(_ context.Context, req resource.Configu
 	api, ok := req.ProviderData.(*cybrapi.API)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected AzureAkvData Source Configure Type",
+			"Unexpected Resource Configure Type",
 			fmt.Sprintf("Expected *cybrapi.Api, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
@@ -170,6 +201,23 @@ func (r *azureAccountResource) Configure(_ context.Context, req resource.Configu
 	r.api = api
 }
 
+// ValidateConfig validates the resource configuration.
+func (r *azureAccountResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var backend types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("backend"), &backend)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateBackend(backend, &resp.Diagnostics)
+}
+
+// ModifyPlan checks the platform of the account when platform validation is enabled.
+func (r *azureAccountResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyAccountPlan(ctx, r.api, r.defaultBackend, req, resp)
+}
+
 // Create a new resource.
 func (r *azureAccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data azureCredModel
@@ -180,6 +228,16 @@ func (r *azureAccountResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remoteAccess := remoteAccessFromModel(ctx, data.RemoteMachines, data.AccessRestrictedToRemoteMachines, types.SetNull(types.StringType), types.BoolNull(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	newAccount := cybrapi.Credential{
 		Name:       data.Name.ValueStringPointer(),
 		Address:    data.Address.ValueStringPointer(),
@@ -202,9 +260,11 @@ func (r *azureAccountResource) Create(ctx context.Context, req resource.CreateRe
 			AutomaticManagement:    data.Manage.ValueBoolPointer(),
 			ManualManagementReason: data.ManageReason.ValueStringPointer(),
 		},
+		RemoteAccess: remoteAccess,
+		Folder:       data.Folder.ValueStringPointer(),
 	}
 
-	accountSearch, err := r.api.PamAPI.FilterAccounts(
+	accountSearch, err := pam.FilterAccounts(
 		ctx,
 		// name,
 		"",
@@ -227,7 +287,7 @@ func (r *azureAccountResource) Create(ctx context.Context, req resource.CreateRe
 
 	if account == nil {
 		tflog.Info(ctx, "Account not found, creating new")
-		account, err = r.api.PamAPI.AddAccount(ctx, newAccount)
+		account, err = pam.AddAccount(ctx, newAccount)
 		if err != nil {
 			resp.Diagnostics.AddError("Error creating account", err.Error())
 			return
@@ -238,6 +298,7 @@ func (r *azureAccountResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	data.ID = types.StringPointerValue(account.CredID)
+	setAccountGroup(ctx, pam, data.ID.ValueString(), types.StringNull(), data.AccountGroupID, &resp.Diagnostics)
 
 	// Set last updated time to last updated time in the vault
 	if account.SecretMgmt != nil && account.SecretMgmt.ModifiedTime != nil {
@@ -261,21 +322,33 @@ func (r *azureAccountResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	newState, err := r.api.PamAPI.GetAccount(ctx, data.ID.ValueString())
+	data.Backend = backendOrDefault(data.Backend, r.defaultBackend)
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	newState, err := pam.GetAccount(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading account", err.Error())
 		return
 	}
 
 	data = azureCredModel{
-		Name:       types.StringPointerValue(newState.Name),
-		Address:    types.StringPointerValue(newState.Address),
-		Username:   types.StringPointerValue(newState.UserName),
-		Platform:   types.StringPointerValue(newState.Platform),
-		Safe:       types.StringPointerValue(newState.SafeName),
-		SecretType: types.StringPointerValue(newState.SecretType),
-		Secret:     data.Secret, // Secret is not returned by the API
-		ID:         types.StringPointerValue(newState.CredID),
+		Backend:                          data.Backend,
+		Name:                             types.StringPointerValue(newState.Name),
+		Address:                          types.StringPointerValue(newState.Address),
+		Username:                         types.StringPointerValue(newState.UserName),
+		Platform:                         types.StringPointerValue(newState.Platform),
+		Safe:                             types.StringPointerValue(newState.SafeName),
+		SecretType:                       types.StringPointerValue(newState.SecretType),
+		Secret:                           data.Secret, // Secret is not returned by the API
+		AccountGroupID:                   data.AccountGroupID,
+		RemoteMachines:                   data.RemoteMachines,
+		AccessRestrictedToRemoteMachines: data.AccessRestrictedToRemoteMachines,
+		Folder:                           data.Folder, // Folder is not returned by the API
+		ID:                               types.StringPointerValue(newState.CredID),
 	}
 
 	if newState.Props != nil {
@@ -302,6 +375,9 @@ func (r *azureAccountResource) Read(ctx context.Context, req resource.ReadReques
 		data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
 	}
 
+	data.RemoteMachines, data.AccessRestrictedToRemoteMachines = readRemoteAccess(newState.RemoteAccess, data.RemoteMachines, data.AccessRestrictedToRemoteMachines)
+	data.AccountGroupID = readAccountGroup(ctx, pam, data.ID.ValueString(), data.AccountGroupID, &resp.Diagnostics)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -318,6 +394,16 @@ func (r *azureAccountResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remoteAccess := remoteAccessFromModel(ctx, data.RemoteMachines, data.AccessRestrictedToRemoteMachines, state.RemoteMachines, state.AccessRestrictedToRemoteMachines, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	updatedAccount := cybrapi.Credential{
 		Name:     data.Name.ValueStringPointer(),
 		Address:  data.Address.ValueStringPointer(),
@@ -340,9 +426,10 @@ func (r *azureAccountResource) Update(ctx context.Context, req resource.UpdateRe
 			AutomaticManagement:    data.Manage.ValueBoolPointer(),
 			ManualManagementReason: data.ManageReason.ValueStringPointer(),
 		},
+		RemoteAccess: remoteAccess,
 	}
 
-	account, err := r.api.PamAPI.UpdateAccount(ctx, state.ID.ValueString(), updatedAccount)
+	account, err := pam.UpdateAccount(ctx, state.ID.ValueString(), updatedAccount)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating account", err.Error())
 		return
@@ -350,6 +437,7 @@ func (r *azureAccountResource) Update(ctx context.Context, req resource.UpdateRe
 
 	// Update the ID in case it changed
 	data.ID = types.StringPointerValue(account.CredID)
+	setAccountGroup(ctx, pam, data.ID.ValueString(), state.AccountGroupID, data.AccountGroupID, &resp.Diagnostics)
 
 	// Update last updated time
 	if account.SecretMgmt != nil && account.SecretMgmt.ModifiedTime != nil {
@@ -372,13 +460,49 @@ func (r *azureAccountResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
-	err := r.api.PamAPI.DeleteAccount(ctx, state.ID.ValueString())
+	state.Backend = backendOrDefault(state.Backend, r.defaultBackend)
+
+	pam := pamAPIForBackend(r.api, state.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := pam.DeleteAccount(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting account", err.Error())
 		return
 	}
 }
 
+// ImportState imports an existing account by its ID or by "<safe>/<account name>",
+// optionally prefixed with "<backend>:".
 func (r *azureAccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	backend, importID := splitImportBackend(r.api, req.ID, r.defaultBackend)
+
+	pam := pamAPIForBackend(r.api, backend, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := resolveAccountImportID(ctx, pam, importID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing account", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("backend"), backend)...)
+}
+
+// MoveState moves the state of the deprecated cyberark_pvwa_azure_account alias to the resource.
+func (r *azureAccountResource) MoveState(ctx context.Context) []resource.StateMover {
+	if r.defaultBackend == backendSelfHosted {
+		return nil
+	}
+
+	alias := &azureAccountResource{defaultBackend: backendSelfHosted}
+	aliasSchema := &resource.SchemaResponse{}
+	alias.Schema(ctx, resource.SchemaRequest{}, aliasSchema)
+
+	return []resource.StateMover{pvwaAliasStateMover(aliasSchema.Schema, "azure_account", &azureCredModel{})}
 }
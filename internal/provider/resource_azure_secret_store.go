@@ -50,6 +50,8 @@ type azureSecretStoreModel struct {
 	ResourceGroupName    types.String `tfsdk:"resource_group_name"`
 	ID                   types.String `tfsdk:"id"`
 	LastUpdated          types.String `tfsdk:"last_updated"`
+	ValidateConnection   types.Bool   `tfsdk:"validate_connection"`
+	AdoptExisting        types.Bool   `tfsdk:"adopt_existing"`
 }
 
 // Metadata returns the resource type name.
@@ -73,6 +75,14 @@ For more information click [here](https://docs.cyberark.com/secrets-hub-privileg
 			"last_updated": schema.StringAttribute{
 				Computed: true,
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Manage an existing secret store with the same name instead of failing. The existing store is updated with the configured `app_client_secret`, which cannot be read back to detect drift.",
+				Optional:    true,
+			},
+			"validate_connection": schema.BoolAttribute{
+				Description: "Test the connection from Secrets Hub to the secret store after it is created or updated, and fail the apply with the service's diagnostic message if the test does not succeed.",
+				Optional:    true,
+			},
 			"name": schema.StringAttribute{
 				Description: "Custom Secret Store Name for customizing the object name in a secret store.",
 				Required:    true,
@@ -205,23 +215,36 @@ func (r *azureSecretStoreResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
-	for _, store := range stores.SecretStores {
-		if *store.Name == data.Name.ValueString() && *store.Data.AppClientID == data.AppClientID.ValueString() {
-			tflog.Info(ctx, fmt.Sprintf("Secret store with name %s and account ID %s already exists", data.Name.ValueString(), data.AppClientID.ValueString()))
+	existing := findSecretStoreByName(stores, data.Name.ValueString())
+	if existing != nil && !data.AdoptExisting.ValueBool() {
+		addSecretStoreExistsError(&resp.Diagnostics, "cyberark_azure_secret_store", data.Name.ValueString(), existing.ID)
+		return
+	}
+
+	var output *cybrapi.SecretStoreOutput[cybrapi.AzureAkvData]
+	if existing != nil {
+		// The store type can not be updated
+		updatedStore := newStore
+		updatedStore.Type = nil
 
-			// We assume that secret store is already created
-			data.ID = types.StringValue(store.ID)
-			data.LastUpdated = types.StringPointerValue(store.UpdatedAt)
-			// Save data into Terraform state
-			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		output = adoptSecretStore(ctx, existing.ID, newStore,
+			r.api.SecretsHubAPI.GetAzureAkvSecretStore,
+			func(ctx context.Context, storeID string) (*cybrapi.SecretStoreOutput[cybrapi.AzureAkvData], error) {
+				return r.api.SecretsHubAPI.UpdateAzureAkvSecretStore(ctx, storeID, updatedStore)
+			},
+			true,
+			&resp.Diagnostics,
+		)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		tflog.Info(ctx, "Secret store not found, creating new")
+		output, err = r.api.SecretsHubAPI.AddAzureAkvSecretStore(ctx, newStore)
+		if err != nil {
+			resp.Diagnostics.AddError("Error creating secret store", err.Error())
 			return
 		}
-	}
-
-	output, err := r.api.SecretsHubAPI.AddAzureAkvSecretStore(ctx, newStore)
-	if err != nil {
-		resp.Diagnostics.AddError("Error creating secret store", err.Error())
-		return
 	}
 
 	data.ID = types.StringValue(output.ID)
@@ -229,6 +252,10 @@ func (r *azureSecretStoreResource) Create(ctx context.Context, req resource.Crea
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if data.ValidateConnection.ValueBool() {
+		validateSecretStoreConnection(ctx, r.api.SecretsHubAPI, output.ID, &resp.Diagnostics)
+	}
 }
 
 // Read the resource state.
@@ -253,6 +280,9 @@ func (r *azureSecretStoreResource) Read(ctx context.Context, req resource.ReadRe
 		Type:        types.StringPointerValue(output.Type),
 		ID:          types.StringValue(output.ID),
 		LastUpdated: types.StringPointerValue(output.UpdatedAt),
+		// Not stored by the API
+		ValidateConnection: data.ValidateConnection,
+		AdoptExisting:      data.AdoptExisting,
 	}
 
 	if output.Data != nil {
@@ -316,6 +346,10 @@ func (r *azureSecretStoreResource) Update(ctx context.Context, req resource.Upda
 	data.LastUpdated = types.StringPointerValue(output.UpdatedAt)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if data.ValidateConnection.ValueBool() {
+		validateSecretStoreConnection(ctx, r.api.SecretsHubAPI, output.ID, &resp.Diagnostics)
+	}
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -335,6 +369,22 @@ func (r *azureSecretStoreResource) Delete(ctx context.Context, req resource.Dele
 	}
 }
 
+// ImportState imports an existing secret store by its ID or by "name:<store name>".
 func (r *azureSecretStoreResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id := req.ID
+	if name, ok := importNameFromID(req.ID); ok {
+		stores, err := r.api.SecretsHubAPI.GetAzureAkvSecretStores(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing secret store", err.Error())
+			return
+		}
+
+		id, err = uniqueImportID("secret store", name, secretStoreIDsByName(stores, name))
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing secret store", err.Error())
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
@@ -13,28 +13,43 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &dbAccountResource{}
-	_ resource.ResourceWithConfigure   = &dbAccountResource{}
-	_ resource.ResourceWithImportState = &dbAccountResource{}
+	_ resource.Resource                   = &dbAccountResource{}
+	_ resource.ResourceWithConfigure      = &dbAccountResource{}
+	_ resource.ResourceWithImportState    = &dbAccountResource{}
+	_ resource.ResourceWithValidateConfig = &dbAccountResource{}
+	_ resource.ResourceWithMoveState      = &dbAccountResource{}
+	_ resource.ResourceWithModifyPlan     = &dbAccountResource{}
 )
 
 // NewDBAccountResource is a helper function to simplify the provider implementation.
 func NewDBAccountResource() resource.Resource {
-	return &dbAccountResource{}
+	return &dbAccountResource{defaultBackend: backendPrivilegeCloud}
+}
+
+// NewPVWADBAccountResource returns the deprecated cyberark_pvwa_db_account alias of the resource, which
+// manages accounts in the self-hosted vault by default.
+func NewPVWADBAccountResource() resource.Resource {
+	return &dbAccountResource{defaultBackend: backendSelfHosted}
 }
 
 // dbAccountResource is the resource implementation.
 type dbAccountResource struct {
 	api *cybrapi.API
+
+	// defaultBackend is the backend used when the backend attribute is not set
+	defaultBackend string
 }
 
 // dbCredModel describes the resource data model.
 type dbCredModel struct {
+	Backend                 types.String `tfsdk:"backend"`
 	Name                    types.String `tfsdk:"name"`
 	Address                 types.String `tfsdk:"address"`
 	Username                types.String `tfsdk:"username"`
@@ -49,13 +64,17 @@ type dbCredModel struct {
 	DBDSN                   types.String `tfsdk:"db_dsn"`
 	SecretNameInSecretStore types.String `tfsdk:"secret_name_in_secret_store"`
 
-	Manage       types.Bool   `tfsdk:"sm_manage"`
-	ManageReason types.String `tfsdk:"sm_manage_reason"`
+	Manage                           types.Bool   `tfsdk:"sm_manage"`
+	ManageReason                     types.String `tfsdk:"sm_manage_reason"`
+	AccountGroupID                   types.String `tfsdk:"account_group_id"`
+	RemoteMachines                   types.Set    `tfsdk:"remote_machines"`
+	AccessRestrictedToRemoteMachines types.Bool   `tfsdk:"access_restricted_to_remote_machines"`
+	Folder                           types.String `tfsdk:"folder"`
 }
 
 // Metadata returns the resource type name.
 func (r *dbAccountResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
-	resp.TypeName = req.ProviderTypeName + "_db_account"
+	resp.TypeName = backendTypeName(req.ProviderTypeName, "db_account", r.defaultBackend)
 }
 
 // Schema returns the resource schema.
@@ -63,12 +82,14 @@ func (r *dbAccountResource) Schema(_ context.Context, _ resource.SchemaRequest,
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `Database Account Resource
 
-This resource is responsible for creating a new privileged account that contains all the required DB information as mentioned below in Privilege Cloud.
+This resource is responsible for creating a new privileged account that contains all the required DB information as mentioned below in Privilege Cloud or PAM Self-Hosted.
 
 For more information click [here](https://docs.cyberark.com/privilege-cloud-shared-services/latest/en/Content/WebServices/Add%20Account%20v10.htm).`,
+		DeprecationMessage: backendDeprecationMessage("db_account", r.defaultBackend),
 		Attributes: map[string]schema.Attribute{
+			"backend": backendAttribute(r.defaultBackend),
 			"id": schema.StringAttribute{
-				Description: "CyberArk Privilege Cloud Credential ID- Generated from CyberArk after onboarding account into a safe.",
+				Description: "CyberArk Credential ID- Generated from CyberArk after onboarding account into a safe.",
 				Computed:    true,
 			},
 			"last_updated": schema.StringAttribute{
@@ -112,6 +133,16 @@ For more information click [here](https://docs.cyberark.com/privilege-cloud-shar
 				Description: "Automatic Management of a credential. Optional Value.",
 				Optional:    true,
 			},
+			"account_group_id":                     accountGroupIDAttribute(),
+			"remote_machines":                      remoteMachinesAttribute(),
+			"access_restricted_to_remote_machines": accessRestrictedToRemoteMachinesAttribute(),
+			"folder": schema.StringAttribute{
+				Description: "Folder of the safe to add the credential to. Defaults to the root folder of the safe. The API does not return the folder, so moves outside of Terraform are not detected. Changing it forces a new resource.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"sm_manage_reason": schema.StringAttribute{
 				Description: "If sm_manage is false, provide reason why credential is not managed.",
 				Optional:    true,
@@ -141,7 +172,7 @@ func (r *dbAccountResource) Configure(_ context.Context, req resource.ConfigureR
 	api, ok := req.ProviderData.(*cybrapi.API)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected AzureAkvData Source Configure Type",
+			"Unexpected Resource Configure Type",
 			fmt.Sprintf("Expected *cybrapi.Api, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
@@ -151,6 +182,23 @@ func (r *dbAccountResource) Configure(_ context.Context, req resource.ConfigureR
 	r.api = api
 }
 
+// ValidateConfig validates the resource configuration.
+func (r *dbAccountResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var backend types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("backend"), &backend)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateBackend(backend, &resp.Diagnostics)
+}
+
+// ModifyPlan checks the platform of the account when platform validation is enabled.
+func (r *dbAccountResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyAccountPlan(ctx, r.api, r.defaultBackend, req, resp)
+}
+
 // Create a new resource.
 func (r *dbAccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data dbCredModel
@@ -161,6 +209,16 @@ func (r *dbAccountResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remoteAccess := remoteAccessFromModel(ctx, data.RemoteMachines, data.AccessRestrictedToRemoteMachines, types.SetNull(types.StringType), types.BoolNull(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	newAccount := cybrapi.Credential{
 		Name:       data.Name.ValueStringPointer(),
 		Address:    data.Address.ValueStringPointer(),
@@ -179,9 +237,11 @@ func (r *dbAccountResource) Create(ctx context.Context, req resource.CreateReque
 			AutomaticManagement:    data.Manage.ValueBoolPointer(),
 			ManualManagementReason: data.ManageReason.ValueStringPointer(),
 		},
+		RemoteAccess: remoteAccess,
+		Folder:       data.Folder.ValueStringPointer(),
 	}
 
-	accountSearch, err := r.api.PamAPI.FilterAccounts(
+	accountSearch, err := pam.FilterAccounts(
 		ctx,
 		// name,
 		"",
@@ -204,7 +264,7 @@ func (r *dbAccountResource) Create(ctx context.Context, req resource.CreateReque
 
 	if account == nil {
 		tflog.Info(ctx, "Account not found, creating new")
-		account, err = r.api.PamAPI.AddAccount(ctx, newAccount)
+		account, err = pam.AddAccount(ctx, newAccount)
 		if err != nil {
 			resp.Diagnostics.AddError("Error creating account", err.Error())
 			return
@@ -215,6 +275,7 @@ func (r *dbAccountResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	data.ID = types.StringPointerValue(account.CredID)
+	setAccountGroup(ctx, pam, data.ID.ValueString(), types.StringNull(), data.AccountGroupID, &resp.Diagnostics)
 
 	// Set last updated time to last updated time in the vault
 	if account.SecretMgmt != nil && account.SecretMgmt.ModifiedTime != nil {
@@ -238,21 +299,33 @@ func (r *dbAccountResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	newState, err := r.api.PamAPI.GetAccount(ctx, data.ID.ValueString())
+	data.Backend = backendOrDefault(data.Backend, r.defaultBackend)
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	newState, err := pam.GetAccount(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading account", err.Error())
 		return
 	}
 
 	data = dbCredModel{
-		Name:       types.StringPointerValue(newState.Name),
-		Address:    types.StringPointerValue(newState.Address),
-		Username:   types.StringPointerValue(newState.UserName),
-		Platform:   types.StringPointerValue(newState.Platform),
-		Safe:       types.StringPointerValue(newState.SafeName),
-		SecretType: types.StringPointerValue(newState.SecretType),
-		Secret:     data.Secret, // Secret is not returned by the API
-		ID:         types.StringPointerValue(newState.CredID),
+		Backend:                          data.Backend,
+		Name:                             types.StringPointerValue(newState.Name),
+		Address:                          types.StringPointerValue(newState.Address),
+		Username:                         types.StringPointerValue(newState.UserName),
+		Platform:                         types.StringPointerValue(newState.Platform),
+		Safe:                             types.StringPointerValue(newState.SafeName),
+		SecretType:                       types.StringPointerValue(newState.SecretType),
+		Secret:                           data.Secret, // Secret is not returned by the API
+		AccountGroupID:                   data.AccountGroupID,
+		RemoteMachines:                   data.RemoteMachines,
+		AccessRestrictedToRemoteMachines: data.AccessRestrictedToRemoteMachines,
+		Folder:                           data.Folder, // Folder is not returned by the API
+		ID:                               types.StringPointerValue(newState.CredID),
 	}
 
 	if newState.Props != nil {
@@ -275,6 +348,9 @@ func (r *dbAccountResource) Read(ctx context.Context, req resource.ReadRequest,
 		data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
 	}
 
+	data.RemoteMachines, data.AccessRestrictedToRemoteMachines = readRemoteAccess(newState.RemoteAccess, data.RemoteMachines, data.AccessRestrictedToRemoteMachines)
+	data.AccountGroupID = readAccountGroup(ctx, pam, data.ID.ValueString(), data.AccountGroupID, &resp.Diagnostics)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -291,6 +367,16 @@ func (r *dbAccountResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remoteAccess := remoteAccessFromModel(ctx, data.RemoteMachines, data.AccessRestrictedToRemoteMachines, state.RemoteMachines, state.AccessRestrictedToRemoteMachines, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	updatedAccount := cybrapi.Credential{
 		Name:     data.Name.ValueStringPointer(),
 		Address:  data.Address.ValueStringPointer(),
@@ -309,9 +395,10 @@ func (r *dbAccountResource) Update(ctx context.Context, req resource.UpdateReque
 			AutomaticManagement:    data.Manage.ValueBoolPointer(),
 			ManualManagementReason: data.ManageReason.ValueStringPointer(),
 		},
+		RemoteAccess: remoteAccess,
 	}
 
-	account, err := r.api.PamAPI.UpdateAccount(ctx, state.ID.ValueString(), updatedAccount)
+	account, err := pam.UpdateAccount(ctx, state.ID.ValueString(), updatedAccount)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating account", err.Error())
 		return
@@ -319,6 +406,7 @@ func (r *dbAccountResource) Update(ctx context.Context, req resource.UpdateReque
 
 	// Update the ID in case it changed
 	data.ID = types.StringPointerValue(account.CredID)
+	setAccountGroup(ctx, pam, data.ID.ValueString(), state.AccountGroupID, data.AccountGroupID, &resp.Diagnostics)
 
 	// Update last updated time
 	if account.SecretMgmt != nil && account.SecretMgmt.ModifiedTime != nil {
@@ -341,13 +429,49 @@ func (r *dbAccountResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	err := r.api.PamAPI.DeleteAccount(ctx, data.ID.ValueString())
+	data.Backend = backendOrDefault(data.Backend, r.defaultBackend)
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := pam.DeleteAccount(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting account", err.Error())
 		return
 	}
 }
 
+// ImportState imports an existing account by its ID or by "<safe>/<account name>",
+// optionally prefixed with "<backend>:".
 func (r *dbAccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	backend, importID := splitImportBackend(r.api, req.ID, r.defaultBackend)
+
+	pam := pamAPIForBackend(r.api, backend, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := resolveAccountImportID(ctx, pam, importID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing account", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("backend"), backend)...)
+}
+
+// MoveState moves the state of the deprecated cyberark_pvwa_db_account alias to the resource.
+func (r *dbAccountResource) MoveState(ctx context.Context) []resource.StateMover {
+	if r.defaultBackend == backendSelfHosted {
+		return nil
+	}
+
+	alias := &dbAccountResource{defaultBackend: backendSelfHosted}
+	aliasSchema := &resource.SchemaResponse{}
+	alias.Schema(ctx, resource.SchemaRequest{}, aliasSchema)
+
+	return []resource.StateMover{pvwaAliasStateMover(aliasSchema.Schema, "db_account", &dbCredModel{})}
 }
@@ -0,0 +1,458 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &discoveredAccountsResource{}
+	_ resource.ResourceWithConfigure      = &discoveredAccountsResource{}
+	_ resource.ResourceWithValidateConfig = &discoveredAccountsResource{}
+)
+
+// discoveredPlatformTypes are the platform types of discovered accounts.
+var discoveredPlatformTypes = []string{
+	"Windows Server Local", "Windows Desktop Local", "Windows Domain", "Unix", "Unix SSH Key",
+	"AWS", "AWS Access Keys", "Azure Password Management",
+}
+
+// NewDiscoveredAccountsResource is a helper function to simplify the provider implementation.
+func NewDiscoveredAccountsResource() resource.Resource {
+	return &discoveredAccountsResource{}
+}
+
+// discoveredAccountsResource defines the resource implementation.
+type discoveredAccountsResource struct {
+	api *cybrapi.API
+}
+
+// discoveredAccountsModel describes the resource data model.
+type discoveredAccountsModel struct {
+	Backend             types.String             `tfsdk:"backend"`
+	ID                  types.String             `tfsdk:"id"`
+	Accounts            []discoveredAccountModel `tfsdk:"accounts"`
+	AccountIDs          types.Map                `tfsdk:"account_ids"`
+	OnboardedAccountIDs types.Map                `tfsdk:"onboarded_account_ids"`
+	LastUpdated         types.String             `tfsdk:"last_updated"`
+}
+
+// discoveredAccountModel describes a single discovered account.
+type discoveredAccountModel struct {
+	UserName           types.String `tfsdk:"user_name"`
+	Address            types.String `tfsdk:"address"`
+	PlatformType       types.String `tfsdk:"platform_type"`
+	Domain             types.String `tfsdk:"domain"`
+	AccountEnabled     types.Bool   `tfsdk:"account_enabled"`
+	Privileged         types.Bool   `tfsdk:"privileged"`
+	PrivilegedCriteria types.String `tfsdk:"privileged_criteria"`
+	OSGroups           types.String `tfsdk:"os_groups"`
+	UserDisplayName    types.String `tfsdk:"user_display_name"`
+	Description        types.String `tfsdk:"description"`
+	OSFamily           types.String `tfsdk:"os_family"`
+	OSVersion          types.String `tfsdk:"os_version"`
+	OrganizationalUnit types.String `tfsdk:"organizational_unit"`
+	Properties         types.Map    `tfsdk:"properties"`
+	OnboardSafeName    types.String `tfsdk:"onboard_safe_name"`
+	OnboardPlatformID  types.String `tfsdk:"onboard_platform_id"`
+}
+
+// key returns the key of the account in account_ids and onboarded_account_ids. The vault
+// identifies discovered accounts by their user name and address.
+func (m discoveredAccountModel) key() string {
+	return m.UserName.ValueString() + "@" + m.Address.ValueString()
+}
+
+// Metadata returns the resource type name.
+func (r *discoveredAccountsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_discovered_accounts"
+}
+
+// Schema returns the resource schema.
+func (r *discoveredAccountsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Discovered Accounts Resource
+
+This resource pushes accounts found by external discovery tooling to the discovered (pending) accounts list of the vault, where they are reviewed before they are onboarded. Accounts with ` + "`onboard_safe_name`" + ` are onboarded to that safe right away.
+
+Accounts that are already listed are updated. The API cannot remove single discovered accounts, so accounts removed from the configuration, and all accounts when the resource is destroyed, stay in the list until they are onboarded or deleted in the vault.
+
+For more information click [here](https://docs.cyberark.com/privilege-cloud-shared-services/latest/en/content/webservices/add-discovered-accounts-v10.htm).`,
+		Attributes: map[string]schema.Attribute{
+			"backend": backendAttribute(backendPrivilegeCloud),
+			"id": schema.StringAttribute{
+				Description: "Identifier of the resource, derived from the accounts it was created with.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+			"accounts": schema.ListNestedAttribute{
+				Description: "The discovered accounts. User names and addresses must be unique.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user_name": schema.StringAttribute{
+							Description: "The user name of the account.",
+							Required:    true,
+						},
+						"address": schema.StringAttribute{
+							Description: "The address of the machine or the domain the account was discovered on.",
+							Required:    true,
+						},
+						"platform_type": schema.StringAttribute{
+							Description: "The platform type of the account: " + strings.Join(discoveredPlatformTypes, ", ") + ".",
+							Required:    true,
+						},
+						"domain": schema.StringAttribute{
+							Description: "The domain of a domain account.",
+							Optional:    true,
+						},
+						"account_enabled": schema.BoolAttribute{
+							Description: "Whether the account is enabled on the target system.",
+							Optional:    true,
+						},
+						"privileged": schema.BoolAttribute{
+							Description: "Whether the account is privileged.",
+							Optional:    true,
+						},
+						"privileged_criteria": schema.StringAttribute{
+							Description: "Why the account is privileged, e.g. `Domain Admins`.",
+							Optional:    true,
+						},
+						"os_groups": schema.StringAttribute{
+							Description: "The OS groups of the account.",
+							Optional:    true,
+						},
+						"user_display_name": schema.StringAttribute{
+							Description: "The display name of the account.",
+							Optional:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "The description of the account.",
+							Optional:    true,
+						},
+						"os_family": schema.StringAttribute{
+							Description: "The OS family of the machine, e.g. `Windows` or `Linux`.",
+							Optional:    true,
+						},
+						"os_version": schema.StringAttribute{
+							Description: "The OS version of the machine.",
+							Optional:    true,
+						},
+						"organizational_unit": schema.StringAttribute{
+							Description: "The organizational unit of a domain account.",
+							Optional:    true,
+						},
+						"properties": schema.MapAttribute{
+							Description: "Properties specific to the platform type, such as `SID`, `UID`, `GID`, `AWSAccountID` or `SSHKeyFingerprint`.",
+							ElementType: types.StringType,
+							Optional:    true,
+						},
+						"onboard_safe_name": schema.StringAttribute{
+							Description: "The safe to onboard the discovered account to, with `onboard_platform_id`. The account is onboarded once, when it is added.",
+							Optional:    true,
+						},
+						"onboard_platform_id": schema.StringAttribute{
+							Description: "The platform to onboard the discovered account with. Required with `onboard_safe_name`.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"account_ids": schema.MapAttribute{
+				Description: "The IDs of the accounts in the discovered accounts list, keyed by `<user_name>@<address>`.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"onboarded_account_ids": schema.MapAttribute{
+				Description: "The IDs of the onboarded accounts, keyed by `<user_name>@<address>`.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *discoveredAccountsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(*cybrapi.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cybrapi.Api, got: %T. Please report this issue to the provider developers", req.ProviderData),
+		)
+		return
+	}
+
+	r.api = api
+}
+
+// ValidateConfig validates the resource configuration.
+func (r *discoveredAccountsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data discoveredAccountsModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateBackend(data.Backend, &resp.Diagnostics)
+
+	keys := map[string]bool{}
+	for i, account := range data.Accounts {
+		accountPath := path.Root("accounts").AtListIndex(i)
+
+		platformType := account.PlatformType
+		if !platformType.IsNull() && !platformType.IsUnknown() && !slices.Contains(discoveredPlatformTypes, platformType.ValueString()) {
+			resp.Diagnostics.AddAttributeError(accountPath.AtName("platform_type"), "Invalid Configuration",
+				fmt.Sprintf("platform_type must be one of %s, got %s.", strings.Join(discoveredPlatformTypes, ", "), platformType.ValueString()))
+		}
+
+		if account.OnboardSafeName.IsNull() != account.OnboardPlatformID.IsNull() {
+			resp.Diagnostics.AddAttributeError(accountPath, "Invalid Configuration",
+				"onboard_safe_name and onboard_platform_id must be set together.")
+		}
+
+		if account.UserName.IsUnknown() || account.Address.IsUnknown() {
+			continue
+		}
+		if keys[account.key()] {
+			resp.Diagnostics.AddAttributeError(accountPath, "Duplicate Account",
+				fmt.Sprintf("The account %s is listed more than once.", account.key()))
+		}
+		keys[account.key()] = true
+	}
+}
+
+// Create a new resource.
+func (r *discoveredAccountsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data discoveredAccountsModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(discoveredAccountsID(data.Accounts))
+	if !r.apply(ctx, pam, &data, map[string]string{}, &resp.Diagnostics) {
+		return
+	}
+
+	// Save data into Terraform state, also when adding or onboarding failed partway
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read the resource and set the Terraform state.
+func (r *discoveredAccountsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data discoveredAccountsModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	listed, err := pam.ListDiscoveredAccounts(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading discovered accounts", err.Error())
+		return
+	}
+
+	// Accounts that were onboarded or deleted in the vault are no longer listed
+	ids := mapStrings(data.AccountIDs)
+	for key, id := range ids {
+		if !slices.ContainsFunc(listed, func(account *cybrapi.DiscoveredAccount) bool {
+			return account.ID != nil && *account.ID == id
+		}) {
+			delete(ids, key)
+		}
+	}
+	data.AccountIDs = stringMap(ids)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *discoveredAccountsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state discoveredAccountsModel
+
+	// Read Terraform plan data and current state into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = state.ID
+	if !r.apply(ctx, pam, &data, mapStrings(state.OnboardedAccountIDs), &resp.Diagnostics) {
+		return
+	}
+
+	// Save the accounts that were added or onboarded, also when adding or onboarding failed partway
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the resource from the Terraform state. The discovered accounts stay in the vault.
+func (r *discoveredAccountsResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	tflog.Info(ctx, "Discovered accounts cannot be removed individually and are kept in the discovered accounts list")
+}
+
+// apply adds the accounts that were not onboarded yet to the discovered accounts list and
+// onboards the ones with a safe, then sets the account IDs of data. It returns false if the
+// accounts are invalid and nothing was changed in the vault.
+//
+// When adding or onboarding fails, data keeps the IDs of the accounts added or onboarded before the
+// failure, and only the accounts that are done, so that the state records them and the next
+// apply adds or onboards the others again.
+func (r *discoveredAccountsResource) apply(ctx context.Context, pam cybrapi.PAMAPI, data *discoveredAccountsModel, onboarded map[string]string, diags *diag.Diagnostics) bool {
+	var pending []discoveredAccountModel
+	var accounts []cybrapi.DiscoveredAccount
+	for _, account := range data.Accounts {
+		if _, ok := onboarded[account.key()]; ok {
+			continue
+		}
+		pending = append(pending, account)
+		accounts = append(accounts, discoveredAccountFromModel(ctx, account, diags))
+	}
+	if diags.HasError() {
+		return false
+	}
+
+	// Forget onboarded accounts which are no longer configured
+	for key := range onboarded {
+		if !slices.ContainsFunc(data.Accounts, func(account discoveredAccountModel) bool { return account.key() == key }) {
+			delete(onboarded, key)
+		}
+	}
+
+	ids := map[string]string{}
+	defer func() {
+		if diags.HasError() {
+			data.Accounts = slices.DeleteFunc(data.Accounts, func(account discoveredAccountModel) bool {
+				_, isOnboarded := onboarded[account.key()]
+				_, added := ids[account.key()]
+				return !isOnboarded && !(added && account.OnboardSafeName.IsNull())
+			})
+		}
+
+		data.AccountIDs = stringMap(ids)
+		data.OnboardedAccountIDs = stringMap(onboarded)
+		data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+	}()
+
+	added, err := pam.AddDiscoveredAccounts(ctx, accounts)
+	for i, response := range added {
+		if response.ID != nil {
+			ids[pending[i].key()] = *response.ID
+		}
+	}
+	if err != nil {
+		diags.AddError("Error adding discovered accounts",
+			fmt.Sprintf("Added %d of %d accounts: %s", len(added), len(accounts), err))
+		return true
+	}
+
+	for _, account := range pending {
+		id, ok := ids[account.key()]
+		if !ok || account.OnboardSafeName.IsNull() {
+			continue
+		}
+
+		credential, err := pam.OnboardDiscoveredAccount(ctx, id, cybrapi.OnboardDiscoveredAccountRequest{
+			SafeName:   account.OnboardSafeName.ValueStringPointer(),
+			PlatformID: account.OnboardPlatformID.ValueStringPointer(),
+		})
+		if err != nil {
+			diags.AddError("Error onboarding discovered account",
+				fmt.Sprintf("Could not onboard %s to safe %s: %s", account.key(), account.OnboardSafeName.ValueString(), err))
+			return true
+		}
+
+		delete(ids, account.key())
+		if credential.CredID != nil {
+			onboarded[account.key()] = *credential.CredID
+		}
+	}
+
+	return true
+}
+
+// discoveredAccountFromModel returns the discovered account to add for an account of the
+// resource, discovered now.
+func discoveredAccountFromModel(ctx context.Context, account discoveredAccountModel, diags *diag.Diagnostics) cybrapi.DiscoveredAccount {
+	discoveryDate := time.Now().Unix()
+	discovered := cybrapi.DiscoveredAccount{
+		UserName:           account.UserName.ValueStringPointer(),
+		Address:            account.Address.ValueStringPointer(),
+		PlatformType:       account.PlatformType.ValueStringPointer(),
+		DiscoveryDate:      &discoveryDate,
+		Domain:             account.Domain.ValueStringPointer(),
+		AccountEnabled:     account.AccountEnabled.ValueBoolPointer(),
+		Privileged:         account.Privileged.ValueBoolPointer(),
+		PrivilegedCriteria: account.PrivilegedCriteria.ValueStringPointer(),
+		OSGroups:           account.OSGroups.ValueStringPointer(),
+		UserDisplayName:    account.UserDisplayName.ValueStringPointer(),
+		Description:        account.Description.ValueStringPointer(),
+		OSFamily:           account.OSFamily.ValueStringPointer(),
+		OSVersion:          account.OSVersion.ValueStringPointer(),
+		OrganizationalUnit: account.OrganizationalUnit.ValueStringPointer(),
+	}
+	if !account.Properties.IsNull() {
+		diags.Append(account.Properties.ElementsAs(ctx, &discovered.AdditionalProperties, false)...)
+	}
+	return discovered
+}
+
+// discoveredAccountsID returns an ID for a discovered accounts resource created with the accounts.
+func discoveredAccountsID(accounts []discoveredAccountModel) string {
+	keys := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		keys = append(keys, account.key())
+	}
+	sort.Strings(keys)
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(keys, "\n"))))[:16]
+}
@@ -45,6 +45,8 @@ type gcpSecretStoreModel struct {
 	ServiceAccountEmail          types.String `tfsdk:"service_account_email"`
 	ID                           types.String `tfsdk:"id"`
 	LastUpdated                  types.String `tfsdk:"last_updated"`
+	ValidateConnection           types.Bool   `tfsdk:"validate_connection"`
+	AdoptExisting                types.Bool   `tfsdk:"adopt_existing"`
 }
 
 // Metadata returns the resource type name.
@@ -69,6 +71,14 @@ For more information, visit the CyberArk documentation.`,
 			"last_updated": schema.StringAttribute{
 				Computed: true,
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Manage an existing secret store with the same name instead of failing. The existing store is compared with the configuration and updated if it differs.",
+				Optional:    true,
+			},
+			"validate_connection": schema.BoolAttribute{
+				Description: "Test the connection from Secrets Hub to the secret store after it is created or updated, and fail the apply with the service's diagnostic message if the test does not succeed.",
+				Optional:    true,
+			},
 			"name": schema.StringAttribute{
 				Description: "Custom Secret Store Name for customizing the object name in a secret store.",
 				Required:    true,
@@ -188,23 +198,40 @@ func (r *gcpSecretStoreResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	for _, store := range stores.SecretStores {
-		if *store.Name == data.Name.ValueString() && *store.Data.GcpProjectName == data.GcpProjectName.ValueString() {
-			tflog.Info(ctx, fmt.Sprintf("Secret store with name %s and Gcp Project %s already exists", data.Name.ValueString(), data.GcpProjectName.ValueString()))
+	existing := findSecretStoreByName(stores, data.Name.ValueString())
+	if existing != nil && !data.AdoptExisting.ValueBool() {
+		addSecretStoreExistsError(&resp.Diagnostics, "cyberark_gcp_secret_store", data.Name.ValueString(), existing.ID)
+		return
+	}
 
-			// We assume that secret store is already created
-			data.ID = types.StringValue(store.ID)
-			data.LastUpdated = types.StringPointerValue(store.UpdatedAt)
-			// Save data into Terraform state
-			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	var output *cybrapi.SecretStoreOutput[cybrapi.GcpData]
+	if existing != nil {
+		// The store type and GCP project number can not be updated
+		updatedData := *newStore.Data
+		updatedData.GcpProjectNumber = nil
+		updatedStore := newStore
+		updatedStore.Type = nil
+		updatedStore.Data = &updatedData
+
+		output = adoptSecretStore(ctx, existing.ID, newStore,
+			r.api.SecretsHubAPI.GetGcpSecretStore,
+			func(ctx context.Context, storeID string) (*cybrapi.SecretStoreOutput[cybrapi.GcpData], error) {
+				return r.api.SecretsHubAPI.UpdateGcpSecretStore(ctx, storeID, updatedStore)
+			},
+			false,
+			&resp.Diagnostics,
+			"data.gcpProjectNumber",
+		)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		tflog.Info(ctx, "Secret store not found, creating new")
+		output, err = r.api.SecretsHubAPI.AddGcpSecretStore(ctx, newStore)
+		if err != nil {
+			resp.Diagnostics.AddError("Error creating secret store", err.Error())
 			return
 		}
-	}
-
-	output, err := r.api.SecretsHubAPI.AddGcpSecretStore(ctx, newStore)
-	if err != nil {
-		resp.Diagnostics.AddError("Error creating secret store", err.Error())
-		return
 	}
 
 	data.ID = types.StringValue(output.ID)
@@ -212,6 +239,10 @@ func (r *gcpSecretStoreResource) Create(ctx context.Context, req resource.Create
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if data.ValidateConnection.ValueBool() {
+		validateSecretStoreConnection(ctx, r.api.SecretsHubAPI, output.ID, &resp.Diagnostics)
+	}
 }
 
 // Read the resource state.
@@ -236,6 +267,9 @@ func (r *gcpSecretStoreResource) Read(ctx context.Context, req resource.ReadRequ
 		Type:        types.StringPointerValue(output.Type),
 		ID:          types.StringValue(output.ID),
 		LastUpdated: types.StringPointerValue(output.UpdatedAt),
+		// Not stored by the API
+		ValidateConnection: data.ValidateConnection,
+		AdoptExisting:      data.AdoptExisting,
 	}
 
 	if output.Data != nil {
@@ -290,6 +324,10 @@ func (r *gcpSecretStoreResource) Update(ctx context.Context, req resource.Update
 	data.LastUpdated = types.StringPointerValue(output.UpdatedAt)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if data.ValidateConnection.ValueBool() {
+		validateSecretStoreConnection(ctx, r.api.SecretsHubAPI, output.ID, &resp.Diagnostics)
+	}
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -309,6 +347,22 @@ func (r *gcpSecretStoreResource) Delete(ctx context.Context, req resource.Delete
 	}
 }
 
+// ImportState imports an existing secret store by its ID or by "name:<store name>".
 func (r *gcpSecretStoreResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id := req.ID
+	if name, ok := importNameFromID(req.ID); ok {
+		stores, err := r.api.SecretsHubAPI.GetGcpSecretStores(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing secret store", err.Error())
+			return
+		}
+
+		id, err = uniqueImportID("secret store", name, secretStoreIDsByName(stores, name))
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing secret store", err.Error())
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
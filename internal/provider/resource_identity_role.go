@@ -0,0 +1,204 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &identityRoleResource{}
+	_ resource.ResourceWithConfigure   = &identityRoleResource{}
+	_ resource.ResourceWithImportState = &identityRoleResource{}
+)
+
+// NewIdentityRoleResource is a helper function to simplify the provider implementation.
+func NewIdentityRoleResource() resource.Resource {
+	return &identityRoleResource{}
+}
+
+// identityRoleResource defines the resource implementation.
+type identityRoleResource struct {
+	api *cybrapi.API
+}
+
+// identityRoleModel describes the resource data model.
+type identityRoleModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+}
+
+// Metadata returns the resource type name.
+func (r *identityRoleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_identity_role"
+}
+
+// Schema returns the resource schema.
+func (r *identityRoleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `CyberArk Identity Role Resource
+
+This resource manages a role in the CyberArk Identity tenant of Privilege Cloud. Roles can be added to safes as members with ` + "`member_type = \"role\"`" + `.
+
+For more information click [here](https://docs.cyberark.com/identity/latest/en/content/coreservices/usersroles/roles.htm).`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "CyberArk Identity Role ID- Generated from CyberArk after adding the role.",
+				Computed:    true,
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the role. Changing it forces a new resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the role.",
+				Optional:    true,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *identityRoleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(*cybrapi.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cybrapi.Api, got: %T. Please report this issue to the provider developers", req.ProviderData),
+		)
+		return
+	}
+
+	r.api = api
+}
+
+// Create a new resource.
+func (r *identityRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data identityRoleModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, err := r.api.IdentityAPI.AddRole(ctx, cybrapi.IdentityRole{
+		Name:        data.Name.ValueStringPointer(),
+		Description: knownStringPointer(data.Description),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating identity role", err.Error())
+		return
+	}
+
+	setIdentityRoleModel(&data, role)
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read the resource and set the Terraform state.
+func (r *identityRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data identityRoleModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, err := r.api.IdentityAPI.GetRole(ctx, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading identity role", err.Error())
+		return
+	}
+
+	setIdentityRoleModel(&data, role)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *identityRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state identityRoleModel
+
+	// Read Terraform plan data and current state into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role := cybrapi.IdentityRole{
+		ID:          state.ID.ValueStringPointer(),
+		Name:        data.Name.ValueStringPointer(),
+		Description: knownStringPointer(data.Description),
+	}
+	err := r.api.IdentityAPI.UpdateRole(ctx, state.ID.ValueString(), role)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating identity role", err.Error())
+		return
+	}
+
+	setIdentityRoleModel(&data, &role)
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *identityRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data identityRoleModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.api.IdentityAPI.DeleteRole(ctx, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting identity role", err.Error())
+		return
+	}
+}
+
+// ImportState imports an existing identity role by its ID.
+func (r *identityRoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// setIdentityRoleModel sets the resource data to the identity role returned by the API.
+func setIdentityRoleModel(data *identityRoleModel, role *cybrapi.IdentityRole) {
+	if role.ID != nil {
+		data.ID = types.StringPointerValue(role.ID)
+	}
+	data.Name = stringOrPrior(role.Name, data.Name)
+	data.Description = stringOrPrior(role.Description, data.Description)
+}
@@ -0,0 +1,267 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &identityRoleMemberResource{}
+	_ resource.ResourceWithConfigure      = &identityRoleMemberResource{}
+	_ resource.ResourceWithImportState    = &identityRoleMemberResource{}
+	_ resource.ResourceWithValidateConfig = &identityRoleMemberResource{}
+)
+
+// validIdentityMemberTypes lists the types of members identity roles can have.
+var validIdentityMemberTypes = []string{cybrapi.IdentityMemberUser, cybrapi.IdentityMemberGroup, cybrapi.IdentityMemberRole}
+
+// NewIdentityRoleMemberResource is a helper function to simplify the provider implementation.
+func NewIdentityRoleMemberResource() resource.Resource {
+	return &identityRoleMemberResource{}
+}
+
+// identityRoleMemberResource defines the resource implementation.
+type identityRoleMemberResource struct {
+	api *cybrapi.API
+}
+
+// identityRoleMemberModel describes the resource data model.
+type identityRoleMemberModel struct {
+	ID          types.String `tfsdk:"id"`
+	RoleID      types.String `tfsdk:"role_id"`
+	MemberID    types.String `tfsdk:"member_id"`
+	MemberType  types.String `tfsdk:"member_type"`
+	MemberName  types.String `tfsdk:"member_name"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+}
+
+// Metadata returns the resource type name.
+func (r *identityRoleMemberResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_identity_role_member"
+}
+
+// Schema returns the resource schema.
+func (r *identityRoleMemberResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `CyberArk Identity Role Member Resource
+
+This resource adds a user, group or role to a CyberArk Identity role.
+
+For more information click [here](https://docs.cyberark.com/identity/latest/en/content/coreservices/usersroles/roles.htm).`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the membership, in the format <role_id>/<member_id>.",
+				Computed:    true,
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+			"role_id": schema.StringAttribute{
+				Description: "The ID of the role. Changing it forces a new resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"member_id": schema.StringAttribute{
+				Description: "The ID of the user, group or role to add to the role. Changing it forces a new resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"member_type": schema.StringAttribute{
+				Description: fmt.Sprintf("The type of the member: `%s`. Defaults to `%s`. Changing it forces a new resource.",
+					strings.Join(validIdentityMemberTypes, "`, `"), cybrapi.IdentityMemberUser),
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(cybrapi.IdentityMemberUser),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"member_name": schema.StringAttribute{
+				Description: "The name of the member.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *identityRoleMemberResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(*cybrapi.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cybrapi.Api, got: %T. Please report this issue to the provider developers", req.ProviderData),
+		)
+		return
+	}
+
+	r.api = api
+}
+
+// ValidateConfig validates the resource configuration.
+func (r *identityRoleMemberResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data identityRoleMemberModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.MemberType.IsNull() || data.MemberType.IsUnknown() {
+		return
+	}
+
+	for _, memberType := range validIdentityMemberTypes {
+		if data.MemberType.ValueString() == memberType {
+			return
+		}
+	}
+	resp.Diagnostics.AddAttributeError(path.Root("member_type"), "Invalid member type",
+		fmt.Sprintf("member_type must be one of %s, got: %s", strings.Join(validIdentityMemberTypes, ", "), data.MemberType.ValueString()))
+}
+
+// Create a new resource.
+func (r *identityRoleMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data identityRoleMemberModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleID, memberID := data.RoleID.ValueString(), data.MemberID.ValueString()
+	err := r.api.IdentityAPI.AddRoleMember(ctx, roleID, data.MemberType.ValueString(), memberID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error adding identity role member", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(roleID + "/" + memberID)
+	data.MemberName = types.StringNull()
+
+	members, err := r.api.IdentityAPI.ListRoleMembers(ctx, roleID)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to read identity role members", err.Error())
+	} else if member := findIdentityRoleMember(members, memberID); member != nil {
+		data.MemberName = types.StringPointerValue(member.Name)
+	}
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read the resource and set the Terraform state.
+func (r *identityRoleMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data identityRoleMemberModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, err := r.api.IdentityAPI.ListRoleMembers(ctx, data.RoleID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading identity role members", err.Error())
+		return
+	}
+
+	member := findIdentityRoleMember(members, data.MemberID.ValueString())
+	if member == nil {
+		// The member was removed outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.MemberName = types.StringPointerValue(member.Name)
+	data.MemberType = stringOrPrior(member.Type, data.MemberType)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success. All attributes
+// force a new resource, so there is nothing to update in Identity.
+func (r *identityRoleMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state identityRoleMemberModel
+
+	// Read Terraform plan data and current state into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = state.ID
+	data.MemberName = state.MemberName
+	data.LastUpdated = state.LastUpdated
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *identityRoleMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data identityRoleMemberModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.api.IdentityAPI.RemoveRoleMember(ctx, data.RoleID.ValueString(), data.MemberType.ValueString(), data.MemberID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error removing identity role member", err.Error())
+		return
+	}
+}
+
+// ImportState imports an existing role membership by an ID in the format <role_id>/<member_id>.
+// The member type is read from the role.
+func (r *identityRoleMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	roleID, memberID, ok := strings.Cut(req.ID, "/")
+	if !ok || roleID == "" || memberID == "" {
+		resp.Diagnostics.AddError("Invalid import ID",
+			fmt.Sprintf("Expected an import ID in the format <role_id>/<member_id>, got: %s", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_id"), roleID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("member_id"), memberID)...)
+}
+
+// findIdentityRoleMember returns the member of a role with the given ID, or nil if there is none.
+func findIdentityRoleMember(members []*cybrapi.IdentityRoleMember, memberID string) *cybrapi.IdentityRoleMember {
+	for _, member := range members {
+		if member.GUID != nil && strings.EqualFold(*member.GUID, memberID) {
+			return member
+		}
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &identityServiceUserResource{}
+	_ resource.ResourceWithConfigure   = &identityServiceUserResource{}
+	_ resource.ResourceWithImportState = &identityServiceUserResource{}
+)
+
+// NewIdentityServiceUserResource is a helper function to simplify the provider implementation.
+func NewIdentityServiceUserResource() resource.Resource {
+	return &identityServiceUserResource{}
+}
+
+// identityServiceUserResource defines the resource implementation.
+type identityServiceUserResource struct {
+	api *cybrapi.API
+}
+
+// identityServiceUserModel describes the resource data model.
+type identityServiceUserModel struct {
+	ID          types.String `tfsdk:"id"`
+	Username    types.String `tfsdk:"username"`
+	Password    types.String `tfsdk:"password"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Description types.String `tfsdk:"description"`
+	Email       types.String `tfsdk:"email"`
+	ClientID    types.String `tfsdk:"client_id"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+}
+
+// Metadata returns the resource type name.
+func (r *identityServiceUserResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_identity_service_user"
+}
+
+// Schema returns the resource schema.
+func (r *identityServiceUserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `CyberArk Identity Service User Resource
+
+This resource manages a service user in the CyberArk Identity tenant of Privilege Cloud. The user is an OAuth2 confidential client, which authenticates with its ` + "`client_id`" + ` and its password as the client secret.
+
+For more information click [here](https://docs.cyberark.com/identity/latest/en/content/developer/oauth/oauth-client-creds.htm).`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "CyberArk Identity User ID- Generated from CyberArk after adding the user.",
+				Computed:    true,
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+			"username": schema.StringAttribute{
+				Description: "The login name of the user, including the login suffix of the tenant, e.g. `svc-terraform@example.cyberark.cloud.1234`. Changing it forces a new resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"password": schema.StringAttribute{
+				Description: "The password of the user, which is the client secret of the confidential client.",
+				Required:    true,
+				Sensitive:   true,
+			},
+			"display_name": schema.StringAttribute{
+				Description: "The display name of the user.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the user.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"email": schema.StringAttribute{
+				Description: "The email address of the user.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"client_id": schema.StringAttribute{
+				Description: "The client ID the confidential client authenticates with.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *identityServiceUserResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(*cybrapi.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cybrapi.Api, got: %T. Please report this issue to the provider developers", req.ProviderData),
+		)
+		return
+	}
+
+	r.api = api
+}
+
+// Create a new resource.
+func (r *identityServiceUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data identityServiceUserModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := r.api.IdentityAPI.AddServiceUser(ctx, cybrapi.IdentityServiceUser{
+		Name:        data.Username.ValueStringPointer(),
+		Password:    data.Password.ValueStringPointer(),
+		DisplayName: knownStringPointer(data.DisplayName),
+		Description: knownStringPointer(data.Description),
+		Mail:        knownStringPointer(data.Email),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating identity service user", err.Error())
+		return
+	}
+
+	setIdentityServiceUserModel(&data, user)
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read the resource and set the Terraform state.
+func (r *identityServiceUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data identityServiceUserModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := r.api.IdentityAPI.GetServiceUser(ctx, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading identity service user", err.Error())
+		return
+	}
+
+	setIdentityServiceUserModel(&data, user)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *identityServiceUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state identityServiceUserModel
+
+	// Read Terraform plan data and current state into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user := cybrapi.IdentityServiceUser{
+		ID:          state.ID.ValueStringPointer(),
+		Name:        data.Username.ValueStringPointer(),
+		DisplayName: knownStringPointer(data.DisplayName),
+		Description: knownStringPointer(data.Description),
+		Mail:        knownStringPointer(data.Email),
+	}
+	err := r.api.IdentityAPI.UpdateServiceUser(ctx, state.ID.ValueString(), user)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating identity service user", err.Error())
+		return
+	}
+
+	if !data.Password.Equal(state.Password) {
+		err = r.api.IdentityAPI.SetServiceUserPassword(ctx, state.ID.ValueString(), []byte(data.Password.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("password"), "Error updating identity service user password", err.Error())
+			return
+		}
+	}
+
+	setIdentityServiceUserModel(&data, &user)
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *identityServiceUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data identityServiceUserModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.api.IdentityAPI.DeleteServiceUser(ctx, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting identity service user", err.Error())
+		return
+	}
+}
+
+// ImportState imports an existing identity service user by its ID. The password cannot be read
+// and has to be set in the configuration.
+func (r *identityServiceUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// setIdentityServiceUserModel sets the resource data to the service user returned by the API.
+// The password cannot be read and is kept as it is.
+func setIdentityServiceUserModel(data *identityServiceUserModel, user *cybrapi.IdentityServiceUser) {
+	if user.ID != nil {
+		data.ID = types.StringPointerValue(user.ID)
+	}
+	data.Username = stringOrPrior(user.Name, data.Username)
+	data.DisplayName = stringOrPrior(user.DisplayName, data.DisplayName)
+	data.Description = stringOrPrior(user.Description, data.Description)
+	data.Email = stringOrPrior(user.Mail, data.Email)
+	data.ClientID = data.Username
+}
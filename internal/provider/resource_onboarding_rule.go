@@ -0,0 +1,456 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &onboardingRuleResource{}
+	_ resource.ResourceWithConfigure      = &onboardingRuleResource{}
+	_ resource.ResourceWithImportState    = &onboardingRuleResource{}
+	_ resource.ResourceWithValidateConfig = &onboardingRuleResource{}
+)
+
+// Values accepted by the filters of onboarding rules.
+var (
+	onboardingSystemTypes      = []string{"Windows", "Unix"}
+	onboardingMachineTypes     = []string{"Any", "Workstation", "Server"}
+	onboardingAccountTypes     = []string{"Any", "Privileged", "Non-privileged"}
+	onboardingFilterMethods    = []string{"Equals", "Begins", "Ends"}
+	onboardingRuleEnumerations = []struct {
+		name   string
+		values []string
+	}{
+		{"system_type_filter", onboardingSystemTypes},
+		{"machine_type_filter", onboardingMachineTypes},
+		{"account_category_filter", onboardingAccountTypes},
+		{"user_name_method", onboardingFilterMethods},
+		{"address_method", onboardingFilterMethods},
+	}
+)
+
+// NewOnboardingRuleResource is a helper function to simplify the provider implementation.
+func NewOnboardingRuleResource() resource.Resource {
+	return &onboardingRuleResource{}
+}
+
+// onboardingRuleResource defines the resource implementation.
+type onboardingRuleResource struct {
+	api *cybrapi.API
+}
+
+// onboardingRuleModel describes the resource data model.
+type onboardingRuleModel struct {
+	Backend               types.String `tfsdk:"backend"`
+	ID                    types.String `tfsdk:"id"`
+	RuleName              types.String `tfsdk:"rule_name"`
+	Description           types.String `tfsdk:"description"`
+	TargetPlatformID      types.String `tfsdk:"target_platform_id"`
+	TargetSafeName        types.String `tfsdk:"target_safe_name"`
+	SystemTypeFilter      types.String `tfsdk:"system_type_filter"`
+	MachineTypeFilter     types.String `tfsdk:"machine_type_filter"`
+	AccountCategoryFilter types.String `tfsdk:"account_category_filter"`
+	IsAdminIDFilter       types.Bool   `tfsdk:"is_admin_id_filter"`
+	UserNameFilter        types.String `tfsdk:"user_name_filter"`
+	UserNameMethod        types.String `tfsdk:"user_name_method"`
+	AddressFilter         types.String `tfsdk:"address_filter"`
+	AddressMethod         types.String `tfsdk:"address_method"`
+	ReconcileAccountID    types.String `tfsdk:"reconcile_account_id"`
+	RulePrecedence        types.Int64  `tfsdk:"rule_precedence"`
+	LastUpdated           types.String `tfsdk:"last_updated"`
+}
+
+// Metadata returns the resource type name.
+func (r *onboardingRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_onboarding_rule"
+}
+
+// Schema returns the resource schema.
+func (r *onboardingRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `CyberArk Automatic Onboarding Rule Resource
+
+This resource manages an automatic onboarding rule, which adds discovered accounts matching its filters to a safe with a platform.
+
+For more information click [here](https://docs.cyberark.com/pam-self-hosted/latest/en/content/webservices/add%20automatic%20onboarding%20rule.htm).`,
+		Attributes: map[string]schema.Attribute{
+			"backend": backendAttribute(backendPrivilegeCloud),
+			"id": schema.StringAttribute{
+				Description: "The numeric ID of the rule.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+			"rule_name": schema.StringAttribute{
+				Description: "The name of the rule. Generated by the vault if not set.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the rule.",
+				Optional:    true,
+			},
+			"target_platform_id": schema.StringAttribute{
+				Description: "The ID of the platform onboarded accounts are added with.",
+				Required:    true,
+			},
+			"target_safe_name": schema.StringAttribute{
+				Description: "The name of the safe onboarded accounts are added to.",
+				Required:    true,
+			},
+			"system_type_filter": schema.StringAttribute{
+				Description: "The system type of the discovered accounts the rule applies to: " + strings.Join(onboardingSystemTypes, ", ") + ".",
+				Required:    true,
+			},
+			"machine_type_filter": schema.StringAttribute{
+				Description: "The machine type of the discovered Windows accounts the rule applies to: " + strings.Join(onboardingMachineTypes, ", ") + ". Defaults to `Any`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("Any"),
+			},
+			"account_category_filter": schema.StringAttribute{
+				Description: "The category of the discovered accounts the rule applies to: " + strings.Join(onboardingAccountTypes, ", ") + ". Defaults to `Any`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("Any"),
+			},
+			"is_admin_id_filter": schema.BoolAttribute{
+				Description: "Whether the rule only applies to accounts with the built-in administrator ID, UID 0 on Unix or RID 500 on Windows. Defaults to `false`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"user_name_filter": schema.StringAttribute{
+				Description: "The user name of the discovered accounts the rule applies to, compared with `user_name_method`.",
+				Optional:    true,
+			},
+			"user_name_method": schema.StringAttribute{
+				Description: "How `user_name_filter` is compared: " + strings.Join(onboardingFilterMethods, ", ") + ". Defaults to `Equals`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("Equals"),
+			},
+			"address_filter": schema.StringAttribute{
+				Description: "The address of the discovered accounts the rule applies to, compared with `address_method`.",
+				Optional:    true,
+			},
+			"address_method": schema.StringAttribute{
+				Description: "How `address_filter` is compared: " + strings.Join(onboardingFilterMethods, ", ") + ". Defaults to `Equals`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("Equals"),
+			},
+			"reconcile_account_id": schema.StringAttribute{
+				Description: "The ID of the account that reconciles onboarded accounts, e.g. `12_3`.",
+				Optional:    true,
+			},
+			"rule_precedence": schema.Int64Attribute{
+				Description: "The precedence of the rule among the rules that apply to an account, starting with 1. Defaults to the lowest precedence.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *onboardingRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(*cybrapi.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cybrapi.Api, got: %T. Please report this issue to the provider developers", req.ProviderData),
+		)
+		return
+	}
+
+	r.api = api
+}
+
+// ValidateConfig validates the resource configuration.
+func (r *onboardingRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data onboardingRuleModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateBackend(data.Backend, &resp.Diagnostics)
+
+	for _, enum := range onboardingRuleEnumerations {
+		var value types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(enum.name), &value)...)
+		if value.IsNull() || value.IsUnknown() || slices.Contains(enum.values, value.ValueString()) {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(path.Root(enum.name), "Invalid Configuration",
+			fmt.Sprintf("%s must be one of %s, got %s.", enum.name, strings.Join(enum.values, ", "), value.ValueString()))
+	}
+
+	if data.SystemTypeFilter.ValueString() == "Unix" && !data.MachineTypeFilter.IsNull() && data.MachineTypeFilter.ValueString() != "Any" {
+		resp.Diagnostics.AddAttributeError(path.Root("machine_type_filter"), "Invalid Configuration",
+			"machine_type_filter only applies to Windows accounts.")
+	}
+
+	if !data.RulePrecedence.IsNull() && !data.RulePrecedence.IsUnknown() && data.RulePrecedence.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("rule_precedence"), "Invalid Configuration",
+			"rule_precedence must be 1 or more.")
+	}
+}
+
+// Create a new resource.
+func (r *onboardingRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data onboardingRuleModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rule, err := pam.AddOnboardingRule(ctx, onboardingRuleFromModel(&data))
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating onboarding rule", err.Error())
+		return
+	}
+
+	setOnboardingRuleModel(&data, rule)
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read the resource and set the Terraform state.
+func (r *onboardingRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data onboardingRuleModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, err := pam.ListOnboardingRules(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading onboarding rules", err.Error())
+		return
+	}
+
+	rule := findOnboardingRule(rules, data.ID.ValueString())
+	if rule == nil {
+		// The rule was deleted outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	setOnboardingRuleModel(&data, rule)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *onboardingRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state onboardingRuleModel
+
+	// Read Terraform plan data and current state into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ruleID, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid onboarding rule ID", err.Error())
+		return
+	}
+
+	rule, err := pam.UpdateOnboardingRule(ctx, ruleID, onboardingRuleFromModel(&data))
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating onboarding rule", err.Error())
+		return
+	}
+
+	data.ID = state.ID
+	setOnboardingRuleModel(&data, rule)
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *onboardingRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data onboardingRuleModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ruleID, err := strconv.Atoi(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid onboarding rule ID", err.Error())
+		return
+	}
+
+	err = pam.DeleteOnboardingRule(ctx, ruleID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting onboarding rule", err.Error())
+		return
+	}
+}
+
+// ImportState imports an existing onboarding rule by its numeric ID, optionally prefixed with "<backend>:".
+func (r *onboardingRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	backend, id := splitImportBackend(r.api, req.ID, backendPrivilegeCloud)
+
+	if _, err := strconv.Atoi(id); err != nil {
+		resp.Diagnostics.AddError("Invalid import ID",
+			fmt.Sprintf("Expected the numeric ID of an onboarding rule, got: %s", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("backend"), backend)...)
+}
+
+// findOnboardingRule returns the rule with the given numeric ID, or nil if there is none.
+func findOnboardingRule(rules []*cybrapi.OnboardingRule, ruleID string) *cybrapi.OnboardingRule {
+	for _, rule := range rules {
+		if rule.RuleID != nil && strconv.Itoa(*rule.RuleID) == ruleID {
+			return rule
+		}
+	}
+	return nil
+}
+
+// onboardingRuleFromModel returns the onboarding rule to add or update for the resource data.
+func onboardingRuleFromModel(data *onboardingRuleModel) cybrapi.OnboardingRule {
+	rule := cybrapi.OnboardingRule{
+		RuleName:              knownStringPointer(data.RuleName),
+		RuleDescription:       data.Description.ValueStringPointer(),
+		TargetPlatformID:      data.TargetPlatformID.ValueStringPointer(),
+		TargetSafeName:        data.TargetSafeName.ValueStringPointer(),
+		SystemTypeFilter:      data.SystemTypeFilter.ValueStringPointer(),
+		MachineTypeFilter:     knownStringPointer(data.MachineTypeFilter),
+		AccountCategoryFilter: knownStringPointer(data.AccountCategoryFilter),
+		IsAdminIDFilter:       knownBoolPointer(data.IsAdminIDFilter),
+		UserNameFilter:        data.UserNameFilter.ValueStringPointer(),
+		UserNameMethod:        knownStringPointer(data.UserNameMethod),
+		AddressFilter:         data.AddressFilter.ValueStringPointer(),
+		AddressMethod:         knownStringPointer(data.AddressMethod),
+		ReconcileAccountID:    data.ReconcileAccountID.ValueStringPointer(),
+	}
+	if !data.RulePrecedence.IsNull() && !data.RulePrecedence.IsUnknown() {
+		precedence := int(data.RulePrecedence.ValueInt64())
+		rule.RulePrecedence = &precedence
+	}
+	return rule
+}
+
+// setOnboardingRuleModel sets the resource data to the onboarding rule returned by the API. The
+// vault returns empty strings for filters that are not set, which are kept null.
+func setOnboardingRuleModel(data *onboardingRuleModel, rule *cybrapi.OnboardingRule) {
+	data.Backend = backendOrDefault(data.Backend, backendPrivilegeCloud)
+	if rule.RuleID != nil {
+		data.ID = types.StringValue(strconv.Itoa(*rule.RuleID))
+	}
+	data.RuleName = stringOrPrior(rule.RuleName, data.RuleName)
+	data.Description = optionalString(rule.RuleDescription, data.Description)
+	if rule.TargetPlatformID != nil {
+		data.TargetPlatformID = types.StringValue(*rule.TargetPlatformID)
+	}
+	if rule.TargetSafeName != nil {
+		data.TargetSafeName = types.StringValue(*rule.TargetSafeName)
+	}
+	if rule.SystemTypeFilter != nil {
+		data.SystemTypeFilter = types.StringValue(*rule.SystemTypeFilter)
+	}
+	data.MachineTypeFilter = stringOrPrior(rule.MachineTypeFilter, data.MachineTypeFilter)
+	data.AccountCategoryFilter = stringOrPrior(rule.AccountCategoryFilter, data.AccountCategoryFilter)
+	if rule.IsAdminIDFilter != nil {
+		data.IsAdminIDFilter = types.BoolValue(*rule.IsAdminIDFilter)
+	}
+	data.UserNameFilter = optionalString(rule.UserNameFilter, data.UserNameFilter)
+	data.UserNameMethod = stringOrPrior(rule.UserNameMethod, data.UserNameMethod)
+	data.AddressFilter = optionalString(rule.AddressFilter, data.AddressFilter)
+	data.AddressMethod = stringOrPrior(rule.AddressMethod, data.AddressMethod)
+	data.ReconcileAccountID = optionalString(rule.ReconcileAccountID, data.ReconcileAccountID)
+	if rule.RulePrecedence != nil {
+		data.RulePrecedence = types.Int64Value(int64(*rule.RulePrecedence))
+	} else if data.RulePrecedence.IsUnknown() {
+		data.RulePrecedence = types.Int64Null()
+	}
+}
+
+// optionalString returns the value of an optional attribute, which is null when the API returns
+// no or an empty value and the attribute was null before.
+func optionalString(value *string, prior types.String) types.String {
+	if value == nil || *value == "" {
+		if prior.IsNull() || prior.IsUnknown() {
+			return types.StringNull()
+		}
+		if value == nil {
+			return prior
+		}
+	}
+	return types.StringValue(*value)
+}
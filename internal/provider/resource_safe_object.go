@@ -13,6 +13,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -22,20 +24,31 @@ var (
 	_ resource.ResourceWithConfigure      = &safeResource{}
 	_ resource.ResourceWithImportState    = &safeResource{}
 	_ resource.ResourceWithValidateConfig = &safeResource{}
+	_ resource.ResourceWithMoveState      = &safeResource{}
 )
 
 // NewSafeResource is a helper function to simplify the provider implementation.
 func NewSafeResource() resource.Resource {
-	return &safeResource{}
+	return &safeResource{defaultBackend: backendPrivilegeCloud}
+}
+
+// NewPVWASafeResource returns the deprecated cyberark_pvwa_safe alias of the resource, which
+// manages safes in the self-hosted vault by default.
+func NewPVWASafeResource() resource.Resource {
+	return &safeResource{defaultBackend: backendSelfHosted}
 }
 
 // safeResource defines the resource implementation.
 type safeResource struct {
 	api *cybrapi.API
+
+	// defaultBackend is the backend used when the backend attribute is not set
+	defaultBackend string
 }
 
 // ExampleResourceModel describes the resource data model.
 type safeResourceModel struct {
+	Backend           types.String `tfsdk:"backend"`
 	RetentionDays     types.Int64  `tfsdk:"retention"`
 	RetentionVersions types.Int64  `tfsdk:"retention_versions"`
 	PurgeEnabled      types.Bool   `tfsdk:"purge"`
@@ -49,28 +62,34 @@ type safeResourceModel struct {
 	SeedMember        types.String `tfsdk:"member"`
 	SeedMType         types.String `tfsdk:"member_type"`
 	PermType          types.String `tfsdk:"permission_level"`
+	EnableOLAC        types.Bool   `tfsdk:"enable_olac"`
+
+	EnableSecretsHubSync types.Bool `tfsdk:"enable_secrets_hub_sync"`
+	EnableConjurSync     types.Bool `tfsdk:"enable_conjur_sync"`
 }
 
 // Metadata returns the resource type name.
 func (r *safeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
-	resp.TypeName = req.ProviderTypeName + "_safe"
+	resp.TypeName = backendTypeName(req.ProviderTypeName, "safe", r.defaultBackend)
 }
 
 // Schema returns the resource schema.
 func (r *safeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: `CyberArk Privilege Cloud Safe Resource
+		MarkdownDescription: `CyberArk Safe Resource
 
-This resource is responsible for creating a new privileged cloud safe in CyberArk Privilege Cloud.
+This resource is responsible for creating a new safe in CyberArk Privilege Cloud or PAM Self-Hosted.
 
 For more information click [here](https://docs.cyberark.com/privilege-cloud-shared-services/latest/en/Content/WebServices/Add%20Safe.htm).`,
+		DeprecationMessage: backendDeprecationMessage("safe", r.defaultBackend),
 		Attributes: map[string]schema.Attribute{
+			"backend": backendAttribute(r.defaultBackend),
 			"id": schema.StringAttribute{
-				Description: "CyberArk Privilege Cloud Safe URL ID- Generated from CyberArk after onboarding safe.",
+				Description: "CyberArk Safe URL ID- Generated from CyberArk after onboarding safe.",
 				Computed:    true,
 			},
 			"id_number": schema.Int64Attribute{
-				Description: "CyberArk Privilege Cloud Safe ID- Generated from CyberArk after onboarding safe.",
+				Description: "CyberArk Safe ID- Generated from CyberArk after onboarding safe.",
 				Computed:    true,
 			},
 			"last_updated": schema.StringAttribute{
@@ -85,12 +104,13 @@ For more information click [here](https://docs.cyberark.com/privilege-cloud-shar
 				Required:    true,
 			},
 			"member_type": schema.StringAttribute{
-				Description: "Member user type: user or group.",
+				Description: "Member user type: user, group or role.",
 				Required:    true,
 			},
 			"permission_level": schema.StringAttribute{
-				Description: "Membership Permission Level. Currently supported inputs: full, read, approver, manager.",
-				Required:    true,
+				Description: "Membership Permission Level. Currently supported inputs: full, read, approver, manager, application. " +
+					"`application` lets the Credential Provider retrieve accounts for an application ID added as a `user` member.",
+				Required: true,
 			},
 			"safe_desc": schema.StringAttribute{
 				Description: "The description of the Safe.",
@@ -101,6 +121,24 @@ For more information click [here](https://docs.cyberark.com/privilege-cloud-shar
 				Computed:    true,
 				Optional:    true,
 			},
+			"enable_olac": schema.BoolAttribute{
+				Description: "Whether or not to enable Object Level Access Control (OLAC) for the Safe.",
+				Computed:    true,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enable_secrets_hub_sync": schema.BoolAttribute{
+				Description: "Whether the SecretsHub user is a member of the Safe, so that Secrets Hub sync policies can sync its accounts. " +
+					"The member is added with the permissions Secrets Hub needs when `true` and removed when `false`.",
+				Optional: true,
+			},
+			"enable_conjur_sync": schema.BoolAttribute{
+				Description: "Whether the ConjurSync user is a member of the Safe, so that its accounts are synced to Conjur. " +
+					"The member is added with the permissions Conjur Sync needs when `true` and removed when `false`.",
+				Optional: true,
+			},
 			"cpm_name": schema.StringAttribute{
 				Description: "The name of the CPM user who will manage the new Safe.",
 				Computed:    true,
@@ -134,7 +172,7 @@ func (r *safeResource) Configure(_ context.Context, req resource.ConfigureReques
 	api, ok := req.ProviderData.(*cybrapi.API)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected AzureAkvData Source Configure Type",
+			"Unexpected Resource Configure Type",
 			fmt.Sprintf("Expected *cybrapi.Api, got: %T. Please report this issue to the provider developers", req.ProviderData),
 		)
 		return
@@ -153,9 +191,11 @@ func (r *safeResource) ValidateConfig(ctx context.Context, req resource.Validate
 		return
 	}
 
+	validateBackend(data.Backend, &resp.Diagnostics)
+
 	// Validate permission level
 	switch data.PermType.ValueString() {
-	case "full", "read", "approver", "manager":
+	case "full", "read", "approver", "manager", "application":
 		// valid options
 	default:
 		resp.Diagnostics.AddError("Permission Level Error",
@@ -180,6 +220,11 @@ func (r *safeResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	newSafe := cybrapi.SafeData{
 		RetentionDays:     nullIfUnknown(data.RetentionDays).ValueInt64Pointer(),
 		RetentionVersions: nullIfUnknown(data.RetentionVersions).ValueInt64Pointer(),
@@ -191,26 +236,28 @@ func (r *safeResource) Create(ctx context.Context, req resource.CreateRequest, r
 		Owner:             data.SeedMember.ValueStringPointer(),
 		OwnerType:         data.SeedMType.ValueStringPointer(),
 		Level:             data.PermType.ValueStringPointer(),
+		EnableOLAC:        knownBoolPointer(data.EnableOLAC),
 	}
 
 	// Check if there is an existing Safe
-	safe, err := r.api.PamAPI.GetSafe(ctx, data.Name.ValueString())
+	safe, err := pam.GetSafe(ctx, data.Name.ValueString())
 	if err != nil {
 		tflog.Info(ctx, "Safe not found, creating new")
-		safe, err = r.api.PamAPI.AddSafe(ctx, newSafe)
+		safe, err = pam.AddSafe(ctx, newSafe)
 		if err != nil {
 			resp.Diagnostics.AddError("Error creating safe", err.Error())
 			return
 		}
 	}
 
-	_, err = r.api.PamAPI.AddSafeMember(ctx, newSafe)
+	_, err = pam.AddSafeMember(ctx, newSafe)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating safe member", err.Error())
 		return
 	}
 
 	data = safeResourceModel{
+		Backend:           data.Backend,
 		ID:                types.StringPointerValue(safe.URLID),
 		IDNUM:             types.Int64PointerValue(safe.NUMBER),
 		RetentionDays:     types.Int64PointerValue(safe.RetentionDays),
@@ -220,9 +267,13 @@ func (r *safeResource) Create(ctx context.Context, req resource.CreateRequest, r
 		Name:              types.StringPointerValue(safe.Name),
 		Description:       types.StringPointerValue(safe.Description),
 		Location:          types.StringPointerValue(safe.Location),
+		EnableOLAC:        types.BoolPointerValue(safe.EnableOLAC),
 		SeedMember:        data.SeedMember, // Can not be read from API
 		SeedMType:         data.SeedMType,  // Can not be read from API
 		PermType:          data.PermType,   // Can not be read from API
+
+		EnableSecretsHubSync: data.EnableSecretsHubSync,
+		EnableConjurSync:     data.EnableConjurSync,
 	}
 
 	// Set last updated time to last refreshed time
@@ -233,6 +284,9 @@ func (r *safeResource) Create(ctx context.Context, req resource.CreateRequest, r
 		data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
 	}
 
+	// The safe exists now, so it is saved even if its sync members cannot be added
+	applySyncMembers(ctx, pam, data.Name.ValueString(), &data, &resp.Diagnostics)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -247,13 +301,26 @@ func (r *safeResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	safe, err := r.api.PamAPI.GetSafe(ctx, data.ID.ValueString())
+	data.Backend = backendOrDefault(data.Backend, r.defaultBackend)
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	safe, err := pam.GetSafe(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading safe", err.Error())
 		return
 	}
 
+	readSyncMembers(ctx, pam, types.StringPointerValue(safe.Name).ValueString(), &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	data = safeResourceModel{
+		Backend:           data.Backend,
 		ID:                types.StringPointerValue(safe.URLID),
 		IDNUM:             types.Int64PointerValue(safe.NUMBER),
 		RetentionDays:     types.Int64PointerValue(safe.RetentionDays),
@@ -263,9 +330,13 @@ func (r *safeResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		Name:              types.StringPointerValue(safe.Name),
 		Description:       types.StringPointerValue(safe.Description),
 		Location:          types.StringPointerValue(safe.Location),
+		EnableOLAC:        types.BoolPointerValue(safe.EnableOLAC),
 		SeedMember:        data.SeedMember, // Can not be read from API
 		SeedMType:         data.SeedMType,  // Can not be read from API
 		PermType:          data.PermType,   // Can not be read from API
+
+		EnableSecretsHubSync: data.EnableSecretsHubSync,
+		EnableConjurSync:     data.EnableConjurSync,
 	}
 
 	// Set last updated time to last refreshed time
@@ -292,6 +363,11 @@ func (r *safeResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	updatedSafe := cybrapi.SafeData{
 		RetentionDays:     nullIfUnknown(data.RetentionDays).ValueInt64Pointer(),
 		RetentionVersions: nullIfUnknown(data.RetentionVersions).ValueInt64Pointer(),
@@ -305,10 +381,11 @@ func (r *safeResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		Owner:             data.SeedMember.ValueStringPointer(),
 		OwnerType:         data.SeedMType.ValueStringPointer(),
 		Level:             data.PermType.ValueStringPointer(),
+		EnableOLAC:        knownBoolPointer(data.EnableOLAC),
 	}
 
 	// Call API to update the safe
-	safe, err := r.api.PamAPI.UpdateSafe(ctx, state.ID.ValueString(), updatedSafe)
+	safe, err := pam.UpdateSafe(ctx, state.ID.ValueString(), updatedSafe)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating safe", err.Error())
 		return
@@ -317,7 +394,7 @@ func (r *safeResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	if !data.SeedMember.IsNull() && !data.SeedMType.IsNull() && !data.PermType.IsNull() {
 		// Validate permission level
 		switch data.PermType.ValueString() {
-		case "full", "read", "approver", "manager":
+		case "full", "read", "approver", "manager", "application":
 			// valid options
 		default:
 			resp.Diagnostics.AddError("Permission Level Error",
@@ -325,7 +402,7 @@ func (r *safeResource) Update(ctx context.Context, req resource.UpdateRequest, r
 			return
 		}
 
-		_, err = r.api.PamAPI.UpdateSafeMember(ctx, updatedSafe)
+		_, err = pam.UpdateSafeMember(ctx, updatedSafe)
 		if err != nil {
 			resp.Diagnostics.AddError("Error updating safe member", err.Error())
 			return
@@ -335,6 +412,7 @@ func (r *safeResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	data = safeResourceModel{
+		Backend:           data.Backend,
 		ID:                types.StringPointerValue(safe.URLID),
 		IDNUM:             types.Int64PointerValue(safe.NUMBER),
 		RetentionDays:     types.Int64PointerValue(safe.RetentionDays),
@@ -344,9 +422,13 @@ func (r *safeResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		Name:              types.StringPointerValue(safe.Name),
 		Description:       types.StringPointerValue(safe.Description),
 		Location:          types.StringPointerValue(safe.Location),
+		EnableOLAC:        types.BoolPointerValue(safe.EnableOLAC),
 		SeedMember:        data.SeedMember, // Can not be read from API
 		SeedMType:         data.SeedMType,  // Can not be read from API
 		PermType:          data.PermType,   // Can not be read from API
+
+		EnableSecretsHubSync: data.EnableSecretsHubSync,
+		EnableConjurSync:     data.EnableConjurSync,
 	}
 
 	// Update last updated time
@@ -357,6 +439,9 @@ func (r *safeResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
 	}
 
+	// The safe was updated, so it is saved even if its sync members cannot be changed
+	applySyncMembers(ctx, pam, data.Name.ValueString(), &data, &resp.Diagnostics)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -370,9 +455,16 @@ func (r *safeResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	data.Backend = backendOrDefault(data.Backend, r.defaultBackend)
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// First delete the safe member if possible
 	if !data.SeedMember.IsNull() && !data.SeedMType.IsNull() && !data.PermType.IsNull() {
-		err := r.api.PamAPI.DeleteSafeMember(ctx, data.Name.ValueString(), data.SeedMember.ValueString())
+		err := pam.DeleteSafeMember(ctx, data.Name.ValueString(), data.SeedMember.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError("Error deleting safe member", err.Error())
 			// Continue with safe deletion even if member deletion fails
@@ -382,15 +474,44 @@ func (r *safeResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	// Then delete the safe
-	err := r.api.PamAPI.DeleteSafe(ctx, data.Name.ValueString())
+	err := pam.DeleteSafe(ctx, data.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting safe", err.Error())
 		return
 	}
 }
 
+// ImportState imports an existing safe by its URL ID or by "name:<safe name>",
+// optionally prefixed with "<backend>:".
 func (r *safeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	backend, importID := splitImportBackend(r.api, req.ID, r.defaultBackend)
+
+	pam := pamAPIForBackend(r.api, backend, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := resolveSafeImportID(ctx, pam, importID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing safe", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("backend"), backend)...)
+}
+
+// MoveState moves the state of the deprecated cyberark_pvwa_safe alias to the resource.
+func (r *safeResource) MoveState(ctx context.Context) []resource.StateMover {
+	if r.defaultBackend == backendSelfHosted {
+		return nil
+	}
+
+	alias := &safeResource{defaultBackend: backendSelfHosted}
+	aliasSchema := &resource.SchemaResponse{}
+	alias.Schema(ctx, resource.SchemaRequest{}, aliasSchema)
+
+	return []resource.StateMover{pvwaAliasStateMover(aliasSchema.Schema, "safe", &safeResourceModel{})}
 }
 
 // Helper method to translate unknown int64 values to null (as opposed to 0)
@@ -400,3 +521,19 @@ func nullIfUnknown(v types.Int64) types.Int64 {
 	}
 	return v
 }
+
+// Helper method to translate unknown string values to nil pointers (as opposed to "")
+func knownStringPointer(v types.String) *string {
+	if v.IsUnknown() {
+		return nil
+	}
+	return v.ValueStringPointer()
+}
+
+// Helper method to translate unknown bool values to nil pointers (as opposed to false)
+func knownBoolPointer(v types.Bool) *bool {
+	if v.IsUnknown() {
+		return nil
+	}
+	return v.ValueBoolPointer()
+}
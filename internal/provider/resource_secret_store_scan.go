@@ -0,0 +1,180 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &secretStoreScanResource{}
+	_ resource.ResourceWithConfigure = &secretStoreScanResource{}
+)
+
+// NewSecretStoreScanResource is a helper function to simplify the provider implementation.
+func NewSecretStoreScanResource() resource.Resource {
+	return &secretStoreScanResource{}
+}
+
+// secretStoreScanResource defines the resource implementation.
+type secretStoreScanResource struct {
+	api *cybrapi.API
+}
+
+// secretStoreScanModel describes the resource data model.
+type secretStoreScanModel struct {
+	StoreID     types.String `tfsdk:"store_id"`
+	Triggers    types.Map    `tfsdk:"triggers"`
+	ScanID      types.String `tfsdk:"scan_id"`
+	Status      types.String `tfsdk:"status"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+}
+
+// Metadata returns the resource type name.
+func (r *secretStoreScanResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret_store_scan"
+}
+
+// Schema returns the resource schema.
+func (r *secretStoreScanResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Secret Store Scan Resource
+
+This resource triggers a scan of a Secret Store in CyberArk Secrets Hub. A new scan is triggered when the resource is created and every time ` + "`store_id`" + ` or ` + "`triggers`" + ` change. Destroying the resource only removes it from the Terraform state.`,
+		Attributes: map[string]schema.Attribute{
+			"store_id": schema.StringAttribute{
+				Description: "ID of an existing CyberArk Secrets Hub Secret Store.",
+				Required:    true,
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary map of values that, when changed, trigger a new scan of the secret store.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"scan_id": schema.StringAttribute{
+				Description: "ID of the last scan triggered by this resource.",
+				Computed:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the latest scan of the secret store.",
+				Computed:    true,
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *secretStoreScanResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(*cybrapi.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected error configuring provider",
+			fmt.Sprintf("Expected *cybrapi.Api, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.api = api
+}
+
+// Create triggers a new scan of the secret store.
+func (r *secretStoreScanResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data secretStoreScanModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.scan(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read refreshes the status of the latest scan of the secret store.
+func (r *secretStoreScanResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data secretStoreScanModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scan, err := r.api.SecretsHubAPI.GetScanStatus(ctx, data.StoreID.ValueString())
+	if cybrapi.IsNotFound(err) {
+		// The secret store was deleted, so its scan is gone as well
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading secret store scan status", err.Error())
+		return
+	}
+
+	data.Status = types.StringPointerValue(scan.Status)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update triggers a new scan of the secret store.
+func (r *secretStoreScanResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data secretStoreScanModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.scan(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete only removes the resource from the Terraform state, since a scan cannot be undone.
+func (r *secretStoreScanResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	tflog.Info(ctx, "Removing secret store scan from state, no API call is made")
+}
+
+// scan triggers a scan of the configured secret store and records the result in the model.
+func (r *secretStoreScanResource) scan(ctx context.Context, data *secretStoreScanModel, diags *diag.Diagnostics) {
+	scan, err := r.api.SecretsHubAPI.ScanSecretStore(ctx, data.StoreID.ValueString())
+	if err != nil {
+		diags.AddError("Error triggering secret store scan",
+			fmt.Sprintf("Error triggering scan of secret store %s: %+v", data.StoreID.ValueString(), err))
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Triggered scan of secret store %s", data.StoreID.ValueString()))
+
+	data.ScanID = types.StringPointerValue(scan.ID)
+	data.Status = types.StringPointerValue(scan.Status)
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+}
@@ -186,6 +186,11 @@ func (r *syncPolicyResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	if policy == nil {
+		checkSecretsHubMember(ctx, r.api.PamAPI, data.SafeName.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
 		tflog.Info(ctx, "Sync policy not found, creating new")
 		policy, err = r.api.SecretsHubAPI.AddSyncPolicy(ctx, newPolicy)
 		if err != nil {
@@ -271,6 +276,13 @@ func (r *syncPolicyResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 }
 
+// ImportState imports an existing sync policy by its ID or by "name:<policy name>".
 func (r *syncPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id, err := resolveSyncPolicyImportID(ctx, r.api.SecretsHubAPI, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing sync policy", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
@@ -0,0 +1,425 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &targetPlatformResource{}
+	_ resource.ResourceWithConfigure      = &targetPlatformResource{}
+	_ resource.ResourceWithImportState    = &targetPlatformResource{}
+	_ resource.ResourceWithValidateConfig = &targetPlatformResource{}
+)
+
+// NewTargetPlatformResource is a helper function to simplify the provider implementation.
+func NewTargetPlatformResource() resource.Resource {
+	return &targetPlatformResource{}
+}
+
+// targetPlatformResource defines the resource implementation.
+type targetPlatformResource struct {
+	api *cybrapi.API
+}
+
+// targetPlatformModel describes the resource data model.
+type targetPlatformModel struct {
+	Backend        types.String `tfsdk:"backend"`
+	ID             types.String `tfsdk:"id"`
+	PlatformID     types.String `tfsdk:"platform_id"`
+	BasePlatformID types.String `tfsdk:"base_platform_id"`
+	PackageFile    types.String `tfsdk:"package_file"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	Active         types.Bool   `tfsdk:"active"`
+	LastUpdated    types.String `tfsdk:"last_updated"`
+}
+
+// requiresReplaceIfSet forces a new resource when a value that was set in the state changes. Values
+// missing from the state of imported resources can be added without replacing the resource.
+var requiresReplaceIfSet = stringplanmodifier.RequiresReplaceIf(
+	func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+		resp.RequiresReplace = !req.StateValue.IsNull()
+	},
+	"Changing the value forces a new resource.",
+	"Changing the value forces a new resource.",
+)
+
+// Metadata returns the resource type name.
+func (r *targetPlatformResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_target_platform"
+}
+
+// Schema returns the resource schema.
+func (r *targetPlatformResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Target Platform Resource
+
+This resource creates a target platform by duplicating an existing target platform or by importing a platform package, and activates or deactivates it.
+
+For more information click [here](https://docs.cyberark.com/privilege-cloud-shared-services/latest/en/content/webservices/duplicate-target-platforms.htm).`,
+		Attributes: map[string]schema.Attribute{
+			"backend": backendAttribute(backendPrivilegeCloud),
+			"id": schema.StringAttribute{
+				Description: "The numeric ID of the target platform.",
+				Computed:    true,
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+			"platform_id": schema.StringAttribute{
+				Description: "The ID of the platform, which accounts reference in their `platform` attribute.",
+				Computed:    true,
+			},
+			"base_platform_id": schema.StringAttribute{
+				Description: "The platform ID of the target platform to duplicate. Conflicts with `package_file`. Changing it forces a new resource.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfSet,
+				},
+			},
+			"package_file": schema.StringAttribute{
+				Description: "The path of a platform package ZIP file to import. Conflicts with `base_platform_id`. Changing it forces a new resource.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfSet,
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the platform. Required with `base_platform_id`, and read from the package otherwise. Changing it forces a new resource.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of a duplicated platform. Changing it forces a new resource.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"active": schema.BoolAttribute{
+				Description: "Whether the platform is active, so that accounts can be added with it. Defaults to `true`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *targetPlatformResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(*cybrapi.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cybrapi.Api, got: %T. Please report this issue to the provider developers", req.ProviderData),
+		)
+		return
+	}
+
+	r.api = api
+}
+
+// ValidateConfig validates the resource configuration.
+func (r *targetPlatformResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data targetPlatformModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateBackend(data.Backend, &resp.Diagnostics)
+
+	// Unknown values may be set later
+	if data.BasePlatformID.IsUnknown() || data.PackageFile.IsUnknown() {
+		return
+	}
+
+	switch {
+	case data.BasePlatformID.IsNull() && data.PackageFile.IsNull():
+		resp.Diagnostics.AddError("Missing platform source",
+			"Either base_platform_id or package_file must be set.")
+	case !data.BasePlatformID.IsNull() && !data.PackageFile.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("package_file"), "Conflicting platform source",
+			"Only one of base_platform_id and package_file can be set.")
+	case !data.BasePlatformID.IsNull() && data.Name.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Missing platform name",
+			"name is required to duplicate a platform.")
+	}
+}
+
+// Create a new resource.
+func (r *targetPlatformResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data targetPlatformModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var platform *cybrapi.TargetPlatform
+	if !data.BasePlatformID.IsNull() {
+		platform = r.duplicate(ctx, pam, data, resp)
+	} else {
+		platform = r.importPackage(ctx, pam, data, resp)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired := data.Active.ValueBool()
+	setTargetPlatformModel(&data, platform)
+	data.Active = types.BoolValue(platform.Active != nil && *platform.Active)
+
+	// Duplicated platforms are inactive, imported ones keep the state of the package
+	if data.Active.ValueBool() != desired {
+		// Save the platform first, so that it is not lost if its state cannot be changed
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+		err := setTargetPlatformActive(ctx, pam, *platform.ID, desired)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("active"), "Error changing platform state", err.Error())
+			return
+		}
+		data.Active = types.BoolValue(desired)
+	}
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// duplicate duplicates the base platform of data.
+func (r *targetPlatformResource) duplicate(ctx context.Context, pam cybrapi.PAMAPI, data targetPlatformModel, resp *resource.CreateResponse) *cybrapi.TargetPlatform {
+	targets, err := pam.ListTargetPlatforms(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading target platforms", err.Error())
+		return nil
+	}
+
+	base := findTargetPlatform(targets, data.BasePlatformID.ValueString())
+	if base == nil || base.ID == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("base_platform_id"), "Platform not found",
+			fmt.Sprintf("The vault has no target platform with the ID %s.", data.BasePlatformID.ValueString()))
+		return nil
+	}
+
+	platform, err := pam.DuplicateTargetPlatform(ctx, *base.ID, data.Name.ValueString(), data.Description.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error duplicating platform", err.Error())
+		return nil
+	}
+	if platform.ID == nil {
+		resp.Diagnostics.AddError("Error duplicating platform", "The vault returned no ID for the new platform.")
+		return nil
+	}
+
+	return platform
+}
+
+// importPackage imports the platform package of data.
+func (r *targetPlatformResource) importPackage(ctx context.Context, pam cybrapi.PAMAPI, data targetPlatformModel, resp *resource.CreateResponse) *cybrapi.TargetPlatform {
+	zip, err := os.ReadFile(data.PackageFile.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("package_file"), "Error reading platform package", err.Error())
+		return nil
+	}
+
+	platformID, err := pam.ImportPlatform(ctx, zip)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing platform", err.Error())
+		return nil
+	}
+
+	targets, err := pam.ListTargetPlatforms(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading target platforms", err.Error())
+		return nil
+	}
+
+	platform := findTargetPlatform(targets, platformID)
+	if platform == nil || platform.ID == nil {
+		resp.Diagnostics.AddError("Error importing platform",
+			fmt.Sprintf("Platform %s was imported, but is not a target platform.", platformID))
+		return nil
+	}
+
+	return platform
+}
+
+// Read the resource and set the Terraform state.
+func (r *targetPlatformResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data targetPlatformModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	targets, err := pam.ListTargetPlatforms(ctx, "")
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading target platforms", err.Error())
+		return
+	}
+
+	var platform *cybrapi.TargetPlatform
+	for _, target := range targets {
+		if target.ID != nil && strconv.Itoa(*target.ID) == data.ID.ValueString() {
+			platform = target
+			break
+		}
+	}
+	if platform == nil {
+		// The platform was deleted outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	setTargetPlatformModel(&data, platform)
+	if platform.Active != nil {
+		data.Active = types.BoolValue(*platform.Active)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success. Only the state of
+// the platform can be changed, all other attributes force a new resource.
+func (r *targetPlatformResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state targetPlatformModel
+
+	// Read Terraform plan data and current state into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Active.Equal(state.Active) {
+		targetID, err := strconv.Atoi(state.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid platform ID", err.Error())
+			return
+		}
+
+		err = setTargetPlatformActive(ctx, pam, targetID, data.Active.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("active"), "Error changing platform state", err.Error())
+			return
+		}
+	}
+
+	data.ID = state.ID
+	data.PlatformID = state.PlatformID
+	data.Name = state.Name
+	data.Description = state.Description
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *targetPlatformResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data targetPlatformModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	targetID, err := strconv.Atoi(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid platform ID", err.Error())
+		return
+	}
+
+	err = pam.DeleteTargetPlatform(ctx, targetID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting platform", err.Error())
+		return
+	}
+}
+
+// ImportState imports an existing target platform by its numeric ID, optionally prefixed with "<backend>:".
+func (r *targetPlatformResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	backend, id := splitImportBackend(r.api, req.ID, backendPrivilegeCloud)
+
+	if _, err := strconv.Atoi(id); err != nil {
+		resp.Diagnostics.AddError("Invalid import ID",
+			fmt.Sprintf("Expected the numeric ID of a target platform, got: %s", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("backend"), backend)...)
+}
+
+// setTargetPlatformActive activates or deactivates a target platform.
+func setTargetPlatformActive(ctx context.Context, pam cybrapi.PAMAPI, targetID int, active bool) error {
+	if active {
+		return pam.ActivateTargetPlatform(ctx, targetID)
+	}
+	return pam.DeactivateTargetPlatform(ctx, targetID)
+}
+
+// setTargetPlatformModel sets the resource data to the target platform returned by the API.
+// Values missing from the response are kept as they are.
+func setTargetPlatformModel(data *targetPlatformModel, platform *cybrapi.TargetPlatform) {
+	if platform.ID != nil {
+		data.ID = types.StringValue(strconv.Itoa(*platform.ID))
+	}
+	data.Backend = backendOrDefault(data.Backend, backendPrivilegeCloud)
+	data.PlatformID = stringOrPrior(platform.PlatformID, data.PlatformID)
+	data.Name = stringOrPrior(platform.Name, data.Name)
+	data.Description = stringOrPrior(platform.Description, data.Description)
+}
@@ -0,0 +1,264 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &vaultGroupResource{}
+	_ resource.ResourceWithConfigure      = &vaultGroupResource{}
+	_ resource.ResourceWithImportState    = &vaultGroupResource{}
+	_ resource.ResourceWithValidateConfig = &vaultGroupResource{}
+)
+
+// NewVaultGroupResource is a helper function to simplify the provider implementation.
+func NewVaultGroupResource() resource.Resource {
+	return &vaultGroupResource{}
+}
+
+// vaultGroupResource defines the resource implementation.
+type vaultGroupResource struct {
+	api *cybrapi.API
+}
+
+// vaultGroupModel describes the resource data model.
+type vaultGroupModel struct {
+	Backend     types.String `tfsdk:"backend"`
+	ID          types.String `tfsdk:"id"`
+	GroupName   types.String `tfsdk:"group_name"`
+	Description types.String `tfsdk:"description"`
+	Location    types.String `tfsdk:"location"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+}
+
+// Metadata returns the resource type name.
+func (r *vaultGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vault_group"
+}
+
+// Schema returns the resource schema.
+func (r *vaultGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `CyberArk Vault Group Resource
+
+This resource manages a vault group. Members are added with the ` + "`cyberark_vault_group_member`" + ` resource.
+
+For more information click [here](https://docs.cyberark.com/pam-self-hosted/latest/en/content/webservices/add-user-group.htm).`,
+		Attributes: map[string]schema.Attribute{
+			"backend": backendAttribute(backendSelfHosted),
+			"id": schema.StringAttribute{
+				Description: "CyberArk Vault Group ID- Generated from CyberArk after adding the group.",
+				Computed:    true,
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+			"group_name": schema.StringAttribute{
+				Description: "The name of the group.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the group.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"location": schema.StringAttribute{
+				Description: "The location of the group in the Vault hierarchy. Changing it forces a new resource.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *vaultGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(*cybrapi.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cybrapi.Api, got: %T. Please report this issue to the provider developers", req.ProviderData),
+		)
+		return
+	}
+
+	r.api = api
+}
+
+// ValidateConfig validates the resource configuration.
+func (r *vaultGroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data vaultGroupModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateBackend(data.Backend, &resp.Diagnostics)
+}
+
+// Create a new resource.
+func (r *vaultGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data vaultGroupModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := pam.AddGroup(ctx, cybrapi.VaultGroup{
+		GroupName:   data.GroupName.ValueStringPointer(),
+		Description: knownStringPointer(data.Description),
+		Location:    knownStringPointer(data.Location),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating vault group", err.Error())
+		return
+	}
+
+	setVaultGroupModel(&data, group)
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read the resource and set the Terraform state.
+func (r *vaultGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data vaultGroupModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := pam.GetGroup(ctx, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading vault group", err.Error())
+		return
+	}
+
+	setVaultGroupModel(&data, group)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *vaultGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state vaultGroupModel
+
+	// Read Terraform plan data and current state into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := pam.UpdateGroup(ctx, state.ID.ValueString(), cybrapi.VaultGroup{
+		GroupName:   data.GroupName.ValueStringPointer(),
+		Description: knownStringPointer(data.Description),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating vault group", err.Error())
+		return
+	}
+
+	data.ID = state.ID
+	data.Location = state.Location
+	setVaultGroupModel(&data, group)
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *vaultGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data vaultGroupModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := pam.DeleteGroup(ctx, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting vault group", err.Error())
+		return
+	}
+}
+
+// ImportState imports an existing vault group by its ID, optionally prefixed with "<backend>:".
+func (r *vaultGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	backend, id := splitImportBackend(r.api, req.ID, backendSelfHosted)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("backend"), backend)...)
+}
+
+// setVaultGroupModel sets the resource data to the vault group returned by the API. Values
+// missing from the response are kept as they are.
+func setVaultGroupModel(data *vaultGroupModel, group *cybrapi.VaultGroup) {
+	if group.ID != nil {
+		data.ID = types.StringValue(strconv.Itoa(*group.ID))
+	}
+	data.Backend = backendOrDefault(data.Backend, backendSelfHosted)
+	if group.GroupName != nil {
+		data.GroupName = types.StringPointerValue(group.GroupName)
+	}
+	if group.Description != nil {
+		data.Description = types.StringPointerValue(group.Description)
+	} else if data.Description.IsUnknown() {
+		data.Description = types.StringValue("")
+	}
+	if group.Location != nil {
+		data.Location = types.StringPointerValue(group.Location)
+	} else if data.Location.IsUnknown() {
+		data.Location = types.StringNull()
+	}
+}
@@ -0,0 +1,293 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &vaultGroupMemberResource{}
+	_ resource.ResourceWithConfigure      = &vaultGroupMemberResource{}
+	_ resource.ResourceWithImportState    = &vaultGroupMemberResource{}
+	_ resource.ResourceWithValidateConfig = &vaultGroupMemberResource{}
+)
+
+const (
+	vaultMemberType  = "vault"
+	domainMemberType = "domain"
+)
+
+// NewVaultGroupMemberResource is a helper function to simplify the provider implementation.
+func NewVaultGroupMemberResource() resource.Resource {
+	return &vaultGroupMemberResource{}
+}
+
+// vaultGroupMemberResource defines the resource implementation.
+type vaultGroupMemberResource struct {
+	api *cybrapi.API
+}
+
+// vaultGroupMemberModel describes the resource data model.
+type vaultGroupMemberModel struct {
+	Backend     types.String `tfsdk:"backend"`
+	ID          types.String `tfsdk:"id"`
+	GroupID     types.String `tfsdk:"group_id"`
+	MemberName  types.String `tfsdk:"member_name"`
+	MemberType  types.String `tfsdk:"member_type"`
+	DomainName  types.String `tfsdk:"domain_name"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+}
+
+// Metadata returns the resource type name.
+func (r *vaultGroupMemberResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vault_group_member"
+}
+
+// Schema returns the resource schema.
+func (r *vaultGroupMemberResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `CyberArk Vault Group Member Resource
+
+This resource adds a vault or domain user to a vault group.
+
+For more information click [here](https://docs.cyberark.com/pam-self-hosted/latest/en/content/webservices/add-member-to-group.htm).`,
+		Attributes: map[string]schema.Attribute{
+			"backend": backendAttribute(backendSelfHosted),
+			"id": schema.StringAttribute{
+				Description: "The ID of the membership, in the format <group_id>/<member_name>.",
+				Computed:    true,
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+			"group_id": schema.StringAttribute{
+				Description: "The ID of the vault group. Changing it forces a new resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"member_name": schema.StringAttribute{
+				Description: "The name of the user to add to the group. Changing it forces a new resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"member_type": schema.StringAttribute{
+				Description: "The type of the member, either vault or domain. Defaults to vault. Changing it forces a new resource.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(vaultMemberType),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain_name": schema.StringAttribute{
+				Description: "The DNS name of the domain of the member. Required when member_type is domain. Changing it forces a new resource.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *vaultGroupMemberResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(*cybrapi.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cybrapi.Api, got: %T. Please report this issue to the provider developers", req.ProviderData),
+		)
+		return
+	}
+
+	r.api = api
+}
+
+// ValidateConfig validates the resource configuration.
+func (r *vaultGroupMemberResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data vaultGroupMemberModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateBackend(data.Backend, &resp.Diagnostics)
+
+	if data.MemberType.IsNull() || data.MemberType.IsUnknown() {
+		return
+	}
+
+	switch data.MemberType.ValueString() {
+	case vaultMemberType:
+	case domainMemberType:
+		if data.DomainName.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("domain_name"), "Missing domain name",
+				"domain_name is required when member_type is domain.")
+		}
+	default:
+		resp.Diagnostics.AddAttributeError(path.Root("member_type"), "Invalid member type",
+			fmt.Sprintf("member_type must be %s or %s, got: %s", vaultMemberType, domainMemberType, data.MemberType.ValueString()))
+	}
+}
+
+// Create a new resource.
+func (r *vaultGroupMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data vaultGroupMemberModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := pam.AddGroupMember(ctx, data.GroupID.ValueString(), cybrapi.VaultGroupMemberInput{
+		MemberID:   data.MemberName.ValueStringPointer(),
+		MemberType: data.MemberType.ValueStringPointer(),
+		DomainName: data.DomainName.ValueStringPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error adding vault group member", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(data.GroupID.ValueString() + "/" + data.MemberName.ValueString())
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read the resource and set the Terraform state.
+func (r *vaultGroupMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data vaultGroupMemberModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := pam.GetGroup(ctx, data.GroupID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading vault group", err.Error())
+		return
+	}
+
+	if findVaultGroupMember(group, data.MemberName.ValueString()) == nil {
+		// The member was removed outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Backend = backendOrDefault(data.Backend, backendSelfHosted)
+	if data.MemberType.IsNull() {
+		data.MemberType = types.StringValue(vaultMemberType)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success. All attributes
+// force a new resource, so there is nothing to update in the vault.
+func (r *vaultGroupMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state vaultGroupMemberModel
+
+	// Read Terraform plan data and current state into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = state.ID
+	data.LastUpdated = state.LastUpdated
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *vaultGroupMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data vaultGroupMemberModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := pam.DeleteGroupMember(ctx, data.GroupID.ValueString(), data.MemberName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting vault group member", err.Error())
+		return
+	}
+}
+
+// ImportState imports an existing group membership by an ID in the format
+// <group_id>/<member_name>, optionally prefixed with "<backend>:".
+func (r *vaultGroupMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	backend, id := splitImportBackend(r.api, req.ID, backendSelfHosted)
+
+	groupID, memberName, ok := strings.Cut(id, "/")
+	if !ok || groupID == "" || memberName == "" {
+		resp.Diagnostics.AddError("Invalid import ID",
+			fmt.Sprintf("Expected an import ID in the format <group_id>/<member_name>, got: %s", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("backend"), backend)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), groupID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("member_name"), memberName)...)
+}
+
+// findVaultGroupMember returns the member of the group with the given user name, or nil if the
+// user is not a member. Vault user names are case-insensitive.
+func findVaultGroupMember(group *cybrapi.VaultGroup, name string) *cybrapi.VaultGroupMember {
+	for _, member := range group.Members {
+		if member.Username != nil && strings.EqualFold(*member.Username, name) {
+			return member
+		}
+	}
+	return nil
+}
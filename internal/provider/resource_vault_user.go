@@ -0,0 +1,433 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &vaultUserResource{}
+	_ resource.ResourceWithConfigure      = &vaultUserResource{}
+	_ resource.ResourceWithImportState    = &vaultUserResource{}
+	_ resource.ResourceWithValidateConfig = &vaultUserResource{}
+)
+
+// validVaultAuthorizations lists the vault authorizations that can be granted to vault users.
+var validVaultAuthorizations = []string{
+	"AddSafes", "AuditUsers", "AddUpdateUsers", "ResetUsersPasswords", "ActivateUsers", "AddNetworkAreas",
+	"ManageDirectoryMapping", "ManageServerFileCategories", "BackupAllSafes", "RestoreAllSafes",
+}
+
+// validAuthenticationMethods lists the methods vault users can authenticate with.
+var validAuthenticationMethods = []string{"AuthTypePass", "AuthTypeLDAP", "AuthTypeRADIUS"}
+
+// NewVaultUserResource is a helper function to simplify the provider implementation.
+func NewVaultUserResource() resource.Resource {
+	return &vaultUserResource{}
+}
+
+// vaultUserResource defines the resource implementation.
+type vaultUserResource struct {
+	api *cybrapi.API
+}
+
+// vaultUserModel describes the resource data model.
+type vaultUserModel struct {
+	Backend               types.String `tfsdk:"backend"`
+	ID                    types.String `tfsdk:"id"`
+	Username              types.String `tfsdk:"username"`
+	UserType              types.String `tfsdk:"user_type"`
+	InitialPassword       types.String `tfsdk:"initial_password"`
+	Location              types.String `tfsdk:"location"`
+	ExpiryDate            types.String `tfsdk:"expiry_date"`
+	VaultAuthorization    types.Set    `tfsdk:"vault_authorization"`
+	AuthenticationMethods types.Set    `tfsdk:"authentication_methods"`
+	EnableUser            types.Bool   `tfsdk:"enable_user"`
+	ChangePassOnNextLogon types.Bool   `tfsdk:"change_password_on_next_logon"`
+	PasswordNeverExpires  types.Bool   `tfsdk:"password_never_expires"`
+	Description           types.String `tfsdk:"description"`
+	Email                 types.String `tfsdk:"email"`
+	FirstName             types.String `tfsdk:"first_name"`
+	LastName              types.String `tfsdk:"last_name"`
+	LastUpdated           types.String `tfsdk:"last_updated"`
+}
+
+// Metadata returns the resource type name.
+func (r *vaultUserResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vault_user"
+}
+
+// Schema returns the resource schema.
+func (r *vaultUserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `CyberArk Vault User Resource
+
+This resource manages a vault user, including its authorizations, expiry, location and password policy.
+
+For more information click [here](https://docs.cyberark.com/pam-self-hosted/latest/en/content/webservices/add%20user%20v10.htm).`,
+		Attributes: map[string]schema.Attribute{
+			"backend": backendAttribute(backendSelfHosted),
+			"id": schema.StringAttribute{
+				Description: "CyberArk Vault User ID- Generated from CyberArk after adding the user.",
+				Computed:    true,
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+			"username": schema.StringAttribute{
+				Description: "The name of the user.",
+				Required:    true,
+			},
+			"user_type": schema.StringAttribute{
+				Description: "The user type, according to the license, for example `EPVUser` or `AIMAccount`.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"initial_password": schema.StringAttribute{
+				Description: "The password the user logs on with the first time. It is only set when the user is added.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"location": schema.StringAttribute{
+				Description: "The location of the user in the Vault hierarchy, for example `\\\\Applications`.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"expiry_date": schema.StringAttribute{
+				Description: "The date, in RFC 3339 format, after which the user can no longer log on.",
+				Optional:    true,
+			},
+			"vault_authorization": schema.SetAttribute{
+				Description: fmt.Sprintf("The vault authorizations of the user. Valid values: `%s`.",
+					strings.Join(validVaultAuthorizations, "`, `")),
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+			},
+			"authentication_methods": schema.SetAttribute{
+				Description: fmt.Sprintf("The methods the user can authenticate with. Valid values: `%s`.",
+					strings.Join(validAuthenticationMethods, "`, `")),
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+			},
+			"enable_user": schema.BoolAttribute{
+				Description: "Whether the user is enabled.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"change_password_on_next_logon": schema.BoolAttribute{
+				Description: "Whether the user must change the password on the next logon.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"password_never_expires": schema.BoolAttribute{
+				Description: "Whether the password of the user never expires.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Notes and comments about the user.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"email": schema.StringAttribute{
+				Description: "The business email address of the user.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"first_name": schema.StringAttribute{
+				Description: "The first name of the user.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"last_name": schema.StringAttribute{
+				Description: "The last name of the user.",
+				Optional:    true,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *vaultUserResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	api, ok := req.ProviderData.(*cybrapi.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cybrapi.Api, got: %T. Please report this issue to the provider developers", req.ProviderData),
+		)
+		return
+	}
+
+	r.api = api
+}
+
+// ValidateConfig validates the resource configuration.
+func (r *vaultUserResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data vaultUserModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateBackend(data.Backend, &resp.Diagnostics)
+
+	for _, authorization := range setStrings(ctx, data.VaultAuthorization, &resp.Diagnostics) {
+		if !slices.Contains(validVaultAuthorizations, authorization) {
+			resp.Diagnostics.AddAttributeError(path.Root("vault_authorization"), "Invalid Vault Authorization",
+				fmt.Sprintf("Vault authorization must be one of %s, got: %s", strings.Join(validVaultAuthorizations, ", "), authorization))
+		}
+	}
+
+	for _, method := range setStrings(ctx, data.AuthenticationMethods, &resp.Diagnostics) {
+		if !slices.Contains(validAuthenticationMethods, method) {
+			resp.Diagnostics.AddAttributeError(path.Root("authentication_methods"), "Invalid Authentication Method",
+				fmt.Sprintf("Authentication method must be one of %s, got: %s", strings.Join(validAuthenticationMethods, ", "), method))
+		}
+	}
+
+	if !data.ExpiryDate.IsNull() && !data.ExpiryDate.IsUnknown() {
+		if _, err := time.Parse(time.RFC3339, data.ExpiryDate.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("expiry_date"), "Invalid Expiry Date",
+				fmt.Sprintf("Expiry date must be in RFC 3339 format, for example 2030-01-31T00:00:00Z: %s", err))
+		}
+	}
+}
+
+// Create a new resource.
+func (r *vaultUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data vaultUserModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	newUser := vaultUserFromModel(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := pam.AddUser(ctx, newUser)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating vault user", err.Error())
+		return
+	}
+
+	setVaultUserModel(&data, user)
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read the resource and set the Terraform state.
+func (r *vaultUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data vaultUserModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := pam.GetUser(ctx, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading vault user", err.Error())
+		return
+	}
+
+	setVaultUserModel(&data, user)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *vaultUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state vaultUserModel
+
+	// Read Terraform plan data and current state into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updatedUser := vaultUserFromModel(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := pam.UpdateUser(ctx, state.ID.ValueString(), updatedUser)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating vault user", err.Error())
+		return
+	}
+
+	data.ID = state.ID
+	setVaultUserModel(&data, user)
+	data.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *vaultUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data vaultUserModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pam := pamAPIForBackend(r.api, data.Backend.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := pam.DeleteUser(ctx, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting vault user", err.Error())
+		return
+	}
+}
+
+// ImportState imports an existing vault user by its ID, optionally prefixed with "<backend>:".
+func (r *vaultUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	backend, id := splitImportBackend(r.api, req.ID, backendSelfHosted)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("backend"), backend)...)
+}
+
+// vaultUserFromModel returns the vault user of the resource data.
+func vaultUserFromModel(ctx context.Context, data vaultUserModel, diags *diag.Diagnostics) cybrapi.VaultUser {
+	user := cybrapi.VaultUser{
+		Username:              data.Username.ValueStringPointer(),
+		UserType:              knownStringPointer(data.UserType),
+		InitialPassword:       data.InitialPassword.ValueStringPointer(),
+		Location:              knownStringPointer(data.Location),
+		VaultAuthorization:    setStrings(ctx, data.VaultAuthorization, diags),
+		AuthenticationMethod:  setStrings(ctx, data.AuthenticationMethods, diags),
+		EnableUser:            knownBoolPointer(data.EnableUser),
+		ChangePassOnNextLogon: knownBoolPointer(data.ChangePassOnNextLogon),
+		PasswordNeverExpires:  knownBoolPointer(data.PasswordNeverExpires),
+		Description:           knownStringPointer(data.Description),
+	}
+
+	if email := knownStringPointer(data.Email); email != nil {
+		user.Internet = &cybrapi.VaultUserInternet{BusinessEmail: email}
+	}
+	firstName, lastName := knownStringPointer(data.FirstName), knownStringPointer(data.LastName)
+	if firstName != nil || lastName != nil {
+		user.PersonalDetails = &cybrapi.VaultUserPersonal{FirstName: firstName, LastName: lastName}
+	}
+
+	if !data.ExpiryDate.IsNull() && !data.ExpiryDate.IsUnknown() {
+		expiry, err := time.Parse(time.RFC3339, data.ExpiryDate.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("expiry_date"), "Invalid Expiry Date", err.Error())
+			return user
+		}
+		expiryDate := expiry.Unix()
+		user.ExpiryDate = &expiryDate
+	}
+
+	return user
+}
+
+// setVaultUserModel sets the resource data to the vault user returned by the API. The initial
+// password cannot be read and is kept as it is.
+func setVaultUserModel(data *vaultUserModel, user *cybrapi.VaultUser) {
+	if user.ID != nil {
+		data.ID = types.StringValue(strconv.Itoa(*user.ID))
+	}
+	data.Backend = backendOrDefault(data.Backend, backendSelfHosted)
+	data.Username = types.StringPointerValue(user.Username)
+	data.UserType = stringOrPrior(user.UserType, data.UserType)
+	data.Location = stringOrPrior(user.Location, data.Location)
+	data.ExpiryDate = expiryDateValue(data.ExpiryDate, user.ExpiryDate)
+	data.VaultAuthorization = stringSet(user.VaultAuthorization)
+	data.AuthenticationMethods = stringSet(user.AuthenticationMethod)
+	data.EnableUser = types.BoolPointerValue(user.EnableUser)
+	data.ChangePassOnNextLogon = types.BoolPointerValue(user.ChangePassOnNextLogon)
+	data.PasswordNeverExpires = types.BoolPointerValue(user.PasswordNeverExpires)
+	data.Description = stringOrPrior(user.Description, data.Description)
+
+	var email, firstName, lastName *string
+	if user.Internet != nil {
+		email = user.Internet.BusinessEmail
+	}
+	if user.PersonalDetails != nil {
+		firstName, lastName = user.PersonalDetails.FirstName, user.PersonalDetails.LastName
+	}
+	data.Email = stringOrPrior(email, data.Email)
+	data.FirstName = stringOrPrior(firstName, data.FirstName)
+	data.LastName = stringOrPrior(lastName, data.LastName)
+}
+
+// stringOrPrior returns the value returned by the API, or the prior value when the API omits
+// it. Unknown prior values become empty strings.
+func stringOrPrior(value *string, prior types.String) types.String {
+	if value != nil {
+		return types.StringValue(*value)
+	}
+	if prior.IsUnknown() {
+		return types.StringValue("")
+	}
+	return prior
+}
+
+// expiryDateValue returns the expiry date of a vault user, keeping the configured value when it
+// is the same time in another format. Users without an expiry date have no or a negative one.
+func expiryDateValue(prior types.String, expiryDate *int64) types.String {
+	if expiryDate == nil || *expiryDate <= 0 {
+		return types.StringNull()
+	}
+
+	expiry := time.Unix(*expiryDate, 0).UTC()
+	if priorTime, err := time.Parse(time.RFC3339, prior.ValueString()); err == nil && priorTime.Equal(expiry) {
+		return prior
+	}
+	return types.StringValue(expiry.Format(time.RFC3339))
+}
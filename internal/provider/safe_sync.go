@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// syncMember is a component user that a safe attribute adds to the safe, so that the accounts
+// of the safe are synced.
+type syncMember struct {
+	attribute string
+	name      string
+	value     func(*safeResourceModel) *types.Bool
+}
+
+// syncMembers lists the sync component users of safes.
+var syncMembers = []syncMember{
+	{
+		attribute: "enable_secrets_hub_sync",
+		name:      cybrapi.SecretsHubMember,
+		value:     func(m *safeResourceModel) *types.Bool { return &m.EnableSecretsHubSync },
+	},
+	{
+		attribute: "enable_conjur_sync",
+		name:      cybrapi.ConjurSyncMember,
+		value:     func(m *safeResourceModel) *types.Bool { return &m.EnableConjurSync },
+	},
+}
+
+// applySyncMembers adds the sync members enabled in data to the safe and removes the disabled
+// ones. Members whose attribute is not set are left as they are. When adding or removing a member
+// fails, its attribute in data is set to whether it is a member, and when the members cannot be
+// listed, the attributes are set to null, so that the next apply tries again.
+func applySyncMembers(ctx context.Context, pam cybrapi.PAMAPI, safeName string, data *safeResourceModel, diags *diag.Diagnostics) {
+	var members []*cybrapi.Member
+	listed := false
+
+	for _, sm := range syncMembers {
+		enabled := sm.value(data)
+		if enabled.IsNull() || enabled.IsUnknown() {
+			continue
+		}
+
+		if !listed {
+			var err error
+			members, err = pam.ListSafeMembers(ctx, safeName)
+			if err != nil {
+				diags.AddError("Error reading safe members", err.Error())
+				for _, sm := range syncMembers {
+					*sm.value(data) = types.BoolNull()
+				}
+				return
+			}
+			listed = true
+		}
+
+		present := hasSafeMember(members, sm.name)
+		switch {
+		case enabled.ValueBool() && !present:
+			if _, err := pam.AddSyncMember(ctx, safeName, sm.name); err != nil {
+				diags.AddAttributeError(path.Root(sm.attribute), "Error adding safe member",
+					fmt.Sprintf("Failed to add %s to safe %s: %s", sm.name, safeName, err))
+				*enabled = types.BoolValue(present)
+			}
+		case !enabled.ValueBool() && present:
+			if err := pam.DeleteSafeMember(ctx, safeName, sm.name); err != nil {
+				diags.AddAttributeError(path.Root(sm.attribute), "Error deleting safe member",
+					fmt.Sprintf("Failed to remove %s from safe %s: %s", sm.name, safeName, err))
+				*enabled = types.BoolValue(present)
+			}
+		}
+	}
+}
+
+// readSyncMembers sets the sync attributes of data that are set to whether their member belongs
+// to the safe.
+func readSyncMembers(ctx context.Context, pam cybrapi.PAMAPI, safeName string, data *safeResourceModel, diags *diag.Diagnostics) {
+	var members []*cybrapi.Member
+	listed := false
+
+	for _, sm := range syncMembers {
+		enabled := sm.value(data)
+		if enabled.IsNull() || enabled.IsUnknown() {
+			continue
+		}
+
+		if !listed {
+			var err error
+			members, err = pam.ListSafeMembers(ctx, safeName)
+			if err != nil {
+				diags.AddError("Error reading safe members", err.Error())
+				return
+			}
+			listed = true
+		}
+
+		*enabled = types.BoolValue(hasSafeMember(members, sm.name))
+	}
+}
+
+// hasSafeMember reports whether members include the member of the given name. Vault user names
+// are case-insensitive.
+func hasSafeMember(members []*cybrapi.Member, name string) bool {
+	for _, member := range members {
+		if member.Member != nil && strings.EqualFold(*member.Member, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSecretsHubMember adds an error to diags when the SecretsHub user is not a member of the
+// safe, since sync policies of the safe cannot be created then. Failing to list the members is
+// only a warning, as the provider user may not be allowed to view them.
+func checkSecretsHubMember(ctx context.Context, pam cybrapi.PAMAPI, safeName string, diags *diag.Diagnostics) {
+	members, err := pam.ListSafeMembers(ctx, safeName)
+	if err != nil {
+		diags.AddWarning("Unable to verify safe members",
+			fmt.Sprintf("Could not verify that %s is a member of safe %s: %s", cybrapi.SecretsHubMember, safeName, err))
+		return
+	}
+
+	if !hasSafeMember(members, cybrapi.SecretsHubMember) {
+		diags.AddAttributeError(path.Root("safe_name"), "Secrets Hub is not a safe member",
+			fmt.Sprintf("The %s user must be a member of safe %s before a sync policy can sync it. Set "+
+				"enable_secrets_hub_sync = true on the cyberark_safe resource of the safe, and reference the safe "+
+				"from the sync policy so that the member is added first.", cybrapi.SecretsHubMember, safeName))
+	}
+}
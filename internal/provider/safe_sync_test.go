@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const safeMembersRoute = "/PasswordVault/API/Safes/{safe}/Members"
+
+// listSafeMembers returns a handler that lists the given members of a safe in the requested page.
+func listSafeMembers(names ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		page := cybrapi.SafeMemberSearchResponse{Members: []*cybrapi.Member{}, Count: new(int)}
+		*page.Count = len(names)
+		for _, name := range names[min(offset, len(names)):min(offset+limit, len(names))] {
+			page.Members = append(page.Members, &cybrapi.Member{Member: &name})
+		}
+		writeJSON(w, http.StatusOK, page)
+	}
+}
+
+// addSafeMember returns a handler that records the members added to a safe.
+func addSafeMember(t *testing.T, added *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var member cybrapi.Member
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&member))
+		*added = append(*added, r.PathValue("safe")+"/"+*member.Member)
+		writeJSON(w, http.StatusCreated, member)
+	}
+}
+
+// deleteSafeMember returns a handler that records the members removed from a safe.
+func deleteSafeMember(deleted *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*deleted = append(*deleted, r.PathValue("safe")+"/"+r.PathValue("member"))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestApplySyncMembers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("AddAndRemove", func(t *testing.T) {
+		var added, deleted []string
+		pam := newPAMServer(t, map[string]http.HandlerFunc{
+			"GET " + safeMembersRoute:                  listSafeMembers("Administrator", "conjursync"),
+			"POST " + safeMembersRoute:                 addSafeMember(t, &added),
+			"DELETE " + safeMembersRoute + "/{member}": deleteSafeMember(&deleted),
+		})
+
+		var diags diag.Diagnostics
+		applySyncMembers(ctx, pam, "Synced", &safeResourceModel{
+			EnableSecretsHubSync: types.BoolValue(true),
+			EnableConjurSync:     types.BoolValue(false),
+		}, &diags)
+
+		require.False(t, diags.HasError(), diags)
+		assert.Equal(t, []string{"Synced/SecretsHub"}, added)
+		// Member names are case-insensitive, so the member listed as conjursync is removed
+		assert.Equal(t, []string{"Synced/ConjurSync"}, deleted)
+	})
+
+	t.Run("MemberOnLaterPage", func(t *testing.T) {
+		names := make([]string, 0, 150)
+		for i := range 149 {
+			names = append(names, fmt.Sprintf("user%d", i))
+		}
+		names = append(names, "SecretsHub")
+
+		// Adding the member again would fail the test, as the server has no route for it
+		pam := newPAMServer(t, map[string]http.HandlerFunc{
+			"GET " + safeMembersRoute: listSafeMembers(names...),
+		})
+
+		var diags diag.Diagnostics
+		applySyncMembers(ctx, pam, "Large", &safeResourceModel{
+			EnableSecretsHubSync: types.BoolValue(true),
+			EnableConjurSync:     types.BoolNull(),
+		}, &diags)
+
+		require.False(t, diags.HasError(), diags)
+	})
+
+	t.Run("NotSet", func(t *testing.T) {
+		// Safes which do not set the attributes never list their members
+		pam := newPAMServer(t, nil)
+
+		var diags diag.Diagnostics
+		applySyncMembers(ctx, pam, "Synced", &safeResourceModel{
+			EnableSecretsHubSync: types.BoolNull(),
+			EnableConjurSync:     types.BoolUnknown(),
+		}, &diags)
+
+		assert.Empty(t, diags)
+	})
+
+	t.Run("AddFails", func(t *testing.T) {
+		var added []string
+		pam := newPAMServer(t, map[string]http.HandlerFunc{
+			"GET " + safeMembersRoute: listSafeMembers("Administrator"),
+			"POST " + safeMembersRoute: func(w http.ResponseWriter, r *http.Request) {
+				var member cybrapi.Member
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&member))
+				if *member.Member == cybrapi.SecretsHubMember {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				added = append(added, *member.Member)
+				writeJSON(w, http.StatusCreated, member)
+			},
+		})
+
+		data := safeResourceModel{
+			EnableSecretsHubSync: types.BoolValue(true),
+			EnableConjurSync:     types.BoolValue(true),
+		}
+		var diags diag.Diagnostics
+		applySyncMembers(ctx, pam, "Synced", &data, &diags)
+
+		// The failure is reported on its attribute, and the other member is still added
+		require.Len(t, diags.Errors(), 1)
+		require.Implements(t, (*diag.DiagnosticWithPath)(nil), diags.Errors()[0])
+		assert.Equal(t, path.Root("enable_secrets_hub_sync"), diags.Errors()[0].(diag.DiagnosticWithPath).Path())
+		assert.Equal(t, []string{cybrapi.ConjurSyncMember}, added)
+
+		// The state records that SecretsHub is not a member, so the next apply adds it again
+		assert.Equal(t, types.BoolValue(false), data.EnableSecretsHubSync)
+		assert.Equal(t, types.BoolValue(true), data.EnableConjurSync)
+	})
+
+	t.Run("ListFails", func(t *testing.T) {
+		pam := newPAMServer(t, map[string]http.HandlerFunc{
+			"GET " + safeMembersRoute: respondStatus(http.StatusForbidden),
+		})
+
+		data := safeResourceModel{
+			EnableSecretsHubSync: types.BoolValue(true),
+			EnableConjurSync:     types.BoolValue(true),
+		}
+		var diags diag.Diagnostics
+		applySyncMembers(ctx, pam, "Synced", &data, &diags)
+
+		require.Len(t, diags.Errors(), 1)
+		assert.Equal(t, "Error reading safe members", diags.Errors()[0].Summary())
+		assert.True(t, data.EnableSecretsHubSync.IsNull())
+		assert.True(t, data.EnableConjurSync.IsNull())
+	})
+}
+
+func TestReadSyncMembers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Read", func(t *testing.T) {
+		pam := newPAMServer(t, map[string]http.HandlerFunc{
+			"GET " + safeMembersRoute: listSafeMembers("Administrator", "CONJURSYNC"),
+		})
+
+		data := safeResourceModel{EnableSecretsHubSync: types.BoolValue(true), EnableConjurSync: types.BoolValue(false)}
+		var diags diag.Diagnostics
+		readSyncMembers(ctx, pam, "Synced", &data, &diags)
+
+		require.False(t, diags.HasError(), diags)
+		assert.Equal(t, types.BoolValue(false), data.EnableSecretsHubSync)
+		assert.Equal(t, types.BoolValue(true), data.EnableConjurSync)
+	})
+
+	t.Run("NotSet", func(t *testing.T) {
+		// Members are not read when the attributes are not set, as for safes managed before they
+		// existed, so users who may not view the members can still read those safes
+		pam := newPAMServer(t, nil)
+
+		data := safeResourceModel{EnableSecretsHubSync: types.BoolNull(), EnableConjurSync: types.BoolNull()}
+		var diags diag.Diagnostics
+		readSyncMembers(ctx, pam, "Synced", &data, &diags)
+
+		assert.Empty(t, diags)
+		assert.True(t, data.EnableSecretsHubSync.IsNull())
+		assert.True(t, data.EnableConjurSync.IsNull())
+	})
+}
+
+func TestCheckSecretsHubMember(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Member", func(t *testing.T) {
+		pam := newPAMServer(t, map[string]http.HandlerFunc{
+			"GET " + safeMembersRoute: listSafeMembers("secretshub"),
+		})
+
+		var diags diag.Diagnostics
+		checkSecretsHubMember(ctx, pam, "Synced", &diags)
+		assert.Empty(t, diags)
+	})
+
+	t.Run("NotMember", func(t *testing.T) {
+		pam := newPAMServer(t, map[string]http.HandlerFunc{
+			"GET " + safeMembersRoute: listSafeMembers("Administrator"),
+		})
+
+		var diags diag.Diagnostics
+		checkSecretsHubMember(ctx, pam, "Synced", &diags)
+		require.True(t, diags.HasError())
+		assert.Equal(t, "Secrets Hub is not a safe member", diags.Errors()[0].Summary())
+		assert.Contains(t, diags.Errors()[0].Detail(), "enable_secrets_hub_sync = true")
+	})
+
+	t.Run("ListFails", func(t *testing.T) {
+		// The provider user may not be allowed to view the members of the safe
+		pam := newPAMServer(t, map[string]http.HandlerFunc{
+			"GET " + safeMembersRoute: respondStatus(http.StatusForbidden),
+		})
+
+		var diags diag.Diagnostics
+		checkSecretsHubMember(ctx, pam, "Synced", &diags)
+		assert.False(t, diags.HasError())
+		assert.Len(t, diags.Warnings(), 1)
+	})
+}
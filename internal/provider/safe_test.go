@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// safeSchema returns the schema of the safe resource.
+func safeSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+
+	schemaResp := resource.SchemaResponse{}
+	NewSafeResource().Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), schemaResp.Diagnostics)
+	return schemaResp
+}
+
+// safeValue returns the value of a safe resource holding data, for use as a plan or state.
+func safeValue(t *testing.T, data safeResourceModel) tftypes.Value {
+	t.Helper()
+
+	state := tfsdk.State{Schema: safeSchema(t).Schema}
+	state.Raw = tftypes.NewValue(state.Schema.Type().TerraformType(context.Background()), nil)
+	require.False(t, state.Set(context.Background(), &data).HasError())
+	return state.Raw
+}
+
+// safeModel returns a configured safe, whose computed attributes are unknown as in a plan.
+func safeModel() safeResourceModel {
+	return safeResourceModel{
+		Backend:              types.StringValue(backendPrivilegeCloud),
+		RetentionDays:        types.Int64Unknown(),
+		RetentionVersions:    types.Int64Unknown(),
+		PurgeEnabled:         types.BoolUnknown(),
+		CPM:                  types.StringUnknown(),
+		Name:                 types.StringValue("Databases"),
+		Description:          types.StringNull(),
+		Location:             types.StringUnknown(),
+		ID:                   types.StringUnknown(),
+		IDNUM:                types.Int64Unknown(),
+		LastUpdated:          types.StringUnknown(),
+		SeedMember:           types.StringValue("admins"),
+		SeedMType:            types.StringValue("group"),
+		PermType:             types.StringValue("full"),
+		EnableOLAC:           types.BoolUnknown(),
+		EnableSecretsHubSync: types.BoolNull(),
+		EnableConjurSync:     types.BoolNull(),
+	}
+}
+
+// respondSafe returns a handler that answers with the safe sent in the request.
+func respondSafe(t *testing.T, status int, sent *map[string]any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(sent))
+		urlID, name := "Databases", "Databases"
+		writeJSON(w, status, cybrapi.SafeData{URLID: &urlID, Name: &name})
+	}
+}
+
+func TestSafeUpdateOLAC(t *testing.T) {
+	ctx := context.Background()
+	schema := safeSchema(t).Schema
+
+	state := safeModel()
+	state.ID = types.StringValue("Databases")
+	state.EnableOLAC = types.BoolValue(true)
+
+	for name, tc := range map[string]struct {
+		planned types.Bool
+		want    any
+	}{
+		// Unknown values are never sent, so that a safe with OLAC enabled is not updated to disable it
+		"NotConfigured": {planned: types.BoolUnknown(), want: nil},
+		"Configured":    {planned: types.BoolValue(true), want: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var sent map[string]any
+			pam := newPAMServer(t, map[string]http.HandlerFunc{
+				"PUT /PasswordVault/API/Safes/{safe}":                  respondSafe(t, http.StatusOK, &sent),
+				"PUT /PasswordVault/API/Safes/{safe}/Members/{member}": func(w http.ResponseWriter, _ *http.Request) { writeJSON(w, http.StatusOK, cybrapi.Member{}) },
+			})
+			r := &safeResource{api: &cybrapi.API{PamAPI: pam}, defaultBackend: backendPrivilegeCloud}
+
+			plan := safeModel()
+			plan.ID = state.ID
+			plan.EnableOLAC = tc.planned
+			req := resource.UpdateRequest{
+				Plan:  tfsdk.Plan{Schema: schema, Raw: safeValue(t, plan)},
+				State: tfsdk.State{Schema: schema, Raw: safeValue(t, state)},
+			}
+			resp := resource.UpdateResponse{State: tfsdk.State{Schema: schema, Raw: req.State.Raw}}
+
+			r.Update(ctx, req, &resp)
+
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.Equal(t, tc.want, sent["enableOLAC"])
+		})
+	}
+}
+
+func TestSafeCreateSyncMemberFails(t *testing.T) {
+	ctx := context.Background()
+	schema := safeSchema(t).Schema
+
+	var sent map[string]any
+	pam := newPAMServer(t, map[string]http.HandlerFunc{
+		"GET /PasswordVault/API/Safes/{safe}":         respondStatus(http.StatusNotFound),
+		"POST /PasswordVault/API/Safes":               respondSafe(t, http.StatusCreated, &sent),
+		"GET /PasswordVault/API/Safes/{safe}/Members": listSafeMembers("admins"),
+		"POST /PasswordVault/API/Safes/{safe}/Members": func(w http.ResponseWriter, r *http.Request) {
+			var member cybrapi.Member
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&member))
+			if *member.Member == cybrapi.SecretsHubMember {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			writeJSON(w, http.StatusCreated, member)
+		},
+	})
+	r := &safeResource{api: &cybrapi.API{PamAPI: pam}, defaultBackend: backendPrivilegeCloud}
+
+	plan := safeModel()
+	plan.EnableSecretsHubSync = types.BoolValue(true)
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: schema, Raw: safeValue(t, plan)}}
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), nil)}}
+
+	r.Create(ctx, req, &resp)
+
+	// The safe was created, so it is tracked although its member could not be added
+	require.True(t, resp.Diagnostics.HasError())
+	var state safeResourceModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	assert.Equal(t, types.StringValue("Databases"), state.ID)
+	assert.Equal(t, types.BoolValue(false), state.EnableSecretsHubSync)
+}
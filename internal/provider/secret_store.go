@@ -0,0 +1,108 @@
+// Package provider implements the SecretHub provider for Terraform.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// validateSecretStoreConnection asks SecretsHub to test the connection to a secret store
+// and records an error diagnostic with the service's message when the test fails.
+func validateSecretStoreConnection(ctx context.Context, api cybrapi.SecretsHubAPI, storeID string, diags *diag.Diagnostics) {
+	tflog.Info(ctx, fmt.Sprintf("Testing connection to secret store %s", storeID))
+
+	status, err := api.TestSecretStoreConnection(ctx, storeID)
+	if err != nil {
+		diags.AddError("Error testing secret store connection", err.Error())
+		return
+	}
+
+	if !status.IsConnected() {
+		message := "no diagnostic message returned by Secrets Hub"
+		if status.Message != nil && *status.Message != "" {
+			message = *status.Message
+		}
+
+		diags.AddError("Secret store connection failed",
+			fmt.Sprintf("Secrets Hub could not connect to secret store %s (status %s): %s", storeID, *status.Status, message))
+	}
+}
+
+// findSecretStoreByName returns the secret store with the given name, or nil if there is none.
+func findSecretStoreByName[T cybrapi.AwsAsmData | cybrapi.AzureAkvData | cybrapi.GcpData](stores *cybrapi.SecretStoresOutput[T], name string) *cybrapi.SecretStoreOutput[T] {
+	for _, store := range stores.SecretStores {
+		if store.Name != nil && *store.Name == name {
+			return store
+		}
+	}
+	return nil
+}
+
+// addSecretStoreExistsError reports that a secret store with the configured name already exists
+// and explains how to bring it under Terraform management.
+func addSecretStoreExistsError(diags *diag.Diagnostics, resourceType string, name string, storeID string) {
+	diags.AddError("Secret store already exists",
+		fmt.Sprintf("A secret store named %q already exists with ID %s. Import it with `terraform import %s.<name> %s`, "+
+			"or set `adopt_existing = true` to manage the existing secret store.", name, storeID, resourceType, storeID))
+}
+
+// adoptSecretStore reads an existing secret store and, when it has drifted from the desired
+// configuration, updates it. Fields listed in immutable cannot be corrected by an update.
+// Stores whose input holds credentials that cannot be read back are updated even when no
+// drift is found, since the configured credentials may differ from the live ones.
+func adoptSecretStore[T cybrapi.AwsAsmData | cybrapi.AzureAkvData | cybrapi.GcpData](
+	ctx context.Context,
+	storeID string,
+	desired cybrapi.SecretStoreInput[T],
+	get func(context.Context, string) (*cybrapi.SecretStoreOutput[T], error),
+	update func(context.Context, string) (*cybrapi.SecretStoreOutput[T], error),
+	writeOnlyCredentials bool,
+	diags *diag.Diagnostics,
+	immutable ...string,
+) *cybrapi.SecretStoreOutput[T] {
+	live, err := get(ctx, storeID)
+	if err != nil {
+		diags.AddError("Error reading existing secret store", err.Error())
+		return nil
+	}
+
+	drift, err := cybrapi.SecretStoreDrift(live, desired)
+	if err != nil {
+		diags.AddError("Error comparing existing secret store", err.Error())
+		return nil
+	}
+
+	if len(drift) == 0 && !writeOnlyCredentials {
+		tflog.Info(ctx, fmt.Sprintf("Adopting existing secret store %s, configuration matches", storeID))
+		return live
+	}
+
+	for _, field := range drift {
+		if slices.Contains(immutable, field) {
+			diags.AddError("Cannot adopt existing secret store",
+				fmt.Sprintf("Secret store %s differs from the configuration in %s, which cannot be changed.", storeID, field))
+			return nil
+		}
+	}
+
+	if len(drift) == 0 {
+		tflog.Info(ctx, fmt.Sprintf("Adopting existing secret store %s, updating its credentials", storeID))
+	} else {
+		tflog.Info(ctx, fmt.Sprintf("Adopting existing secret store %s, updating drifted fields: %s", storeID, strings.Join(drift, ", ")))
+	}
+
+	output, err := update(ctx, storeID)
+	if err != nil {
+		diags.AddError("Error updating existing secret store", err.Error())
+		return nil
+	}
+
+	return output
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdoptSecretStore(t *testing.T) {
+	ctx := context.Background()
+	name, vaultURL, secret := "akv", "https://vault.azure.net", "rotated"
+
+	desired := cybrapi.SecretStoreInput[cybrapi.AzureAkvData]{
+		Name: &name,
+		Data: &cybrapi.AzureAkvData{AzureVaultURL: &vaultURL, AppClientSecret: &secret},
+	}
+	// The client secret is never returned, so the live store matches the configuration
+	live := &cybrapi.SecretStoreOutput[cybrapi.AzureAkvData]{
+		ID:   "store-1",
+		Name: &name,
+		Data: &cybrapi.AzureAkvData{AzureVaultURL: &vaultURL},
+	}
+	get := func(context.Context, string) (*cybrapi.SecretStoreOutput[cybrapi.AzureAkvData], error) {
+		return live, nil
+	}
+
+	for _, tc := range []struct {
+		name                 string
+		writeOnlyCredentials bool
+		updated              bool
+	}{
+		{name: "MatchingConfiguration", writeOnlyCredentials: false, updated: false},
+		{name: "WriteOnlyCredentials", writeOnlyCredentials: true, updated: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			updated := false
+			update := func(context.Context, string) (*cybrapi.SecretStoreOutput[cybrapi.AzureAkvData], error) {
+				updated = true
+				return live, nil
+			}
+
+			output := adoptSecretStore(ctx, live.ID, desired, get, update, tc.writeOnlyCredentials, &diags)
+			require.False(t, diags.HasError(), "%v", diags)
+			assert.Equal(t, live, output)
+			assert.Equal(t, tc.updated, updated)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// setStrings returns the elements of a set of strings, or nil if the set is null or unknown.
+func setStrings(ctx context.Context, set types.Set, diags *diag.Diagnostics) []string {
+	if set.IsNull() || set.IsUnknown() {
+		return nil
+	}
+
+	var values []string
+	diags.Append(set.ElementsAs(ctx, &values, false)...)
+	sort.Strings(values)
+	return values
+}
+
+// stringSet returns a set of the given strings.
+func stringSet(values []string) types.Set {
+	elements := make([]attr.Value, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, types.StringValue(value))
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
+
+// stringList returns a list of the given strings.
+func stringList(values []string) types.List {
+	elements := make([]attr.Value, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, types.StringValue(value))
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
+// mapStrings returns the elements of a map of strings, or an empty map if the map is null or
+// unknown.
+func mapStrings(m types.Map) map[string]string {
+	values := map[string]string{}
+	for key, value := range m.Elements() {
+		if s, ok := value.(types.String); ok && !s.IsNull() && !s.IsUnknown() {
+			values[key] = s.ValueString()
+		}
+	}
+	return values
+}
+
+// stringMap returns a map of the given strings.
+func stringMap(values map[string]string) types.Map {
+	elements := make(map[string]attr.Value, len(values))
+	for key, value := range values {
+		elements[key] = types.StringValue(value)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
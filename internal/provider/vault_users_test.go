@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	cybrapi "github.com/cyberark/terraform-provider-cyberark/internal/cyberark"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpiryDateValue(t *testing.T) {
+	expiry := int64(1767225600)
+	none := int64(-1)
+
+	tests := []struct {
+		name       string
+		prior      types.String
+		expiryDate *int64
+		expected   types.String
+	}{
+		{
+			name:       "NoExpiry",
+			prior:      types.StringNull(),
+			expiryDate: nil,
+			expected:   types.StringNull(),
+		},
+		{
+			name:       "NegativeExpiry",
+			prior:      types.StringNull(),
+			expiryDate: &none,
+			expected:   types.StringNull(),
+		},
+		{
+			name:       "UTC",
+			prior:      types.StringNull(),
+			expiryDate: &expiry,
+			expected:   types.StringValue("2026-01-01T00:00:00Z"),
+		},
+		{
+			name:       "SameInstantKept",
+			prior:      types.StringValue("2026-01-01T02:00:00+02:00"),
+			expiryDate: &expiry,
+			expected:   types.StringValue("2026-01-01T02:00:00+02:00"),
+		},
+		{
+			name:       "Changed",
+			prior:      types.StringValue("2025-01-01T00:00:00Z"),
+			expiryDate: &expiry,
+			expected:   types.StringValue("2026-01-01T00:00:00Z"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, expiryDateValue(tt.prior, tt.expiryDate))
+		})
+	}
+}
+
+func TestVaultUserFromModel(t *testing.T) {
+	ctx := context.Background()
+	var diags diag.Diagnostics
+
+	user := vaultUserFromModel(ctx, vaultUserModel{
+		Username:              types.StringValue("jdoe"),
+		UserType:              types.StringUnknown(),
+		InitialPassword:       types.StringValue("Cyberark1"),
+		Location:              types.StringValue("\\Applications"),
+		ExpiryDate:            types.StringValue("2026-01-01T00:00:00Z"),
+		VaultAuthorization:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue("AuditUsers"), types.StringValue("AddSafes")}),
+		AuthenticationMethods: types.SetUnknown(types.StringType),
+		EnableUser:            types.BoolValue(true),
+		ChangePassOnNextLogon: types.BoolUnknown(),
+		PasswordNeverExpires:  types.BoolValue(false),
+		Description:           types.StringNull(),
+		Email:                 types.StringValue("jdoe@example.com"),
+		FirstName:             types.StringNull(),
+		LastName:              types.StringUnknown(),
+	}, &diags)
+	require.False(t, diags.HasError(), diags)
+
+	assert.Equal(t, "jdoe", *user.Username)
+	assert.Nil(t, user.UserType)
+	assert.Equal(t, "\\Applications", *user.Location)
+	assert.Equal(t, int64(1767225600), *user.ExpiryDate)
+	assert.Equal(t, []string{"AddSafes", "AuditUsers"}, user.VaultAuthorization)
+	assert.Nil(t, user.AuthenticationMethod)
+	assert.True(t, *user.EnableUser)
+	assert.Nil(t, user.ChangePassOnNextLogon)
+	assert.False(t, *user.PasswordNeverExpires)
+	assert.Nil(t, user.Description)
+	assert.Equal(t, "jdoe@example.com", *user.Internet.BusinessEmail)
+	assert.Nil(t, user.PersonalDetails)
+}
+
+func TestFindVaultGroupMember(t *testing.T) {
+	alice, bob := "Alice", "bob@example.com"
+	group := &cybrapi.VaultGroup{
+		Members: []*cybrapi.VaultGroupMember{{Username: &alice}, {}, {Username: &bob}},
+	}
+
+	assert.Equal(t, &alice, findVaultGroupMember(group, "alice").Username)
+	assert.Equal(t, &bob, findVaultGroupMember(group, "bob@example.com").Username)
+	assert.Nil(t, findVaultGroupMember(group, "carol"))
+	assert.Nil(t, findVaultGroupMember(&cybrapi.VaultGroup{}, "alice"))
+}
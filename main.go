@@ -4,6 +4,7 @@ package main
 import (
 	"context"
 	"log"
+	"time"
 
 	"github.com/cyberark/terraform-provider-cyberark/internal/provider"
 
@@ -17,13 +18,28 @@ var (
 	version = "dev"
 )
 
+// shutdownTimeout bounds logging off vault sessions after Terraform stops the provider. Terraform
+// kills provider processes which have not exited 2 seconds after being stopped, so logoffs must
+// finish well within that window.
+const shutdownTimeout = 1500 * time.Millisecond
+
 func main() {
 	opts := providerserver.ServeOpts{
 		Address: "registry.terraform.io/cyberark/cyberark",
 		Debug:   false,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	factory, shutdown := provider.NewWithShutdown(version)
+
+	err := providerserver.Serve(context.Background(), factory, opts)
+
+	// Log off vault sessions once Terraform has stopped the provider
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	if shutdownErr := shutdown(ctx); shutdownErr != nil {
+		log.Println(shutdownErr.Error())
+	}
+	cancel()
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}